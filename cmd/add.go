@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/econron/wamon/internal/db"
+	"github.com/econron/wamon/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// templateLookbackDays is how far back templates see entries (yesterday's entries, open TODOs)
+const templateLookbackDays = 7
+
+var addTemplate string
+
+// addCmd records a new entry, optionally starting from a named template
+var addCmd = &cobra.Command{
+	Use:  "add",
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		runInteractiveJournal(addTemplate)
+	},
+}
+
+// recentEntries returns the entries recorded in the days before entry, for use in templates
+func recentEntries(database db.DB, entry *models.Entry) ([]*models.Entry, error) {
+	created := entry.CreatedAt.Local()
+	dayStart := time.Date(created.Year(), created.Month(), created.Day(), 0, 0, 0, 0, time.Local)
+	return database.GetEntriesBetween(dayStart.AddDate(0, 0, -templateLookbackDays), entry.CreatedAt)
+}
+
+func init() {
+	rootCmd.AddCommand(addCmd)
+
+	addCmd.Flags().StringVar(&addTemplate, "template", "", "")
+	addCmd.Flags().BoolVar(&multiEntry, "multi", false, "")
+
+	localizeCommand(addCmd, "add")
+	localizeFlag(addCmd.Flags(), "template", "add.template_flag")
+	localizeFlag(addCmd.Flags(), "multi", "flag.multi")
+
+	addCmd.RegisterFlagCompletionFunc("template", completeTemplates)
+}
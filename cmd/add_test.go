@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/econron/wamon/internal/db"
+	"github.com/econron/wamon/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecentEntries(t *testing.T) {
+	testDBPath, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	database, err := db.NewDB(testDBPath)
+	assert.NoError(t, err)
+	defer database.Close()
+
+	now := time.Date(2025, 4, 10, 12, 0, 0, 0, time.Local)
+	for _, e := range []*models.Entry{
+		{ID: "old", CreatedAt: now.AddDate(0, 0, -templateLookbackDays-1)},
+		{ID: "week", CreatedAt: now.AddDate(0, 0, -templateLookbackDays).Add(-time.Hour)},
+		{ID: "yesterday", CreatedAt: now.AddDate(0, 0, -1)},
+		{ID: "later", CreatedAt: now.Add(time.Hour)},
+	} {
+		e.Category = models.Research
+		e.Satisfaction = 3
+		assert.NoError(t, database.SaveEntry(e))
+	}
+
+	recent, err := recentEntries(database, &models.Entry{ID: "now", CreatedAt: now})
+	assert.NoError(t, err)
+	var ids []string
+	for _, e := range recent {
+		ids = append(ids, e.ID)
+	}
+	assert.Equal(t, []string{"week", "yesterday"}, ids)
+}
+
+func TestCompleteTemplates(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	dir := filepath.Join(home, ".wamon", "templates")
+	assert.NoError(t, os.MkdirAll(dir, 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "standup.md"), []byte(""), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "research.md"), []byte(""), 0644))
+
+	completions, _ := completeTemplates(addCmd, nil, "st")
+	assert.Equal(t, []string{"standup"}, completions)
+}
@@ -0,0 +1,241 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/econron/wamon/internal/db"
+	"github.com/econron/wamon/internal/i18n"
+	"github.com/econron/wamon/internal/models"
+	"github.com/econron/wamon/internal/query"
+	"github.com/spf13/cobra"
+)
+
+var bulkFilter string
+var bulkSet []string
+var bulkAddTags []string
+var bulkRemoveTags []string
+var bulkDryRun bool
+
+// bulkFields are the fields that can be changed with --set
+var bulkFields = []string{"category", "satisfaction", "research_topic", "program_title"}
+
+// bulkEdit holds the changes applied to every matching entry; nil fields are left as they are
+type bulkEdit struct {
+	category      *models.Category
+	satisfaction  *int
+	researchTopic *string
+	programTitle  *string
+	addTags       []string
+	removeTags    []string
+}
+
+// bulkChange is an entry with the edit applied and a description of each change
+type bulkChange struct {
+	entry   *models.Entry
+	changes []string
+}
+
+// bulkCmd changes every entry that matches a filter in one go
+var bulkCmd = &cobra.Command{
+	Use:  "bulk",
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		q, err := query.Parse(bulkFilter)
+		if err != nil {
+			fmt.Println(i18n.T("error.invalid_filter", err))
+			return
+		}
+		if q.Empty() {
+			fmt.Println(i18n.T("bulk.filter_required"))
+			return
+		}
+
+		edit, err := parseBulkEdit(bulkSet, bulkAddTags, bulkRemoveTags)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		// Initialize database
+		database, err := db.NewDB(dbPath)
+		if err != nil {
+			fmt.Println(i18n.T("error.db_init", err, dbPath))
+			return
+		}
+		defer database.Close()
+
+		// Run the configured hooks when entries change
+		database, err = withHooks(database, printHookWarning)
+		if err != nil {
+			fmt.Println(i18n.T("error.config_load", err))
+			return
+		}
+
+		entries, err := database.GetAllEntries()
+		if err != nil {
+			fmt.Println(i18n.T("error.fetch", err))
+			return
+		}
+
+		matched := q.Filter(entries)
+		if len(matched) == 0 {
+			fmt.Println(i18n.T("bulk.no_match", q))
+			return
+		}
+
+		changes := planBulkEdit(matched, edit)
+		if len(changes) == 0 {
+			fmt.Println(i18n.T("bulk.nothing_to_change", len(matched)))
+			return
+		}
+
+		printBulkPreview(changes)
+
+		if bulkDryRun {
+			fmt.Println("\n" + i18n.T("bulk.dry_run", len(changes)))
+			return
+		}
+
+		updated := make([]*models.Entry, len(changes))
+		for i, change := range changes {
+			updated[i] = change.entry
+		}
+		if err := database.UpdateEntries(updated); err != nil {
+			fmt.Println(i18n.T("edit.update_error", err))
+			return
+		}
+
+		fmt.Println("\n" + i18n.T("bulk.updated", len(changes)))
+	},
+}
+
+// parseBulkEdit validates the --set, --add-tag and --remove-tag values
+func parseBulkEdit(set, addTags, removeTags []string) (*bulkEdit, error) {
+	edit := &bulkEdit{
+		addTags:    models.NormalizeTags(addTags),
+		removeTags: models.NormalizeTags(removeTags),
+	}
+
+	for _, assignment := range set {
+		field, value, ok := strings.Cut(assignment, "=")
+		if !ok {
+			return nil, errors.New(i18n.T("bulk.invalid_set", assignment))
+		}
+		field = strings.TrimSpace(field)
+		value = strings.TrimSpace(value)
+
+		switch field {
+		case "category":
+			category, ok := models.ParseCategory(value)
+			if !ok {
+				return nil, errors.New(invalidCategoryMessage())
+			}
+			edit.category = &category
+		case "satisfaction":
+			satisfaction, err := strconv.Atoi(value)
+			if err != nil || satisfaction < 1 || satisfaction > 5 {
+				return nil, errors.New(i18n.T("bulk.invalid_satisfaction", value))
+			}
+			edit.satisfaction = &satisfaction
+		case "research_topic":
+			edit.researchTopic = &value
+		case "program_title":
+			edit.programTitle = &value
+		default:
+			return nil, errors.New(i18n.T("bulk.unknown_field", field, strings.Join(bulkFields, ", ")))
+		}
+	}
+
+	if len(set) == 0 && len(edit.addTags) == 0 && len(edit.removeTags) == 0 {
+		return nil, errors.New(i18n.T("bulk.no_changes"))
+	}
+	return edit, nil
+}
+
+// planBulkEdit applies the edit to copies of the entries and keeps the ones that change
+func planBulkEdit(entries []*models.Entry, edit *bulkEdit) []bulkChange {
+	var changes []bulkChange
+	for _, entry := range entries {
+		updated := *entry
+		updated.Tags = append([]string(nil), entry.Tags...)
+		if descriptions := edit.apply(&updated); len(descriptions) > 0 {
+			changes = append(changes, bulkChange{entry: &updated, changes: descriptions})
+		}
+	}
+	return changes
+}
+
+// apply changes the entry and describes each field that actually changed
+func (e *bulkEdit) apply(entry *models.Entry) []string {
+	var descriptions []string
+
+	if e.category != nil && entry.Category != *e.category {
+		descriptions = append(descriptions, i18n.T("bulk.change.category", i18n.CategoryLabel(entry.Category), i18n.CategoryLabel(*e.category)))
+		entry.Category = *e.category
+	}
+	if e.satisfaction != nil && entry.Satisfaction != *e.satisfaction {
+		descriptions = append(descriptions, i18n.T("bulk.change.satisfaction", entry.Satisfaction, *e.satisfaction))
+		entry.Satisfaction = *e.satisfaction
+	}
+	if e.researchTopic != nil && entry.ResearchTopic != *e.researchTopic {
+		descriptions = append(descriptions, i18n.T("bulk.change.research_topic", entry.ResearchTopic, *e.researchTopic))
+		entry.ResearchTopic = *e.researchTopic
+	}
+	if e.programTitle != nil && entry.ProgramTitle != *e.programTitle {
+		descriptions = append(descriptions, i18n.T("bulk.change.program_title", entry.ProgramTitle, *e.programTitle))
+		entry.ProgramTitle = *e.programTitle
+	}
+
+	for _, tag := range e.addTags {
+		if !entry.HasTag(tag) {
+			descriptions = append(descriptions, i18n.T("bulk.change.add_tag", tag))
+			entry.Tags = append(entry.Tags, tag)
+		}
+	}
+	for _, tag := range e.removeTags {
+		if entry.HasTag(tag) {
+			descriptions = append(descriptions, i18n.T("bulk.change.remove_tag", tag))
+			var kept []string
+			for _, t := range entry.Tags {
+				if t != tag {
+					kept = append(kept, t)
+				}
+			}
+			entry.Tags = kept
+		}
+	}
+
+	return descriptions
+}
+
+// printBulkPreview prints a table of the entries that will change
+func printBulkPreview(changes []bulkChange) {
+	fmt.Printf("%-14s  %-16s  %s\n", "ID", i18n.T("bulk.column.date"), i18n.T("bulk.column.changes"))
+	fmt.Println(strings.Repeat("-", 60))
+	for _, change := range changes {
+		fmt.Printf("%-14s  %-16s  %s\n", change.entry.ID, formatDate(change.entry.CreatedAt), strings.Join(change.changes, ", "))
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(bulkCmd)
+
+	bulkCmd.Flags().StringVar(&bulkFilter, "filter", "", "")
+	bulkCmd.Flags().StringArrayVar(&bulkSet, "set", nil, "")
+	bulkCmd.Flags().StringSliceVar(&bulkAddTags, "add-tag", nil, "")
+	bulkCmd.Flags().StringSliceVar(&bulkRemoveTags, "remove-tag", nil, "")
+	bulkCmd.Flags().BoolVar(&bulkDryRun, "dry-run", false, "")
+
+	localizeCommand(bulkCmd, "bulk")
+	localizeFlag(bulkCmd.Flags(), "filter", "bulk.filter_flag")
+	localizeFlag(bulkCmd.Flags(), "set", "bulk.set_flag")
+	localizeFlag(bulkCmd.Flags(), "add-tag", "bulk.add_tag_flag")
+	localizeFlag(bulkCmd.Flags(), "remove-tag", "bulk.remove_tag_flag")
+	localizeFlag(bulkCmd.Flags(), "dry-run", "bulk.dry_run_flag")
+
+	bulkCmd.RegisterFlagCompletionFunc("add-tag", completeTags)
+	bulkCmd.RegisterFlagCompletionFunc("remove-tag", completeTags)
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/econron/wamon/internal/db"
+	"github.com/econron/wamon/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// setBulkFlags sets the bulk command flags and resets them when the test ends
+func setBulkFlags(t *testing.T, filter string, set, addTags, removeTags []string, dryRun bool) {
+	bulkFilter, bulkSet, bulkAddTags, bulkRemoveTags, bulkDryRun = filter, set, addTags, removeTags, dryRun
+	t.Cleanup(func() {
+		bulkFilter, bulkSet, bulkAddTags, bulkRemoveTags, bulkDryRun = "", nil, nil, nil, false
+	})
+}
+
+// saveBulkTestEntries stores three entries, two of them in the research category
+func saveBulkTestEntries(t *testing.T, testDBPath string) {
+	database, err := db.NewDB(testDBPath)
+	assert.NoError(t, err)
+	defer database.Close()
+
+	created := time.Date(2025, 4, 1, 9, 0, 0, 0, time.Local)
+	assert.NoError(t, database.SaveEntry(&models.Entry{ID: "20250401090000", Category: models.Research, ResearchTopic: "a", Satisfaction: 3, CreatedAt: created}))
+	assert.NoError(t, database.SaveEntry(&models.Entry{ID: "20250402090000", Category: models.Research, ResearchTopic: "b", Satisfaction: 3, Tags: []string{"x"}, CreatedAt: created.AddDate(0, 0, 1)}))
+	assert.NoError(t, database.SaveEntry(&models.Entry{ID: "20250403090000", Category: models.Programming, ProgramTitle: "c", Satisfaction: 3, CreatedAt: created.AddDate(0, 0, 2)}))
+}
+
+func TestBulkDryRun(t *testing.T) {
+	testDBPath, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+	saveBulkTestEntries(t, testDBPath)
+
+	setBulkFlags(t, "cat:research", []string{"category=programming"}, []string{"x"}, nil, true)
+	output := captureOutput(func() {
+		bulkCmd.Run(bulkCmd, []string{})
+	})
+	assert.Contains(t, output, "20250401090000")
+	assert.Contains(t, output, "カテゴリ: 調べ物 → プログラマ, タグ追加: x")
+	assert.NotContains(t, output, "20250403090000")
+	assert.Contains(t, output, "2件の記録が変更されます")
+
+	database, err := db.NewDB(testDBPath)
+	assert.NoError(t, err)
+	defer database.Close()
+	entries, err := database.GetEntriesByCategory(models.Research)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestBulkApply(t *testing.T) {
+	testDBPath, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+	saveBulkTestEntries(t, testDBPath)
+
+	setBulkFlags(t, "cat:research", []string{"category=programming"}, []string{"y"}, []string{"x"}, false)
+	output := captureOutput(func() {
+		bulkCmd.Run(bulkCmd, []string{})
+	})
+	assert.Contains(t, output, "2件の記録を更新しました")
+
+	database, err := db.NewDB(testDBPath)
+	assert.NoError(t, err)
+	defer database.Close()
+
+	entry, err := database.GetEntryByID("20250402090000")
+	assert.NoError(t, err)
+	assert.Equal(t, models.Programming, entry.Category)
+	assert.Equal(t, []string{"y"}, entry.Tags)
+
+	revisions, err := database.GetEntryRevisions("20250402090000")
+	assert.NoError(t, err)
+	assert.Len(t, revisions, 1)
+	assert.Equal(t, models.Research, revisions[0].Entry.Category)
+
+	// Entries that were not matched get no revision
+	revisions, err = database.GetEntryRevisions("20250403090000")
+	assert.NoError(t, err)
+	assert.Empty(t, revisions)
+}
+
+func TestBulkNothingToChange(t *testing.T) {
+	testDBPath, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+	saveBulkTestEntries(t, testDBPath)
+
+	setBulkFlags(t, "cat:programming", []string{"category=programming"}, nil, nil, false)
+	output := captureOutput(func() {
+		bulkCmd.Run(bulkCmd, []string{})
+	})
+	assert.Contains(t, output, "変更が必要な記録はありません")
+
+	setBulkFlags(t, "tag:none", []string{"satisfaction=5"}, nil, nil, false)
+	output = captureOutput(func() {
+		bulkCmd.Run(bulkCmd, []string{})
+	})
+	assert.Contains(t, output, "条件に一致する記録がありません")
+}
+
+func TestBulkInvalidArguments(t *testing.T) {
+	_, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	tests := []struct {
+		filter   string
+		set      []string
+		expected string
+	}{
+		{"", []string{"satisfaction=5"}, "--filter"},
+		{"color:red", []string{"satisfaction=5"}, "不明な項目です: color"},
+		{"tag:go", nil, "いずれかを指定してください"},
+		{"tag:go", []string{"satisfaction"}, "field=value"},
+		{"tag:go", []string{"satisfaction=9"}, "1から5"},
+		{"tag:go", []string{"category=cooking"}, "research_and_programming"},
+		{"tag:go", []string{"id=1"}, "変更できない項目です: id"},
+	}
+
+	for _, tt := range tests {
+		setBulkFlags(t, tt.filter, tt.set, nil, nil, false)
+		output := captureOutput(func() {
+			bulkCmd.Run(bulkCmd, []string{})
+		})
+		assert.Contains(t, output, tt.expected, tt.filter)
+	}
+}
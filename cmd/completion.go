@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/econron/wamon/internal/db"
+	"github.com/econron/wamon/internal/i18n"
+	"github.com/econron/wamon/internal/models"
+	"github.com/econron/wamon/internal/report"
+	"github.com/econron/wamon/internal/templates"
+	"github.com/spf13/cobra"
+)
+
+// completionPreviewLength is the maximum number of characters of the body shown next to an ID
+const completionPreviewLength = 40
+
+// completionCmd replaces cobra's default completion command so it can offer an install helper
+var completionCmd = &cobra.Command{
+	Use:  "completion",
+	Args: cobra.NoArgs,
+}
+
+// completionShells generate the completion script of each supported shell
+var completionShells = map[string]func(cmd *cobra.Command) error{
+	"bash": func(cmd *cobra.Command) error {
+		return cmd.Root().GenBashCompletionV2(cmd.OutOrStdout(), true)
+	},
+	"zsh": func(cmd *cobra.Command) error {
+		return cmd.Root().GenZshCompletion(cmd.OutOrStdout())
+	},
+	"fish": func(cmd *cobra.Command) error {
+		return cmd.Root().GenFishCompletion(cmd.OutOrStdout(), true)
+	},
+	"powershell": func(cmd *cobra.Command) error {
+		return cmd.Root().GenPowerShellCompletionWithDesc(cmd.OutOrStdout())
+	},
+}
+
+// completionInstallCmd writes the completion script to the shell's standard location
+var completionInstallCmd = &cobra.Command{
+	Use:       "install [bash|zsh|fish]",
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: []string{"bash", "zsh", "fish"},
+	Run: func(cmd *cobra.Command, args []string) {
+		shell := filepath.Base(os.Getenv("SHELL"))
+		if len(args) > 0 {
+			shell = args[0]
+		}
+
+		home, err := os.UserHomeDir()
+		if err != nil {
+			fmt.Println(i18n.T("completion.home_error", err))
+			return
+		}
+		path, ok := completionPath(shell, home)
+		if !ok {
+			fmt.Println(i18n.T("completion.unsupported_shell", shell))
+			return
+		}
+
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			fmt.Println(i18n.T("completion.write_error", err))
+			return
+		}
+		file, err := os.Create(path)
+		if err != nil {
+			fmt.Println(i18n.T("completion.write_error", err))
+			return
+		}
+		defer file.Close()
+
+		cmd.SetOut(file)
+		defer cmd.SetOut(nil)
+		if err := completionShells[shell](cmd); err != nil {
+			fmt.Println(i18n.T("completion.write_error", err))
+			return
+		}
+
+		fmt.Println(i18n.T("completion.installed", shell, path))
+		fmt.Println(i18n.T("completion.hint." + shell))
+	},
+}
+
+// completionPath returns where the completion script of a shell is loaded from automatically
+func completionPath(shell, home string) (string, bool) {
+	switch shell {
+	case "bash":
+		dataHome := os.Getenv("XDG_DATA_HOME")
+		if dataHome == "" {
+			dataHome = filepath.Join(home, ".local", "share")
+		}
+		return filepath.Join(dataHome, "bash-completion", "completions", "wamon"), true
+	case "zsh":
+		return filepath.Join(home, ".zfunc", "_wamon"), true
+	case "fish":
+		configHome := os.Getenv("XDG_CONFIG_HOME")
+		if configHome == "" {
+			configHome = filepath.Join(home, ".config")
+		}
+		return filepath.Join(configHome, "fish", "completions", "wamon.fish"), true
+	default:
+		return "", false
+	}
+}
+
+// completeEntryIDs completes entry IDs, showing the date and the start of the body as the description
+func completeEntryIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	// Completing only reads, so it never creates or changes the database
+	database, err := db.OpenReadOnly(dbPath)
+	if err != nil {
+		return nil, completionErrorDirective(err)
+	}
+	defer database.Close()
+
+	entries, err := database.GetAllEntries()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	var completions []string
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.ID, toComplete) {
+			completions = append(completions, entry.ID+"\t"+completionPreview(entry))
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completionErrorDirective completes nothing when there is no database yet and
+// reports other errors to the shell
+func completionErrorDirective(err error) cobra.ShellCompDirective {
+	if errors.Is(err, os.ErrNotExist) {
+		return cobra.ShellCompDirectiveNoFileComp
+	}
+	return cobra.ShellCompDirectiveError
+}
+
+// completing reports whether the shell is asking for completions. Anything
+// printed to stdout then would be taken as a completion.
+func completing() bool {
+	return len(os.Args) > 1 && (os.Args[1] == cobra.ShellCompRequestCmd || os.Args[1] == cobra.ShellCompNoDescRequestCmd)
+}
+
+// completionPreview is the one-line description of an entry shown by the shell
+func completionPreview(entry *models.Entry) string {
+	body := strings.Join(strings.Fields(entry.Body()), " ")
+	if runes := []rune(body); len(runes) > completionPreviewLength {
+		body = string(runes[:completionPreviewLength]) + "…"
+	}
+	return formatDate(entry.CreatedAt) + " " + body
+}
+
+// completeCategories completes category codes, described by their display names
+func completeCategories(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var completions []string
+	for _, category := range models.Categories() {
+		if strings.HasPrefix(string(category), toComplete) {
+			completions = append(completions, string(category)+"\t"+i18n.CategoryLabel(category))
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeTags completes the tags used in the database
+func completeTags(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	// Completing only reads, so it never creates or changes the database
+	database, err := db.OpenReadOnly(dbPath)
+	if err != nil {
+		return nil, completionErrorDirective(err)
+	}
+	defer database.Close()
+
+	tags, err := database.GetAllTags()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	var completions []string
+	for _, tag := range tags {
+		if strings.HasPrefix(tag, strings.ToLower(toComplete)) {
+			completions = append(completions, tag)
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeTemplates completes the names of the user templates
+func completeTemplates(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	names, err := templates.List(templates.Dir())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	var completions []string
+	for _, name := range names {
+		if strings.HasPrefix(name, toComplete) {
+			completions = append(completions, name)
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeLanguages completes the supported display languages
+func completeLanguages(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var completions []string
+	for _, lang := range i18n.Languages() {
+		completions = append(completions, string(lang))
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeReportPeriods completes the periods of the report command
+func completeReportPeriods(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var completions []string
+	for _, kind := range report.Kinds {
+		completions = append(completions, string(kind))
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	rootCmd.AddCommand(completionCmd)
+	completionCmd.AddCommand(completionInstallCmd)
+	localizeCommand(completionCmd, "completion")
+	localizeCommand(completionInstallCmd, "completion_install")
+
+	for _, shell := range []string{"bash", "zsh", "fish", "powershell"} {
+		generate := completionShells[shell]
+		shellCmd := &cobra.Command{
+			Use:  shell,
+			Args: cobra.NoArgs,
+			Run: func(cmd *cobra.Command, args []string) {
+				if err := generate(cmd); err != nil {
+					fmt.Println(i18n.T("completion.write_error", err))
+				}
+			},
+		}
+		completionCmd.AddCommand(shellCmd)
+		localizeCommand(shellCmd, "completion_"+shell)
+	}
+}
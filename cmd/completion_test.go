@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/econron/wamon/internal/db"
+	"github.com/econron/wamon/internal/models"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompleteEntryIDs(t *testing.T) {
+	testDBPath, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	database, err := db.NewDB(testDBPath)
+	assert.NoError(t, err)
+	created := time.Date(2025, 4, 1, 9, 30, 0, 0, time.Local)
+	assert.NoError(t, database.SaveEntry(&models.Entry{ID: "20250401093000", Category: models.Research, ResearchTopic: "Goの\nジェネリクス", Satisfaction: 4, CreatedAt: created}))
+	assert.NoError(t, database.SaveEntry(&models.Entry{ID: "20250501093000", Category: models.Programming, ProgramTitle: "CLI", Satisfaction: 3, CreatedAt: created.AddDate(0, 1, 0)}))
+	database.Close()
+
+	completions, directive := completeEntryIDs(editCmd, nil, "202504")
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+	assert.Equal(t, []string{"20250401093000\t2025-04-01 09:30 Goの ジェネリクス"}, completions)
+
+	completions, _ = completeEntryIDs(editCmd, nil, "")
+	assert.Len(t, completions, 2)
+
+	// Only the first argument is an ID
+	completions, _ = completeEntryIDs(editCmd, []string{"20250401093000"}, "")
+	assert.Empty(t, completions)
+}
+
+func TestCompletionPreviewIsTruncated(t *testing.T) {
+	entry := &models.Entry{ResearchTopic: strings.Repeat("あ", 50), CreatedAt: time.Date(2025, 4, 1, 9, 30, 0, 0, time.Local)}
+	assert.Equal(t, "2025-04-01 09:30 "+strings.Repeat("あ", completionPreviewLength)+"…", completionPreview(entry))
+}
+
+func TestCompleteCategories(t *testing.T) {
+	completions, directive := completeCategories(listCmd, nil, "")
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+	assert.Equal(t, []string{"research\t調べ物", "programming\tプログラマ", "research_and_programming\t調べてプログラマ"}, completions)
+
+	completions, _ = completeCategories(listCmd, nil, "research_")
+	assert.Equal(t, []string{"research_and_programming\t調べてプログラマ"}, completions)
+}
+
+func TestCompleteTags(t *testing.T) {
+	testDBPath, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	database, err := db.NewDB(testDBPath)
+	assert.NoError(t, err)
+	assert.NoError(t, database.SaveEntry(&models.Entry{ID: "1", Category: models.Research, ResearchTopic: "a", Satisfaction: 3, CreatedAt: time.Now(), Tags: []string{"go", "sqlite"}}))
+	assert.NoError(t, database.SaveEntry(&models.Entry{ID: "2", Category: models.Research, ResearchTopic: "b", Satisfaction: 3, CreatedAt: time.Now(), Tags: []string{"golang"}}))
+	database.Close()
+
+	completions, _ := completeTags(listCmd, nil, "GO")
+	assert.Equal(t, []string{"go", "golang"}, completions)
+}
+
+func TestCompletionWithoutDatabase(t *testing.T) {
+	testDBPath, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+	dbPath = filepath.Join(filepath.Dir(testDBPath), "missing", "wamon.db")
+
+	completions, directive := completeEntryIDs(editCmd, nil, "")
+	assert.Empty(t, completions)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+
+	completions, directive = completeTags(listCmd, nil, "")
+	assert.Empty(t, completions)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+
+	// Completing creates neither the directory nor the database
+	_, err := os.Stat(filepath.Dir(dbPath))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestCompletingPrintsNoWarnings(t *testing.T) {
+	args := os.Args
+	t.Cleanup(func() { os.Args = args })
+
+	os.Args = []string{"wamon", "list"}
+	assert.Equal(t, "warning\n", captureOutput(func() { printInitWarning("warning") }))
+
+	for _, request := range []string{cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd} {
+		os.Args = []string{"wamon", request, "show", ""}
+		assert.Empty(t, captureOutput(func() { printInitWarning("warning") }))
+	}
+}
+
+func TestCompletionPath(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", "")
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	path, ok := completionPath("bash", "/home/seal")
+	assert.True(t, ok)
+	assert.Equal(t, filepath.Join("/home/seal", ".local", "share", "bash-completion", "completions", "wamon"), path)
+
+	path, ok = completionPath("zsh", "/home/seal")
+	assert.True(t, ok)
+	assert.Equal(t, filepath.Join("/home/seal", ".zfunc", "_wamon"), path)
+
+	t.Setenv("XDG_CONFIG_HOME", "/xdg")
+	path, ok = completionPath("fish", "/home/seal")
+	assert.True(t, ok)
+	assert.Equal(t, filepath.Join("/xdg", "fish", "completions", "wamon.fish"), path)
+
+	_, ok = completionPath("tcsh", "/home/seal")
+	assert.False(t, ok)
+}
+
+func TestCompletionInstall(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	output := captureOutput(func() {
+		completionInstallCmd.Run(completionInstallCmd, []string{"fish"})
+	})
+	assert.Contains(t, output, "インストールしました")
+
+	data, err := os.ReadFile(filepath.Join(home, ".config", "fish", "completions", "wamon.fish"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "complete -c wamon")
+
+	output = captureOutput(func() {
+		completionInstallCmd.Run(completionInstallCmd, []string{"tcsh"})
+	})
+	assert.Contains(t, output, "対応していないシェルです")
+}
+
+func TestCompletionScript(t *testing.T) {
+	var buf bytes.Buffer
+	bashCmd, _, err := completionCmd.Find([]string{"bash"})
+	assert.NoError(t, err)
+	bashCmd.SetOut(&buf)
+	defer bashCmd.SetOut(nil)
+
+	bashCmd.Run(bashCmd, nil)
+	assert.Contains(t, buf.String(), "__start_wamon")
+}
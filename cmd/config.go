@@ -0,0 +1,352 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/econron/wamon/internal/config"
+	"github.com/econron/wamon/internal/i18n"
+	"github.com/econron/wamon/internal/interactive"
+	"github.com/spf13/cast"
+	"github.com/spf13/cobra"
+)
+
+// explainConfig makes config path show where the config file and each value come from
+var explainConfig bool
+
+// editConfigContent opens the config file content in an editor; tests replace it
+var editConfigContent = interactive.EditWithExternalEditor
+
+// configCmd groups the commands that show and change the config file
+var configCmd = &cobra.Command{
+	Use:  "config",
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+// configGetCmd prints the value of a key
+var configGetCmd = &cobra.Command{
+	Use:               "get KEY",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeConfigKeys,
+	Run: func(cmd *cobra.Command, args []string) {
+		field, ok := config.Lookup(args[0])
+		if !ok {
+			fmt.Println(&config.UnknownKeyError{Key: args[0]})
+			return
+		}
+		file, err := config.ReadFile(config.ActivePath())
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		value, ok := file.Get(field.Key)
+		if !ok {
+			value = field.Default
+		}
+		if value == nil {
+			fmt.Println(i18n.T("config.not_set", field.Key))
+			return
+		}
+		fmt.Println(displayConfigValue(field, value))
+	},
+}
+
+// configSetCmd validates a value and saves it to the config file
+var configSetCmd = &cobra.Command{
+	Use:               "set KEY VALUE",
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeConfigKeys,
+	Run: func(cmd *cobra.Command, args []string) {
+		field, ok := config.Lookup(args[0])
+		if !ok {
+			fmt.Println(&config.UnknownKeyError{Key: args[0]})
+			return
+		}
+		value, err := field.Parse(args[1])
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		path := config.ActivePath()
+		file, err := config.ReadFile(path)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		file.Set(field.Key, value)
+		if err := file.Write(); err != nil {
+			fmt.Println(i18n.T("config.write_error", err))
+			return
+		}
+		fmt.Println(i18n.T("config.set", field.Key, displayConfigValue(field, value), path))
+	},
+}
+
+// configUnsetCmd removes a key from the config file
+var configUnsetCmd = &cobra.Command{
+	Use:               "unset KEY",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeConfigKeys,
+	Run: func(cmd *cobra.Command, args []string) {
+		field, ok := config.Lookup(args[0])
+		if !ok {
+			fmt.Println(&config.UnknownKeyError{Key: args[0]})
+			return
+		}
+
+		path := config.ActivePath()
+		file, err := config.ReadFile(path)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		if !file.Unset(field.Key) {
+			fmt.Println(i18n.T("config.not_set", field.Key))
+			return
+		}
+		if err := file.Write(); err != nil {
+			fmt.Println(i18n.T("config.write_error", err))
+			return
+		}
+		fmt.Println(i18n.T("config.unset", field.Key, path))
+	},
+}
+
+// configListCmd prints every key of the schema with its value in the config file or its default
+var configListCmd = &cobra.Command{
+	Use:  "list",
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		file, err := config.ReadFile(config.ActivePath())
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		for _, field := range config.Fields() {
+			value, ok := file.Get(field.Key)
+			switch {
+			case ok:
+				fmt.Printf("%s = %s\n", field.Key, displayConfigValue(field, value))
+			case field.Default != nil:
+				fmt.Printf("%s = %s %s\n", field.Key, formatConfigValue(field.Default), i18n.T("config.default_mark"))
+			default:
+				fmt.Printf("%s =\n", field.Key)
+			}
+		}
+	},
+}
+
+// configEditCmd opens the config file in an editor and saves it once it is valid
+var configEditCmd = &cobra.Command{
+	Use:  "edit",
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		path := config.ActivePath()
+		data, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			fmt.Println(i18n.T("config.parse_error", path, err))
+			return
+		}
+
+		content := string(data)
+		for {
+			edited, err := editConfigContent(os.Stdout, content)
+			if err != nil {
+				fmt.Println(i18n.T("error.edit", err))
+				return
+			}
+			edited = interactive.StripErrorComments(edited)
+			if strings.TrimSpace(edited) == "" {
+				fmt.Println(i18n.T("config.edit_cancelled"))
+				return
+			}
+
+			// The file is saved as it was written, keeping the user's comments
+			errs := validateConfigContent(edited, config.Format(path))
+			if len(errs) == 0 {
+				if err := config.WriteRaw(path, []byte(edited)); err != nil {
+					fmt.Println(i18n.T("config.write_error", err))
+					return
+				}
+				fmt.Println(i18n.T("config.edited", path))
+				return
+			}
+
+			fmt.Println(i18n.T("config.edit_retry", len(errs)))
+			// Each problem is shown above its key; the others go at the top
+			lines := config.KeyLines([]byte(edited), config.Format(path))
+			fieldErrs := make([]interactive.FieldError, len(errs))
+			for i, err := range errs {
+				fieldErrs[i] = interactive.FieldError{Line: lines[config.ErrorKey(err)], Message: err.Error()}
+			}
+			content = interactive.AnnotateErrors(edited, fieldErrs)
+		}
+	},
+}
+
+// configPathCmd prints the path of the config file
+var configPathCmd = &cobra.Command{
+	Use:  "path",
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !explainConfig {
+			fmt.Println(config.ActivePath())
+			return
+		}
+		explainConfigResolution(config.Resolve(cfgFile))
+	},
+}
+
+// explainConfigResolution prints how the config file was found and where each value comes from
+func explainConfigResolution(resolution config.Resolution) {
+	fmt.Println(i18n.T("config.explain_file", resolution.Path, i18n.T("config.origin."+string(resolution.Origin))))
+
+	if len(resolution.Searched) > 0 {
+		fmt.Println()
+		fmt.Println(i18n.T("config.explain_searched"))
+		for _, candidate := range resolution.Searched {
+			mark := " "
+			if candidate.Path == resolution.Path && resolution.Exists {
+				mark = "*"
+			}
+			fmt.Printf("  %s %s (%s)\n", mark, candidate.Path, i18n.T("config.origin."+string(candidate.Origin)))
+		}
+	}
+
+	file, err := config.ReadFile(resolution.Path)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(i18n.T("config.explain_values"))
+	width := 0
+	for _, key := range config.Keys() {
+		width = max(width, len(key))
+	}
+	for _, setting := range config.Explain(file, configFlags()) {
+		source := setting.Source
+		switch source {
+		case "":
+			source = i18n.T("config.source.unset")
+		case config.SourceFile, config.SourceDefault:
+			source = i18n.T("config.source." + source)
+		}
+		value := ""
+		if setting.Value != nil {
+			value = displayConfigValue(setting.Field, setting.Value) + " "
+		}
+		fmt.Printf("  %-*s = %s(%s)\n", width, setting.Field.Key, value, source)
+	}
+}
+
+// configFlags returns the values of the config keys given as flags on the command line
+func configFlags() map[string]config.Flag {
+	flags := make(map[string]config.Flag)
+	if langFlag != "" {
+		flags["lang"] = config.Flag{Name: "--lang", Value: langFlag}
+	}
+	if flag := rootCmd.PersistentFlags().Lookup("db"); flag != nil && flag.Changed {
+		flags["database.path"] = config.Flag{Name: "--db", Value: dbPath}
+	}
+	return flags
+}
+
+// configMigrateSecretsCmd moves tokens written in the config file to a private file
+var configMigrateSecretsCmd = &cobra.Command{
+	Use:  "migrate-secrets",
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		path := config.ActivePath()
+		file, err := config.ReadFile(path)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		secretPath := config.SecretPath()
+		migrated, err := config.MigrateSecrets(file, secretPath)
+		if err != nil {
+			fmt.Println(i18n.T("config.write_error", err))
+			return
+		}
+		if !migrated {
+			fmt.Println(i18n.T("config.nothing_to_migrate", path))
+			return
+		}
+		fmt.Println(i18n.T("config.migrated", secretPath, path))
+	},
+}
+
+// validateConfigContent parses the content of a config file and checks it against the schema
+func validateConfigContent(content, format string) []error {
+	values, err := config.Parse([]byte(content), format)
+	if err != nil {
+		return []error{err}
+	}
+	return config.Validate(values)
+}
+
+// displayConfigValue formats a value for output, redacting secrets
+func displayConfigValue(field config.Field, value interface{}) string {
+	if field.Secret {
+		return config.Redact(formatConfigValue(value))
+	}
+	return formatConfigValue(value)
+}
+
+// formatConfigValue formats a value the way it is given to config set
+func formatConfigValue(value interface{}) string {
+	switch v := value.(type) {
+	case []string, []interface{}:
+		return strings.Join(cast.ToStringSlice(v), ",")
+	case map[string]interface{}:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// completeConfigKeys completes the keys of the config schema
+func completeConfigKeys(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var keys []string
+	for _, field := range config.Fields() {
+		if strings.HasPrefix(field.Key, toComplete) {
+			keys = append(keys, field.Key+"\t"+field.Description())
+		}
+	}
+	return keys, cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configGetCmd, configSetCmd, configUnsetCmd, configListCmd, configEditCmd, configPathCmd, configMigrateSecretsCmd)
+
+	localizeCommand(configCmd, "config")
+	localizeCommand(configGetCmd, "config_get")
+	localizeCommand(configSetCmd, "config_set")
+	localizeCommand(configUnsetCmd, "config_unset")
+	localizeCommand(configListCmd, "config_list")
+	localizeCommand(configEditCmd, "config_edit")
+	localizeCommand(configPathCmd, "config_path")
+	localizeCommand(configMigrateSecretsCmd, "config_migrate_secrets")
+
+	configPathCmd.Flags().BoolVar(&explainConfig, "explain", false, "")
+	localizeFlag(configPathCmd.Flags(), "explain", "flag.explain")
+}
@@ -6,28 +6,19 @@ import (
 	"time"
 
 	"github.com/econron/wamon/internal/db"
+	"github.com/econron/wamon/internal/i18n"
 	"github.com/spf13/cobra"
 )
 
 // exportCmd represents the export command
 var exportCmd = &cobra.Command{
-	Use:   "export [FILE]",
-	Short: "全ての記録をJSON形式でエクスポート",
-	Long: `記録した全てのエントリをJSON形式でエクスポートします。
-エクスポートされたファイルは、1行につき1つのJSONオブジェクトの形式で保存されます。
-ファイル名が指定されない場合は、カレントディレクトリにwamon_export.jsonという名前で保存されます。
-
-例:
-  $ wamon export
-  $ wamon export my_records.json
-  $ wamon export --since 24h
-  $ wamon export my_records.json --since 168h`,
+	Use:  "export [FILE]",
 	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		// Initialize database
 		database, err := db.NewDB(dbPath)
 		if err != nil {
-			fmt.Printf("データベースの初期化エラー: %v\nデータベースパス: %s を確認してください。\n", err, dbPath)
+			fmt.Println(i18n.T("error.db_init", err, dbPath))
 			return
 		}
 		defer database.Close()
@@ -48,7 +39,7 @@ var exportCmd = &cobra.Command{
 			// Parse duration
 			duration, err := time.ParseDuration(sinceStr)
 			if err != nil {
-				fmt.Printf("指定された期間の形式が不正です: %v\n", err)
+				fmt.Println(i18n.T("error.invalid_since", err))
 				return
 			}
 
@@ -58,14 +49,14 @@ var exportCmd = &cobra.Command{
 			// Export entries since the specified time
 			err = database.ExportEntriesSince(filePath, since)
 			if err != nil {
-				fmt.Printf("エクスポートエラー: %v\n", err)
+				fmt.Println(i18n.T("export.error", err))
 				return
 			}
 
 			// Get entries to count them
 			entries, err := database.GetEntriesSince(since)
 			if err != nil {
-				fmt.Printf("エントリ数の取得エラー: %v\n", err)
+				fmt.Println(i18n.T("export.count_error", err))
 				return
 			}
 			count = len(entries)
@@ -73,26 +64,26 @@ var exportCmd = &cobra.Command{
 			// Export all entries
 			err = database.ExportEntries(filePath)
 			if err != nil {
-				fmt.Printf("エクスポートエラー: %v\n", err)
+				fmt.Println(i18n.T("export.error", err))
 				return
 			}
 
 			// Get entry count
 			count, err = database.GetEntryCount()
 			if err != nil {
-				fmt.Printf("エントリ数の取得エラー: %v\n", err)
+				fmt.Println(i18n.T("export.count_error", err))
 				return
 			}
 		}
 
-		fmt.Printf("%d件のエントリを %s にエクスポートしました\n", count, filePath)
+		fmt.Println(i18n.T("export.done", count, filePath))
 
 		// Display the file path
 		absPath, err := os.Getwd()
 		if err == nil {
 			if filePath[0] != '/' && filePath[0] != '~' {
 				// If it's a relative path, show the absolute path
-				fmt.Printf("ファイル: %s/%s\n", absPath, filePath)
+				fmt.Println(i18n.T("export.file", absPath, filePath))
 			}
 		}
 	},
@@ -102,5 +93,8 @@ func init() {
 	rootCmd.AddCommand(exportCmd)
 
 	// Add since flag
-	exportCmd.Flags().String("since", "", "指定した期間分のエントリのみエクスポート (例: 24h, 168h)")
+	exportCmd.Flags().String("since", "", "")
+
+	localizeCommand(exportCmd, "export")
+	localizeFlag(exportCmd.Flags(), "since", "export.since_flag")
 }
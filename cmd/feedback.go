@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/econron/wamon/internal/art"
+	"github.com/econron/wamon/internal/config"
+	"github.com/econron/wamon/internal/db"
+	"github.com/econron/wamon/internal/feedback"
+	"github.com/econron/wamon/internal/i18n"
+	"github.com/econron/wamon/internal/interactive"
+	"github.com/econron/wamon/internal/models"
+	"golang.org/x/term"
+)
+
+// sealFeedback picks what the seal says about newly saved entries from the built-in
+// message pack and the user packs in ~/.wamon/messages. Several entries saved
+// together get one combined reaction.
+func sealFeedback(database db.DB, saved ...*models.Entry) feedback.Feedback {
+	engine, err := loadFeedbackEngine()
+	if err != nil {
+		fmt.Println(i18n.T("feedback.load_warning", err))
+		// The built-in pack alone still gives the usual messages
+		builtin, err := feedback.LoadBuiltin(i18n.Current())
+		if err != nil {
+			return feedback.Feedback{}
+		}
+		engine = feedback.NewEngine([]*feedback.Pack{builtin}, feedback.Options{})
+	}
+
+	entries, err := database.GetAllEntries()
+	if err != nil {
+		fmt.Println(i18n.T("error.fetch", err))
+		return feedback.Feedback{}
+	}
+	return engine.ChooseBatch(saved, entries)
+}
+
+// loadFeedbackEngine builds the feedback engine for the current language
+func loadFeedbackEngine() (*feedback.Engine, error) {
+	builtin, err := feedback.LoadBuiltin(i18n.Current())
+	if err != nil {
+		return nil, err
+	}
+	userPacks, err := feedback.LoadDir(feedback.Dir(), i18n.Current())
+	if err != nil {
+		return nil, err
+	}
+	calendar, err := loadCalendar()
+	if err != nil {
+		return nil, err
+	}
+	packs := append([]*feedback.Pack{builtin}, userPacks...)
+	return feedback.NewEngine(packs, feedback.Options{Calendar: calendar}), nil
+}
+
+// showSeal draws the seal art with the feedback, or prints only the message
+// with --no-art or art.plain so scripts and screen readers get plain text
+func showSeal(prompter *interactive.Prompter, fb feedback.Feedback) {
+	appConfig, err := config.LoadConfig()
+	if err != nil || noArt || appConfig.Art.Plain || fb.Message == "" {
+		prompter.ShowSealMessage(fb.Message)
+		return
+	}
+
+	pack, err := art.Load(appConfig.Art.Pack, art.Dir())
+	if err != nil {
+		fmt.Println(i18n.T("art.load_warning", err))
+		prompter.ShowSealMessage(fb.Message)
+		return
+	}
+
+	art.Render(os.Stdout, pack, fb.Mood, fb.Message, art.Options{
+		Animate: appConfig.Art.Animate && canAnimate(),
+	})
+}
+
+// canAnimate reports whether stdout is a terminal that understands cursor movement
+func canAnimate() bool {
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/econron/wamon/internal/db"
+	"github.com/econron/wamon/internal/feedback"
+	"github.com/econron/wamon/internal/interactive"
+	"github.com/econron/wamon/internal/models"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSealFeedback(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	_, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	database, err := db.NewDB(dbPath)
+	assert.NoError(t, err)
+	defer database.Close()
+
+	entry := &models.Entry{
+		ID:           time.Now().Format("20060102150405"),
+		Category:     models.Research,
+		Satisfaction: 4,
+		CreatedAt:    time.Now(),
+	}
+	assert.NoError(t, database.SaveEntry(entry))
+
+	// The very first entry is celebrated by the built-in pack
+	assert.Contains(t, sealFeedback(database, entry).Message, "ワモンアザラシ")
+
+	// A user pack can replace the built-in rule
+	dir := filepath.Join(home, ".wamon", "messages")
+	assert.NoError(t, os.MkdirAll(dir, 0755))
+	pack := "rules:\n  - id: milestone\n    priority: 100\n    when:\n      milestones: [1]\n    messages: [\"はじめの一歩！\"]\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "mine.yaml"), []byte(pack), 0644))
+	assert.Equal(t, "はじめの一歩！", sealFeedback(database, entry).Message)
+
+	// A broken user pack is reported but the built-in messages are still shown
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "broken.yaml"), []byte("rules: ["), 0644))
+	var message string
+	output := captureOutput(func() {
+		message = sealFeedback(database, entry).Message
+	})
+	assert.Contains(t, output, "メッセージパック")
+	assert.Contains(t, message, "ワモンアザラシ")
+}
+
+func TestShowSeal(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Reset()
+	defer viper.Reset()
+	defer func() { noArt = false }()
+
+	fb := feedback.Feedback{Message: "よく頑張ったね！", Mood: "proud"}
+
+	// The art is drawn without animation since stdout is not a terminal
+	output := captureOutput(func() {
+		showSeal(interactive.NewPrompter(), fb)
+	})
+	assert.Contains(t, output, "=( w )=")
+	assert.Contains(t, output, "よく頑張ったね！")
+	assert.NotContains(t, output, "\x1b[")
+
+	// Another art pack can be configured
+	viper.Set("art.pack", "tiny")
+	output = captureOutput(func() {
+		showSeal(interactive.NewPrompter(), fb)
+	})
+	assert.Contains(t, output, "(-w-)✧")
+
+	// --no-art prints only the message
+	noArt = true
+	output = captureOutput(func() {
+		showSeal(interactive.NewPrompter(), fb)
+	})
+	assert.Equal(t, "\n🦭 よく頑張ったね！\n\n", output)
+	noArt = false
+
+	// So does art.plain
+	viper.Set("art.plain", true)
+	output = captureOutput(func() {
+		showSeal(interactive.NewPrompter(), fb)
+	})
+	assert.NotContains(t, output, "(-w-)")
+	viper.Set("art.plain", false)
+
+	// An unknown pack is reported and the message is still shown
+	viper.Set("art.pack", "missing")
+	output = captureOutput(func() {
+		showSeal(interactive.NewPrompter(), fb)
+	})
+	assert.Contains(t, output, "missing")
+	assert.Contains(t, output, "🦭 よく頑張ったね！")
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/econron/wamon/internal/db"
+	"github.com/econron/wamon/internal/heatmap"
+	"github.com/econron/wamon/internal/i18n"
+	"github.com/econron/wamon/internal/models"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var heatmapYear int
+var heatmapMetric string
+var heatmapCategory string
+var heatmapSVG string
+
+// heatmapCmd represents the heatmap command
+var heatmapCmd = &cobra.Command{
+	Use:  "heatmap",
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		metric, err := heatmap.ParseMetric(heatmapMetric)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		year := heatmapYear
+		if year == 0 {
+			year = time.Now().Year()
+		}
+
+		// Initialize database
+		database, err := db.NewDB(dbPath)
+		if err != nil {
+			fmt.Println(i18n.T("error.db_init", err, dbPath))
+			return
+		}
+		defer database.Close()
+
+		var entries []*models.Entry
+		if heatmapCategory == "" {
+			start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+			entries, err = database.GetEntriesBetween(start, start.AddDate(1, 0, 0))
+		} else {
+			category, ok := models.ParseCategory(heatmapCategory)
+			if !ok {
+				fmt.Println(invalidCategoryMessage())
+				return
+			}
+			entries, err = database.GetEntriesByCategory(category)
+		}
+		if err != nil {
+			fmt.Println(i18n.T("error.fetch", err))
+			return
+		}
+
+		grid := heatmap.Build(entries, year, metric)
+
+		if heatmapSVG != "" {
+			file, err := os.Create(heatmapSVG)
+			if err != nil {
+				fmt.Println(i18n.T("heatmap.create_error", err))
+				return
+			}
+			defer file.Close()
+			if err := heatmap.RenderSVG(file, grid); err != nil {
+				fmt.Println(i18n.T("heatmap.svg_error", err))
+				return
+			}
+			fmt.Println(i18n.T("heatmap.saved", heatmapSVG))
+			return
+		}
+
+		fmt.Println(i18n.T("heatmap.title", year))
+		heatmap.RenderText(os.Stdout, grid, useColor())
+	},
+}
+
+// useColor reports whether ANSI colors should be written to stdout.
+// See https://no-color.org for the NO_COLOR convention.
+func useColor() bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+func init() {
+	rootCmd.AddCommand(heatmapCmd)
+
+	heatmapCmd.Flags().IntVar(&heatmapYear, "year", 0, "")
+	heatmapCmd.Flags().StringVar(&heatmapMetric, "metric", string(heatmap.Count), "")
+	heatmapCmd.Flags().StringVarP(&heatmapCategory, "category", "c", "", "")
+	heatmapCmd.Flags().StringVar(&heatmapSVG, "svg", "", "")
+
+	localizeCommand(heatmapCmd, "heatmap")
+	localizeFlag(heatmapCmd.Flags(), "year", "heatmap.year_flag")
+	localizeFlag(heatmapCmd.Flags(), "metric", "heatmap.metric_flag")
+	localizeFlag(heatmapCmd.Flags(), "category", "flag.category")
+	localizeFlag(heatmapCmd.Flags(), "svg", "heatmap.svg_flag")
+
+	heatmapCmd.RegisterFlagCompletionFunc("category", completeCategories)
+}
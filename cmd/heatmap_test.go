@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/econron/wamon/internal/db"
+	"github.com/econron/wamon/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// resetHeatmapFlags restores the heatmap flag defaults after a test
+func resetHeatmapFlags() {
+	heatmapYear = 0
+	heatmapMetric = "count"
+	heatmapCategory = ""
+	heatmapSVG = ""
+}
+
+func createHeatmapTestEntries(t *testing.T, testDBPath string) {
+	database, err := db.NewDB(testDBPath)
+	assert.NoError(t, err)
+	defer database.Close()
+
+	for _, e := range []*models.Entry{
+		{ID: "1", Category: models.Research, ResearchTopic: "a", Satisfaction: 4, CreatedAt: time.Date(2025, 4, 1, 9, 0, 0, 0, time.Local)},
+		{ID: "2", Category: models.Programming, ProgramTitle: "b", Satisfaction: 2, CreatedAt: time.Date(2025, 4, 2, 9, 0, 0, 0, time.Local)},
+	} {
+		assert.NoError(t, database.SaveEntry(e))
+	}
+}
+
+func TestHeatmapCommand(t *testing.T) {
+	testDBPath, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+	defer resetHeatmapFlags()
+	createHeatmapTestEntries(t, testDBPath)
+
+	heatmapYear = 2025
+	output := captureOutput(func() {
+		heatmapCmd.Run(heatmapCmd, []string{})
+	})
+	assert.Contains(t, output, "2025年の記録")
+	assert.Contains(t, output, "Apr")
+	// stdout is a pipe here, so no colors are used
+	assert.NotContains(t, output, "\x1b[")
+	assert.Contains(t, output, "█")
+}
+
+func TestHeatmapCommandSVG(t *testing.T) {
+	testDBPath, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+	defer resetHeatmapFlags()
+	createHeatmapTestEntries(t, testDBPath)
+
+	heatmapYear = 2025
+	heatmapCategory = "research"
+	heatmapSVG = filepath.Join(filepath.Dir(testDBPath), "heatmap.svg")
+	output := captureOutput(func() {
+		heatmapCmd.Run(heatmapCmd, []string{})
+	})
+	assert.Contains(t, output, "保存しました")
+
+	data, err := os.ReadFile(heatmapSVG)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "2025-04-01: 1 entries")
+	assert.Contains(t, string(data), "2025-04-02: 0 entries")
+}
+
+func TestHeatmapCommandInvalidFlags(t *testing.T) {
+	_, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+	defer resetHeatmapFlags()
+
+	heatmapMetric = "stars"
+	output := captureOutput(func() {
+		heatmapCmd.Run(heatmapCmd, []string{})
+	})
+	assert.Contains(t, output, "不明な指標です")
+
+	heatmapMetric = "count"
+	heatmapCategory = "unknown"
+	output = captureOutput(func() {
+		heatmapCmd.Run(heatmapCmd, []string{})
+	})
+	assert.Contains(t, output, "無効なカテゴリです")
+}
+
+func TestUseColorRespectsNoColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	assert.False(t, useColor())
+}
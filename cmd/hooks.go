@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/econron/wamon/internal/config"
+	"github.com/econron/wamon/internal/db"
+	"github.com/econron/wamon/internal/hooks"
+	"github.com/econron/wamon/internal/i18n"
+)
+
+// loadHookRunner creates a runner for the hooks in the configuration
+func loadHookRunner() (*hooks.Runner, error) {
+	appConfig, err := config.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return newHookRunner(appConfig.Hooks), nil
+}
+
+// newHookRunner creates a runner that tells hooks which database is in use
+func newHookRunner(hookConfig hooks.Config) *hooks.Runner {
+	runner := hooks.NewRunner(hookConfig)
+	runner.Env["WAMON_DB_PATH"] = dbPath
+	return runner
+}
+
+// withHooks wraps the database so saving, updating and deleting entries run the
+// configured hooks. Failed post hooks are passed to warn.
+func withHooks(database db.DB, warn func(error)) (db.DB, error) {
+	runner, err := loadHookRunner()
+	if err != nil {
+		return nil, err
+	}
+	return hooks.WrapDB(database, runner, warn), nil
+}
+
+// printHookWarning reports a post hook that failed after the change was made
+func printHookWarning(err error) {
+	fmt.Println(i18n.T("hook.warning", err))
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/econron/wamon/internal/db"
+	"github.com/econron/wamon/internal/models"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithHooks(t *testing.T) {
+	_, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+	viper.Reset()
+	defer viper.Reset()
+
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out")
+	hook := filepath.Join(dir, "hook")
+	assert.NoError(t, os.WriteFile(hook, []byte("#!/bin/sh\necho \"$WAMON_DB_PATH\" > "+out+"\n"), 0755))
+	viper.Set("hooks", map[string]interface{}{
+		"post-save": []interface{}{hook},
+	})
+
+	database, err := db.NewDB(dbPath)
+	assert.NoError(t, err)
+	defer database.Close()
+
+	hooked, err := withHooks(database, printHookWarning)
+	assert.NoError(t, err)
+	assert.NotEqual(t, database, hooked)
+
+	entry := &models.Entry{ID: "20250401120000", Category: models.Research, Satisfaction: 3, CreatedAt: time.Now()}
+	assert.NoError(t, hooked.SaveEntry(entry))
+
+	// The hook is told which database is in use
+	content, err := os.ReadFile(out)
+	assert.NoError(t, err)
+	assert.Equal(t, dbPath+"\n", string(content))
+}
+
+func TestWithHooksInvalidConfig(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	viper.Set("hooks", map[string]interface{}{
+		"on-save": []interface{}{"/bin/true"},
+	})
+	_, err := withHooks(nil, printHookWarning)
+	assert.Error(t, err)
+}
+
+func TestPrintHookWarning(t *testing.T) {
+	output := captureOutput(func() {
+		printHookWarning(assert.AnError)
+	})
+	assert.Contains(t, output, assert.AnError.Error())
+}
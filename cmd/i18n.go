@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"github.com/econron/wamon/internal/i18n"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// localizers re-apply the translated help texts after the language changes
+var localizers []func()
+
+// localizeCommand sets the Short and Long help texts of a command from the
+// "cmd.<name>.short" and "cmd.<name>.long" catalog keys
+func localizeCommand(cmd *cobra.Command, name string) {
+	apply := func() {
+		cmd.Short = i18n.T("cmd." + name + ".short")
+		cmd.Long = i18n.T("cmd." + name + ".long")
+	}
+	apply()
+	localizers = append(localizers, apply)
+}
+
+// localizeFlag sets the usage text of a flag from a catalog key
+func localizeFlag(flags *pflag.FlagSet, name, key string) {
+	apply := func() {
+		if flag := flags.Lookup(name); flag != nil {
+			flag.Usage = i18n.T(key)
+		}
+	}
+	apply()
+	localizers = append(localizers, apply)
+}
+
+// relocalize re-applies every registered help text in the active language
+func relocalize() {
+	for _, apply := range localizers {
+		apply()
+	}
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/econron/wamon/internal/db"
+	"github.com/econron/wamon/internal/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+// useLanguage switches the language for a test and returns a function restoring the default
+func useLanguage(t *testing.T, lang string) func() {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "")
+	langFlag = lang
+	applyLanguage()
+	return func() {
+		langFlag = ""
+		languageErr = nil
+		i18n.SetLanguage(string(i18n.Default))
+		relocalize()
+	}
+}
+
+func TestLangFlagLocalizesOutput(t *testing.T) {
+	testDBPath, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+	defer useLanguage(t, "en")()
+
+	database, err := db.NewDB(testDBPath)
+	assert.NoError(t, err)
+	createTestEntries(t, database, 2)
+	database.Close()
+
+	categoryFilter = ""
+	output := captureOutput(func() {
+		listCmd.Run(listCmd, []string{})
+	})
+	assert.Contains(t, output, "Total: 2 entries")
+	assert.Contains(t, output, "Category: Research")
+	assert.Contains(t, output, "Category: Programming")
+}
+
+func TestLangFlagLocalizesHelp(t *testing.T) {
+	defer useLanguage(t, "en")()
+
+	assert.Equal(t, "Show past entries", listCmd.Short)
+	assert.Equal(t, "filter by category (research, programming, both)", listCmd.Flags().Lookup("category").Usage)
+
+	i18n.SetLanguage("ja")
+	relocalize()
+	assert.Equal(t, "過去の記録を表示", listCmd.Short)
+}
+
+func TestInvalidLangStopsCommand(t *testing.T) {
+	defer useLanguage(t, "fr")()
+
+	// Messages fall back to the default language, and the command is not run
+	assert.Equal(t, i18n.Default, i18n.Current())
+	err := rootCmd.PersistentPreRunE(listCmd, nil)
+	if assert.Error(t, err) {
+		assert.Equal(t, i18n.T("error.unsupported_language", "fr", "en, ja"), err.Error())
+	}
+
+	useLanguage(t, "en")
+	assert.NoError(t, rootCmd.PersistentPreRunE(listCmd, nil))
+}
+
+func TestInvalidCategoryMessageIsLocalized(t *testing.T) {
+	defer useLanguage(t, "en")()
+
+	assert.Equal(t, "Invalid category. Valid categories: research (Research), programming (Programming), research_and_programming (Research & Programming)", invalidCategoryMessage())
+}
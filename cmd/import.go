@@ -4,24 +4,19 @@ import (
 	"fmt"
 
 	"github.com/econron/wamon/internal/db"
+	"github.com/econron/wamon/internal/i18n"
 	"github.com/spf13/cobra"
 )
 
 // importCmd represents the import command
 var importCmd = &cobra.Command{
-	Use:   "import [FILE]",
-	Short: "JSONファイルからデータをインポート",
-	Long: `エクスポートされたJSONファイルからデータをインポートします。
-同じIDの項目が既に存在する場合はスキップされます。
-
-例:
-  $ wamon import wamon_backup.json`,
+	Use:  "import [FILE]",
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		// Initialize database
 		database, err := db.NewDB(dbPath)
 		if err != nil {
-			fmt.Printf("データベースの初期化エラー: %v\nデータベースパス: %s を確認してください。\n", err, dbPath)
+			fmt.Println(i18n.T("error.db_init", err, dbPath))
 			return
 		}
 		defer database.Close()
@@ -32,23 +27,25 @@ var importCmd = &cobra.Command{
 		// Import entries
 		count, err := database.ImportEntries(filePath)
 		if err != nil {
-			fmt.Printf("インポートエラー: %v\n", err)
+			fmt.Println(i18n.T("import.error", err))
 			return
 		}
 
-		fmt.Printf("%d件のエントリを正常にインポートしました\n", count)
+		fmt.Println(i18n.T("import.done", count))
 
 		// Get total entry count
 		total, err := database.GetEntryCount()
 		if err != nil {
-			fmt.Printf("データベース内の総エントリ数の取得に失敗しました: %v\n", err)
+			fmt.Println(i18n.T("import.total_error", err))
 			return
 		}
 
-		fmt.Printf("現在のデータベースには合計%d件のエントリがあります\n", total)
+		fmt.Println(i18n.T("import.total", total))
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(importCmd)
+
+	localizeCommand(importCmd, "import")
 }
@@ -74,3 +74,75 @@ func TestInitConfig(t *testing.T) {
 
 // Mock functions to override os.UserHomeDir for testing
 var osUserHomeDir = os.UserHomeDir
+
+func TestInitConfigTOML(t *testing.T) {
+	originalCfgFile := cfgFile
+	defer func() {
+		cfgFile = originalCfgFile
+	}()
+
+	testConfigFile := filepath.Join(t.TempDir(), "config.toml")
+	err := os.WriteFile(testConfigFile, []byte("[slack]\ntoken = 'test-token'\nchannel = 'test-channel'\n"), 0644)
+	assert.NoError(t, err)
+
+	cfgFile = testConfigFile
+	viper.Reset()
+	defer viper.Reset()
+
+	initConfig()
+
+	assert.Equal(t, testConfigFile, viper.ConfigFileUsed())
+	assert.Equal(t, "test-token", viper.GetString("slack.token"))
+	assert.Equal(t, "test-channel", viper.GetString("slack.channel"))
+}
+
+func TestInitConfigDatabasePath(t *testing.T) {
+	originalCfgFile, originalDBPath := cfgFile, dbPath
+	defer func() {
+		cfgFile, dbPath = originalCfgFile, originalDBPath
+	}()
+	t.Setenv("WAMON_DB_PATH", "")
+
+	// The database path in the config file is used when --db is not given
+	testConfigFile := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(testConfigFile, []byte("database:\n  path: /data/wamon.db\n"), 0644)
+	assert.NoError(t, err)
+
+	cfgFile, dbPath = testConfigFile, ""
+	viper.Reset()
+	defer viper.Reset()
+
+	initConfig()
+	assert.Equal(t, "/data/wamon.db", dbPath)
+
+	// WAMON_DB_PATH takes precedence over the file
+	t.Setenv("WAMON_DB_PATH", "/env/wamon.db")
+	dbPath = ""
+	initConfig()
+	assert.Equal(t, "/env/wamon.db", dbPath)
+
+	// --db takes precedence over both
+	dbPath = "/flag/wamon.db"
+	initConfig()
+	assert.Equal(t, "/flag/wamon.db", dbPath)
+}
+
+func TestInitConfigFromEnvironment(t *testing.T) {
+	originalCfgFile := cfgFile
+	defer func() {
+		cfgFile = originalCfgFile
+	}()
+
+	testConfigFile := filepath.Join(t.TempDir(), "wamon.toml")
+	err := os.WriteFile(testConfigFile, []byte("[slack]\nchannel = 'env-channel'\n"), 0644)
+	assert.NoError(t, err)
+	t.Setenv("WAMON_CONFIG", testConfigFile)
+
+	cfgFile = ""
+	viper.Reset()
+	defer viper.Reset()
+
+	initConfig()
+	assert.Equal(t, testConfigFile, viper.ConfigFileUsed())
+	assert.Equal(t, "env-channel", viper.GetString("slack.channel"))
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/econron/wamon/internal/config"
+	"github.com/econron/wamon/internal/i18n"
+	"github.com/econron/wamon/internal/logging"
+)
+
+// Logging flags
+var verbose bool
+var logLevel string
+var logFormat string
+var logFile string
+
+// logCloser closes the log file of the current logger
+var logCloser io.Closer
+
+// setupLogging installs the logger described by the flags as the default slog logger.
+// --verbose (or --debug) writes debug records to stderr; --log-file writes them to a
+// rotating file. Without either, nothing is logged.
+func setupLogging() {
+	opts := logging.Options{Level: slog.LevelInfo, Format: logFormat, File: logFile}
+	if verbose || debugMode {
+		opts.Level = slog.LevelDebug
+		opts.Stderr = os.Stderr
+	}
+	if logLevel != "" {
+		level, err := logging.ParseLevel(logLevel)
+		if err != nil {
+			printInitWarning(i18n.T("log.invalid_level", logLevel))
+		} else {
+			opts.Level = level
+		}
+	}
+
+	logger, closer, err := logging.New(opts)
+	if err != nil {
+		printInitWarning(i18n.T("log.setup_error", err))
+		logger, closer = logging.Discard(), nil
+	}
+
+	closeLog()
+	logCloser = closer
+	slog.SetDefault(logger)
+}
+
+// closeLog closes the log file, if any
+func closeLog() {
+	if logCloser != nil {
+		logCloser.Close()
+		logCloser = nil
+	}
+}
+
+// logConfigSources logs where the config file and each value come from
+func logConfigSources(resolution config.Resolution) {
+	if !logging.Enabled(slog.LevelDebug) {
+		return
+	}
+	file, err := config.ReadFile(resolution.Path)
+	if err != nil {
+		return
+	}
+	for _, setting := range config.Explain(file, configFlags()) {
+		if setting.Source == "" {
+			continue
+		}
+		slog.Debug("config value", "key", setting.Field.Key, "value", displayConfigValue(setting.Field, setting.Value), "source", setting.Source)
+	}
+}
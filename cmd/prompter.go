@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+
+	"github.com/econron/wamon/internal/i18n"
+	"github.com/econron/wamon/internal/interactive"
+	"golang.org/x/term"
+)
+
+// scriptFile replays the answers of an interactive session from a file
+var scriptFile string
+
+// stdinIsTerminal reports whether someone can type answers; tests replace it
+var stdinIsTerminal = func() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// newPrompter returns a prompter that replays --script, or one for the terminal.
+// Without a terminal nobody can answer, so it fails instead of waiting for input.
+func newPrompter() (*interactive.Prompter, error) {
+	if scriptFile != "" {
+		script, err := os.ReadFile(scriptFile)
+		if err != nil {
+			return nil, errors.New(i18n.T("script.read_error", scriptFile, err))
+		}
+		return interactive.NewScriptPrompter(bytes.NewReader(script), os.Stdout), nil
+	}
+
+	if !stdinIsTerminal() {
+		return nil, errors.New(i18n.T("prompt.not_a_terminal"))
+	}
+	return interactive.NewPrompter(), nil
+}
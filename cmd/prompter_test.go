@@ -0,0 +1,246 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/econron/wamon/internal/db"
+	"github.com/econron/wamon/internal/models"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+// useScript points --script at a file with the given content for the test
+func useScript(t *testing.T, content string) {
+	path := filepath.Join(t.TempDir(), "answers.txt")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	scriptFile = path
+	t.Cleanup(func() { scriptFile = "" })
+}
+
+func TestNewPrompterWithoutTerminal(t *testing.T) {
+	original := stdinIsTerminal
+	defer func() { stdinIsTerminal = original }()
+	stdinIsTerminal = func() bool { return false }
+
+	_, err := newPrompter()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "--script")
+	}
+
+	stdinIsTerminal = func() bool { return true }
+	prompter, err := newPrompter()
+	assert.NoError(t, err)
+	assert.NotNil(t, prompter)
+}
+
+func TestNewPrompterScript(t *testing.T) {
+	useScript(t, "hello\n")
+
+	var answer string
+	var err error
+	output := captureOutput(func() {
+		prompter, promptErr := newPrompter()
+		assert.NoError(t, promptErr)
+		answer, err = prompter.AskString()
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", answer)
+	// The answer is echoed
+	assert.Equal(t, "hello\n", output)
+
+	scriptFile = filepath.Join(t.TempDir(), "missing.txt")
+	_, err = newPrompter()
+	assert.Error(t, err)
+}
+
+func TestRunInteractiveJournalScript(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	_, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+	viper.Reset()
+	defer viper.Reset()
+
+	useScript(t, `# 1: 調べたこと
+1
+調べたこと:
+Goのcontextパッケージ
+タグ:
+go, context
+.
+5
+`)
+
+	output := captureOutput(func() {
+		runInteractiveJournal("")
+	})
+	assert.Contains(t, output, "記録しました！")
+
+	database, err := db.NewDB(dbPath)
+	assert.NoError(t, err)
+	defer database.Close()
+
+	entries, err := database.GetAllEntries()
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, models.Research, entries[0].Category)
+		assert.Equal(t, "Goのcontextパッケージ", entries[0].ResearchTopic)
+		assert.Equal(t, []string{"go", "context"}, entries[0].Tags)
+		assert.Equal(t, 5, entries[0].Satisfaction)
+	}
+}
+
+func TestRunInteractiveJournalWithoutTerminal(t *testing.T) {
+	_, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	original := stdinIsTerminal
+	defer func() { stdinIsTerminal = original }()
+	stdinIsTerminal = func() bool { return false }
+
+	output := captureOutput(func() {
+		runInteractiveJournal("")
+	})
+	assert.Contains(t, output, "--script")
+}
+
+func TestRunInteractiveJournalRetryAndBack(t *testing.T) {
+	_, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+	viper.Reset()
+	defer viper.Reset()
+
+	// An invalid answer is asked again and back revisits the content
+	useScript(t, `back
+4
+2
+書いたプログラム:
+wamon
+.
+9
+back
+書いたプログラム:
+wamon journal
+.
+3
+`)
+
+	output := captureOutput(func() {
+		runInteractiveJournal("")
+	})
+	assert.Contains(t, output, "これが最初のステップです")
+	assert.Contains(t, output, "記録しました！")
+
+	database, err := db.NewDB(dbPath)
+	assert.NoError(t, err)
+	defer database.Close()
+
+	entries, err := database.GetAllEntries()
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, models.Programming, entries[0].Category)
+		assert.Equal(t, "wamon journal", entries[0].ProgramTitle)
+		assert.Equal(t, 3, entries[0].Satisfaction)
+	}
+}
+
+func TestRunInteractiveJournalQuit(t *testing.T) {
+	_, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+	viper.Reset()
+	defer viper.Reset()
+
+	useScript(t, "1\n.\nquit\n")
+
+	output := captureOutput(func() {
+		runInteractiveJournal("")
+	})
+	assert.Contains(t, output, "記録をキャンセルしました")
+
+	database, err := db.NewDB(dbPath)
+	assert.NoError(t, err)
+	defer database.Close()
+
+	count, err := database.GetEntryCount()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestRunInteractiveJournalMulti(t *testing.T) {
+	_, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+	viper.Reset()
+	defer viper.Reset()
+	multiEntry = true
+	defer func() { multiEntry = false }()
+
+	useScript(t, `1
+調べたこと:
+SQLiteのトランザクション
+.
+4
+# add another
+y
+2
+書いたプログラム:
+wamon --multi
+.
+5
+n
+# save
+y
+`)
+
+	output := captureOutput(func() {
+		runInteractiveJournal("")
+	})
+	assert.Contains(t, output, "今回の記録 (2件)")
+	assert.Contains(t, output, "SQLiteのトランザクション")
+	assert.Contains(t, output, "2件を記録しました！")
+
+	database, err := db.NewDB(dbPath)
+	assert.NoError(t, err)
+	defer database.Close()
+
+	entries, err := database.GetAllEntries()
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	// Both entries were saved as one operation, so one undo removes them
+	operations, err := database.GetOperations(10)
+	assert.NoError(t, err)
+	assert.Len(t, operations, 1)
+}
+
+func TestRunInteractiveJournalMultiQuitAndDecline(t *testing.T) {
+	_, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+	viper.Reset()
+	defer viper.Reset()
+	multiEntry = true
+	defer func() { multiEntry = false }()
+
+	// Quitting the second entry still offers to save the first
+	useScript(t, "1\n.\n4\ny\nquit\ny\n")
+	output := captureOutput(func() {
+		runInteractiveJournal("")
+	})
+	assert.Contains(t, output, "今回の記録 (1件)")
+	assert.Contains(t, output, "記録しました！")
+
+	// Declining the summary saves nothing more
+	useScript(t, "2\n.\n3\nn\nn\n")
+	output = captureOutput(func() {
+		runInteractiveJournal("")
+	})
+	assert.Contains(t, output, "記録をキャンセルしました")
+
+	database, err := db.NewDB(dbPath)
+	assert.NoError(t, err)
+	defer database.Close()
+
+	count, err := database.GetEntryCount()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
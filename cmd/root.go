@@ -23,7 +23,9 @@ package cmd
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
@@ -31,9 +33,14 @@ import (
 
 	"github.com/econron/wamon/internal/config"
 	"github.com/econron/wamon/internal/db"
+	"github.com/econron/wamon/internal/hooks"
+	"github.com/econron/wamon/internal/i18n"
 	"github.com/econron/wamon/internal/interactive"
+	"github.com/econron/wamon/internal/logging"
 	"github.com/econron/wamon/internal/models"
+	"github.com/econron/wamon/internal/report"
 	"github.com/econron/wamon/internal/slack"
+	"github.com/econron/wamon/internal/stats"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -43,162 +50,170 @@ var cfgFile string
 var debugMode bool
 var dbPath string
 var categoryFilter string
+var tagFilter string
+var langFlag string
+
+// languageErr is set by applyLanguage when --lang names an unsupported language
+var languageErr error
+var noArt bool
+var multiEntry bool
+
+// Flags of the report command
+var reportPeriod string
+var reportSince string
+var reportUntil string
+var reportCurrent bool
+var reportDryRun bool
+var reportFormat string
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
-	Use:   "github.com/econron/wamon",
-	Short: "ワモンアザラシと一緒に日々の活動を記録するCLIツール",
-	Long: `ワモンアザラシと一緒に日々の活動を記録するCLIツールです。
-調べ物や書いたプログラムを記録して、ワモンアザラシから褒めてもらいましょう！`,
+	Use: "wamon",
+	// An unsupported --lang stops every command instead of falling back silently
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if languageErr != nil {
+			cmd.SilenceUsage = true
+			return languageErr
+		}
+		return nil
+	},
 	Run: func(cmd *cobra.Command, args []string) {
-		runInteractiveJournal()
+		runInteractiveJournal("")
 	},
 }
 
 // editCmd represents the edit command
 var editCmd = &cobra.Command{
-	Use:   "edit [ID]",
-	Short: "既存の記録を編集",
-	Long: `指定されたIDの記録を編集します。
-エディタが開くので、内容を編集して保存してください。`,
-	Args: cobra.ExactArgs(1),
+	Use:               "edit [ID]",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeEntryIDs,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Initialize database
 		database, err := db.NewDB(dbPath)
 		if err != nil {
-			fmt.Printf("データベースの初期化エラー: %v\nデータベースパス: %s を確認してください。\n", err, dbPath)
+			fmt.Println(i18n.T("error.db_init", err, dbPath))
 			return
 		}
 		defer database.Close()
 
+		// Run the configured hooks when entries change
+		database, err = withHooks(database, printHookWarning)
+		if err != nil {
+			fmt.Println(i18n.T("error.config_load", err))
+			return
+		}
+
 		// Get the entry
 		entry, err := database.GetEntryByID(args[0])
 		if err != nil {
 			if err == sql.ErrNoRows {
-				fmt.Printf("ID %s の記録が見つかりません。\n正しいIDを指定してください。\n", args[0])
+				fmt.Println(i18n.T("error.entry_not_found", args[0]))
 			} else {
-				fmt.Printf("データの取得エラー: %v\n再度試してみてください。\n", err)
+				fmt.Println(i18n.T("error.fetch", err))
 			}
 			return
 		}
 
 		// Create prompter
-		prompter := interactive.NewPrompter()
+		prompter, err := newPrompter()
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
 
 		// Edit the entry
-		err = prompter.EditEntry(entry)
+		recent, err := recentEntries(database, entry)
+		if err != nil {
+			fmt.Println(i18n.T("error.fetch", err))
+			return
+		}
+		err = prompter.EditEntry(entry, recent)
 		if err != nil {
-			fmt.Printf("編集エラー: %v\n編集をキャンセルしました。\n", err)
+			fmt.Println(i18n.T("error.edit", err))
 			return
 		}
 
 		// Update the entry in the database
 		err = database.UpdateEntry(entry)
 		if err != nil {
-			fmt.Printf("データの更新エラー: %v\n", err)
-			fmt.Println("編集内容を保存できませんでした。再度試してみてください。")
+			fmt.Println(i18n.T("edit.update_error", err))
 			return
 		}
 
-		fmt.Println("記録を更新しました！")
+		fmt.Println(i18n.T("edit.updated"))
 
 		// Show the updated entry
-		fmt.Println("\n🦭 更新された記録 🦭")
+		fmt.Println("\n" + i18n.T("edit.title"))
 		fmt.Println("------------------------")
-		fmt.Printf("記録ID: %s [%s]\n", entry.ID, formatDate(entry.CreatedAt))
-		fmt.Printf("カテゴリ: %s\n", entry.Category)
-		if entry.ResearchTopic != "" {
-			fmt.Printf("調べたこと: %s\n", entry.ResearchTopic)
-		}
-		if entry.ProgramTitle != "" {
-			fmt.Printf("書いたプログラム: %s\n", entry.ProgramTitle)
-		}
-		fmt.Printf("満足度: %d/5\n", entry.Satisfaction)
+		fmt.Println(i18n.T("entry.id_with_date", entry.ID, formatDate(entry.CreatedAt)))
+		printEntryFields(entry)
 		fmt.Println("------------------------")
 	},
 }
 
 // listCmd represents the list command to show past entries
 var listCmd = &cobra.Command{
-	Use:   "list",
-	Short: "過去の記録を表示",
-	Long:  "過去に記録したエントリを一覧表示します。カテゴリでフィルタリングすることもできます。",
+	Use: "list",
 	Run: func(cmd *cobra.Command, args []string) {
 		// Initialize database
 		database, err := db.NewDB(dbPath)
 		if err != nil {
-			fmt.Printf("データベースの初期化エラー: %v\nデータベースパス: %s を確認してください。\n", err, dbPath)
+			fmt.Println(i18n.T("error.db_init", err, dbPath))
 			return
 		}
 		defer database.Close()
 
 		var entries []*models.Entry
-		var filter models.Category
 
 		// Convert user-friendly category name to internal representation
-		switch strings.ToLower(categoryFilter) {
-		case "research", "調べ物":
-			filter = models.Research
-			entries, err = database.GetEntriesByCategory(filter)
-		case "programming", "プログラマ":
-			filter = models.Programming
-			entries, err = database.GetEntriesByCategory(filter)
-		case "both", "調べてプログラマ":
-			filter = models.ResearchAndProgram
-			entries, err = database.GetEntriesByCategory(filter)
-		case "":
+		if categoryFilter == "" {
 			// No filter, get all entries
 			entries, err = database.GetAllEntries()
-		default:
-			fmt.Println("無効なカテゴリです。有効なカテゴリ: 調べ物, プログラマ, 調べてプログラマ")
-			return
+		} else {
+			filter, ok := models.ParseCategory(categoryFilter)
+			if !ok {
+				fmt.Println(invalidCategoryMessage())
+				return
+			}
+			entries, err = database.GetEntriesByCategory(filter)
 		}
 
 		if err != nil {
-			fmt.Printf("データの取得エラー: %v\n再度試してみてください。\n", err)
+			fmt.Println(i18n.T("error.fetch", err))
 			return
 		}
 
+		if tagFilter != "" {
+			entries = filterByTag(entries, tagFilter)
+		}
+
 		if len(entries) == 0 {
-			fmt.Println("記録がありません。")
+			fmt.Println(i18n.T("list.empty"))
 			return
 		}
 
 		// Display entries
-		fmt.Println("🦭 ワモンアザラシの記録 🦭")
+		fmt.Println(i18n.T("app.title"))
 		fmt.Println("------------------------")
 
 		for i, entry := range entries {
-			fmt.Printf("記録 #%d [ID: %s] [%s]\n", i+1, entry.ID, formatDate(entry.CreatedAt))
-			fmt.Printf("カテゴリ: %s\n", entry.Category)
-
-			if entry.ResearchTopic != "" {
-				fmt.Printf("調べたこと: %s\n", entry.ResearchTopic)
-			}
-
-			if entry.ProgramTitle != "" {
-				fmt.Printf("書いたプログラム: %s\n", entry.ProgramTitle)
-			}
-
-			fmt.Printf("満足度: %d/5\n", entry.Satisfaction)
+			fmt.Println(i18n.T("list.entry_header", i+1, entry.ID, formatDate(entry.CreatedAt)))
+			printEntryFields(entry)
 			fmt.Println("------------------------")
 		}
 
-		fmt.Printf("合計: %d件の記録\n", len(entries))
+		fmt.Println(i18n.T("list.total", len(entries)))
 	},
 }
 
-// reportCmd represents the command to send weekly report to Slack
+// reportCmd represents the command to send the report of a period to Slack
 var reportCmd = &cobra.Command{
-	Use:   "report",
-	Short: "Slackに過去1週間の記録を送信",
-	Long: `過去1週間に記録したエントリをSlackに送信します。
-Slackの設定は~/.wamon.yamlで行います。`,
+	Use: "report",
 	Run: func(cmd *cobra.Command, args []string) {
 		// Initialize database
 		database, err := db.NewDB(dbPath)
 		if err != nil {
-			fmt.Printf("データベースの初期化エラー: %v\nデータベースパス: %s を確認してください。\n", err, dbPath)
+			fmt.Println(i18n.T("error.db_init", err, dbPath))
 			return
 		}
 		defer database.Close()
@@ -206,73 +221,203 @@ Slackの設定は~/.wamon.yamlで行います。`,
 		// Load configuration
 		appConfig, err := config.LoadConfig()
 		if err != nil {
-			fmt.Printf("設定の読み込みエラー: %v\n再度試してみてください。\n", err)
+			fmt.Println(i18n.T("error.config_load", err))
 			return
 		}
 
-		// Create prompter
-		prompter := interactive.NewPrompter()
+		period, err := reportPeriodFromFlags(cmd, appConfig.Report, time.Now())
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		if err := checkReportFormat(cmd); err != nil {
+			fmt.Println(err)
+			return
+		}
 
-		// If Slack token is not configured, ask for it
-		if appConfig.Slack.Token == "" {
-			fmt.Println("SlackのBot User OAuth Tokenを入力してください（xoxb-で始まるトークン）:")
-			token, err := prompter.AskString()
-			if err != nil {
-				fmt.Printf("入力エラー: %v\n再度試してみてください。\n", err)
-				return
-			}
-			if !strings.HasPrefix(token, "xoxb-") {
-				fmt.Println("無効なトークンです。Bot User OAuth Token（xoxb-で始まるトークン）を入力してください。")
-				return
-			}
-			appConfig.Slack.Token = token
+		// Get entries of the period
+		entries, err := database.GetEntriesBetween(period.Start, period.End)
+		if err != nil {
+			fmt.Println(i18n.T("error.fetch", err))
+			return
 		}
 
-		// If channel is not configured or empty, ask for it
-		if appConfig.Slack.Channel == "" {
-			fmt.Println("投稿先のSlackチャンネル名を入力してください（例: general）:")
-			channel, err := prompter.AskString()
-			if err != nil {
-				fmt.Printf("入力エラー: %v\n再度試してみてください。\n", err)
-				return
-			}
-			appConfig.Slack.Channel = channel
+		// Nothing to send, so there is no need to ask for the Slack settings
+		if len(entries) == 0 {
+			fmt.Println(i18n.T("report.empty", period.Label()))
+			return
 		}
 
-		// Save the configuration
-		err = config.SaveSlackConfig(appConfig.Slack.Token, appConfig.Slack.Channel)
-		if err != nil {
-			fmt.Printf("設定の保存エラー: %v\n", err)
-			fmt.Println("設定の保存に失敗しましたが、今回のレポート送信は続行します。")
+		// A dry run only prints the message, so it needs no Slack settings
+		if !reportDryRun && !prepareSlack(appConfig) {
+			return
 		}
 
-		// Get entries from the past week
-		entries, err := database.GetEntriesFromLastWeek()
-		if err != nil {
-			fmt.Printf("データの取得エラー: %v\n再度試してみてください。\n", err)
+		// Let the pre-report hooks stop the report or change its entries
+		hookReport := &hooks.Report{Channel: appConfig.Slack.Channel, Entries: entries}
+		if err := newHookRunner(appConfig.Hooks).RunPre(hooks.PreReport, hookReport, nil); err != nil {
+			fmt.Println(err)
 			return
 		}
+		entries = hookReport.Entries
 
 		if len(entries) == 0 {
-			fmt.Println("過去1週間の記録がありません。")
+			fmt.Println(i18n.T("report.empty", period.Label()))
+			return
+		}
+
+		// Compare with the previous period and add the streak when they can be computed.
+		// A dry run prints the warnings to stderr, so its output can be piped.
+		warnings := os.Stdout
+		if reportDryRun {
+			warnings = os.Stderr
+		}
+		r := report.Build(period, entries)
+		previous := period.Previous()
+		if previousEntries, err := database.GetEntriesBetween(previous.Start, previous.End); err != nil {
+			fmt.Fprintln(warnings, i18n.T("error.fetch", err))
+		} else {
+			r.Compare(previousEntries)
+		}
+		if streak, err := computeStreak(database); err != nil {
+			fmt.Fprintln(warnings, i18n.T("report.streak_error", err))
+		} else {
+			r.Streak = &streak
+		}
+
+		if reportDryRun {
+			message, err := slack.RenderReport(r, reportFormat)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+			fmt.Print(message)
 			return
 		}
 
 		// Send to Slack
-		err = slack.SendWeeklyReport(appConfig.Slack.Token, appConfig.Slack.Channel, entries)
-		if err != nil {
-			fmt.Printf("Slackへの送信エラー: %v\n再度試してみてください。\n", err)
+		if err := slack.SendReport(appConfig.Slack.Token, appConfig.Slack.Channel, r); err != nil {
+			fmt.Println(i18n.T("report.send_error", err))
 			return
 		}
 
-		fmt.Printf("過去1週間の記録 (%d件) をSlackチャンネル #%s に送信しました！\n", len(entries), appConfig.Slack.Channel)
+		fmt.Println(i18n.T("report.sent", period.Label(), len(entries), appConfig.Slack.Channel))
 	},
 }
 
+// prepareSlack reads the Slack token and asks for the token and the channel that
+// are not configured, saving the answers. It reports whether the report can be sent.
+func prepareSlack(appConfig *config.AppConfig) bool {
+	// Read the token from the environment, a command or a file
+	if _, err := appConfig.ResolveSlackToken(); err != nil {
+		fmt.Println(i18n.T("report.token_error", err))
+		return false
+	}
+
+	// Create a prompter when something has to be asked
+	var prompter *interactive.Prompter
+	if appConfig.Slack.Token == "" || appConfig.Slack.Channel == "" {
+		var err error
+		prompter, err = newPrompter()
+		if err != nil {
+			fmt.Println(err)
+			return false
+		}
+	}
+
+	// If Slack token is not configured, ask for it
+	askedToken, askedChannel := appConfig.Slack.Token == "", appConfig.Slack.Channel == ""
+	if askedToken {
+		fmt.Println(i18n.T("report.ask_token"))
+		token, err := prompter.AskString()
+		if err != nil {
+			fmt.Println(i18n.T("error.input", err))
+			return false
+		}
+		if !strings.HasPrefix(token, "xoxb-") {
+			fmt.Println(i18n.T("report.invalid_token"))
+			return false
+		}
+		appConfig.Slack.Token = token
+	}
+
+	// If channel is not configured or empty, ask for it
+	if askedChannel {
+		fmt.Println(i18n.T("report.ask_channel"))
+		channel, err := prompter.AskString()
+		if err != nil {
+			fmt.Println(i18n.T("error.input", err))
+			return false
+		}
+		appConfig.Slack.Channel = channel
+	}
+
+	// Save what was asked; the token goes to its own private file, not the config file
+	switch {
+	case askedToken:
+		if err := config.SaveSlackConfig(appConfig.Slack.Token, appConfig.Slack.Channel); err != nil {
+			fmt.Println(i18n.T("report.config_save_error", err))
+		} else {
+			fmt.Println(i18n.T("report.token_saved", config.SecretPath()))
+		}
+	case askedChannel:
+		if err := config.SaveSlackChannel(appConfig.Slack.Channel); err != nil {
+			fmt.Println(i18n.T("report.config_save_error", err))
+		}
+	}
+	return true
+}
+
+// checkReportFormat checks the --format flag, which only applies to a dry run
+func checkReportFormat(cmd *cobra.Command) error {
+	if cmd.Flags().Changed("format") && !reportDryRun {
+		return errors.New(i18n.T("report.format_without_dry_run"))
+	}
+	for _, format := range slack.Formats {
+		if reportFormat == format {
+			return nil
+		}
+	}
+	return errors.New(i18n.T("report.invalid_format", reportFormat, strings.Join(slack.Formats, ", ")))
+}
+
+// reportPeriodFromFlags returns the period the report command sends. Without
+// --current it is the last complete week, month or quarter; --since and --until
+// choose the days of a custom period.
+func reportPeriodFromFlags(cmd *cobra.Command, reportConfig config.ReportConfig, now time.Time) (report.Period, error) {
+	kind, err := report.ParseKind(reportPeriod)
+	if err != nil {
+		return report.Period{}, err
+	}
+	custom := cmd.Flags().Changed("since") || cmd.Flags().Changed("until")
+	if custom && !cmd.Flags().Changed("period") {
+		kind = report.KindCustom
+	}
+	if kind == report.KindCustom {
+		return report.ParseCustom(reportSince, reportUntil, now)
+	}
+	if custom {
+		return report.Period{}, errors.New(i18n.T("report.period_conflict"))
+	}
+
+	weekStart := report.DefaultWeekStart
+	if reportConfig.WeekStart != "" {
+		if weekStart, err = stats.ParseWeekday(reportConfig.WeekStart); err != nil {
+			return report.Period{}, errors.New(i18n.T("report.week_start_error", err))
+		}
+	}
+	period := report.Current(kind, now, weekStart)
+	if !reportCurrent {
+		period = period.Previous()
+	}
+	return period, nil
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
 	err := rootCmd.Execute()
+	closeLog()
 	if err != nil {
 		os.Exit(1)
 	}
@@ -282,12 +427,19 @@ func init() {
 	cobra.OnInitialize(initConfig)
 
 	// Global flags
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.wamon.yaml)")
-	rootCmd.PersistentFlags().BoolVar(&debugMode, "debug", false, "enable debug mode")
-
-	// Database path
-	defaultDBPath := getDefaultDBPath()
-	rootCmd.PersistentFlags().StringVar(&dbPath, "db", defaultDBPath, "database file path")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "")
+	rootCmd.PersistentFlags().BoolVar(&debugMode, "debug", false, "")
+	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "", "")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", logging.FormatText, "")
+	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "")
+	rootCmd.PersistentFlags().StringVar(&langFlag, "lang", "", "")
+	rootCmd.PersistentFlags().BoolVar(&noArt, "no-art", false, "")
+	rootCmd.PersistentFlags().StringVar(&scriptFile, "script", "", "")
+
+	// Database path; without --db it is resolved after the config file is read
+	rootCmd.PersistentFlags().StringVar(&dbPath, "db", "", "")
+	rootCmd.Flags().BoolVar(&multiEntry, "multi", false, "")
 
 	// Add commands
 	rootCmd.AddCommand(listCmd)
@@ -296,34 +448,149 @@ func init() {
 	rootCmd.AddCommand(setDBCmd)
 
 	// Category filter for list command
-	listCmd.Flags().StringVarP(&categoryFilter, "category", "c", "", "filter by category (調べ物, プログラマ, 調べてプログラマ)")
+	listCmd.Flags().StringVarP(&categoryFilter, "category", "c", "", "")
+	listCmd.Flags().StringVarP(&tagFilter, "tag", "t", "", "")
+
+	// Period of the report
+	reportCmd.Flags().StringVar(&reportPeriod, "period", string(report.KindWeek), "")
+	reportCmd.Flags().StringVar(&reportSince, "since", "", "")
+	reportCmd.Flags().StringVar(&reportUntil, "until", "", "")
+	reportCmd.Flags().BoolVar(&reportCurrent, "current", false, "")
+	reportCmd.Flags().BoolVar(&reportDryRun, "dry-run", false, "")
+	reportCmd.Flags().StringVar(&reportFormat, "format", slack.FormatBlocksJSON, "")
+
+	localizeCommand(rootCmd, "root")
+	localizeCommand(editCmd, "edit")
+	localizeCommand(listCmd, "list")
+	localizeCommand(reportCmd, "report")
+	localizeCommand(setDBCmd, "set_db")
+	localizeFlag(rootCmd.PersistentFlags(), "config", "flag.config")
+	localizeFlag(rootCmd.PersistentFlags(), "debug", "flag.debug")
+	localizeFlag(rootCmd.PersistentFlags(), "verbose", "flag.verbose")
+	localizeFlag(rootCmd.PersistentFlags(), "log-level", "flag.log_level")
+	localizeFlag(rootCmd.PersistentFlags(), "log-format", "flag.log_format")
+	localizeFlag(rootCmd.PersistentFlags(), "log-file", "flag.log_file")
+	localizeFlag(rootCmd.PersistentFlags(), "lang", "flag.lang")
+	localizeFlag(rootCmd.PersistentFlags(), "no-art", "flag.no_art")
+	localizeFlag(rootCmd.PersistentFlags(), "script", "flag.script")
+	localizeFlag(rootCmd.PersistentFlags(), "db", "flag.db")
+	localizeFlag(rootCmd.Flags(), "multi", "flag.multi")
+	localizeFlag(listCmd.Flags(), "category", "flag.category")
+	localizeFlag(listCmd.Flags(), "tag", "flag.tag")
+	localizeFlag(reportCmd.Flags(), "period", "report.period_flag")
+	localizeFlag(reportCmd.Flags(), "since", "report.since_flag")
+	localizeFlag(reportCmd.Flags(), "until", "report.until_flag")
+	localizeFlag(reportCmd.Flags(), "current", "report.current_flag")
+	localizeFlag(reportCmd.Flags(), "dry-run", "report.dry_run_flag")
+	localizeFlag(reportCmd.Flags(), "format", "report.format_flag")
+
+	// Shell completion
+	listCmd.RegisterFlagCompletionFunc("category", completeCategories)
+	listCmd.RegisterFlagCompletionFunc("tag", completeTags)
+	rootCmd.RegisterFlagCompletionFunc("lang", completeLanguages)
+	reportCmd.RegisterFlagCompletionFunc("period", completeReportPeriods)
+	reportCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions(slack.Formats, cobra.ShellCompDirectiveNoFileComp))
+	rootCmd.RegisterFlagCompletionFunc("log-level", cobra.FixedCompletions([]string{"debug", "info", "warn", "error"}, cobra.ShellCompDirectiveNoFileComp))
+	rootCmd.RegisterFlagCompletionFunc("log-format", cobra.FixedCompletions([]string{logging.FormatText, logging.FormatJSON}, cobra.ShellCompDirectiveNoFileComp))
+
+	// Help is printed without running the initializers, so apply the language first
+	defaultHelp := rootCmd.HelpFunc()
+	rootCmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
+		initConfig()
+		defaultHelp(cmd, args)
+	})
 }
 
 // initConfig reads in config file and ENV variables if set.
 func initConfig() {
-	if cfgFile != "" {
-		// Use config file from the flag.
-		viper.SetConfigFile(cfgFile)
-	} else {
-		// Find home directory.
-		home, err := os.UserHomeDir()
-		if err != nil {
-			fmt.Println(err)
-			return
-		}
+	setupLogging()
 
-		// Search config in home directory with name ".wamon" (without extension).
-		viper.AddConfigPath(filepath.Join(home, ".wamon"))
-		viper.AddConfigPath(home)
-		viper.SetConfigType("yaml")
-		viper.SetConfigName(".wamon")
-	}
+	// The config file comes from --config, WAMON_CONFIG, the XDG directories or the legacy locations
+	resolution := config.Resolve(cfgFile)
 
 	viper.AutomaticEnv() // read in environment variables that match
+	config.SetDefaults()
 
 	// If a config file is found, read it in.
-	if err := viper.ReadInConfig(); err == nil && debugMode {
-		fmt.Println("Using config file:", viper.ConfigFileUsed())
+	err := config.Load(resolution)
+
+	applyLanguage()
+	if err != nil {
+		slog.Warn("config file could not be read", "path", resolution.Path, "error", err)
+		printInitWarning(i18n.T("config.parse_error", resolution.Path, err))
+	}
+	logConfigSources(resolution)
+
+	// The database path is resolved once the config file has been read
+	if dbPath == "" {
+		dbPath = getDefaultDBPath()
+	}
+	slog.Debug("database path", "path", dbPath)
+}
+
+// printInitWarning shows a problem found while reading the settings. Nothing is
+// printed while completing, since it would be taken as a completion.
+func printInitWarning(message string) {
+	if completing() {
+		return
+	}
+	fmt.Println(message)
+}
+
+// applyLanguage selects the language from --lang, the config file and the environment.
+// An unsupported --lang is kept in languageErr, so the command is stopped before it runs.
+func applyLanguage() {
+	languageErr = nil
+
+	// AutomaticEnv maps "lang" to $LANG, so only take the value from the config file here
+	configLang := ""
+	if viper.InConfig("lang") {
+		configLang = viper.GetString("lang")
+	}
+	i18n.SetLanguage(string(i18n.Detect(langFlag, configLang)))
+	relocalize()
+
+	if langFlag != "" && !i18n.Supported(langFlag) {
+		var names []string
+		for _, lang := range i18n.Languages() {
+			names = append(names, string(lang))
+		}
+		languageErr = errors.New(i18n.T("error.unsupported_language", langFlag, strings.Join(names, ", ")))
+	}
+}
+
+// invalidCategoryMessage lists the valid category codes with their display names
+func invalidCategoryMessage() string {
+	var names []string
+	for _, category := range models.Categories() {
+		names = append(names, fmt.Sprintf("%s (%s)", category, i18n.CategoryLabel(category)))
+	}
+	return i18n.T("error.invalid_category", strings.Join(names, ", "))
+}
+
+// filterByTag keeps the entries that have the given tag
+func filterByTag(entries []*models.Entry, tag string) []*models.Entry {
+	var result []*models.Entry
+	for _, entry := range entries {
+		if entry.HasTag(tag) {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+// printEntryFields prints the category, text fields and satisfaction of an entry
+func printEntryFields(entry *models.Entry) {
+	fmt.Println(i18n.T("entry.category", i18n.CategoryLabel(entry.Category)))
+	if entry.ResearchTopic != "" {
+		fmt.Println(i18n.T("entry.research_topic", entry.ResearchTopic))
+	}
+	if entry.ProgramTitle != "" {
+		fmt.Println(i18n.T("entry.program_title", entry.ProgramTitle))
+	}
+	fmt.Println(i18n.T("entry.satisfaction", entry.Satisfaction))
+	if len(entry.Tags) > 0 {
+		fmt.Println(i18n.T("entry.tags", strings.Join(entry.Tags, ", ")))
 	}
 }
 
@@ -332,144 +599,202 @@ func formatDate(t time.Time) string {
 	return t.Format("2006-01-02 15:04")
 }
 
-// runInteractiveJournal guides the user through recording their activity
-func runInteractiveJournal() {
+// printDraftKept tells where the unsaved text of the journal was kept
+func printDraftKept(journal *interactive.Journal) {
+	if journal.HasDraft() {
+		fmt.Println(i18n.T("journal.draft_kept", journal.DraftPath))
+	}
+}
+
+// printEntrySummary prints a table of the entries recorded in a session.
+// Only fixed-width columns are padded, since labels differ in width between languages.
+func printEntrySummary(entries []*models.Entry) {
+	fmt.Println("\n" + i18n.T("journal.summary", len(entries)))
+	fmt.Println(strings.Repeat("-", 60))
+	for i, entry := range entries {
+		fmt.Printf("%2d.  %-16s  %-3s  [%s] %s\n", i+1, formatDate(entry.CreatedAt), fmt.Sprintf("%d/5", entry.Satisfaction),
+			i18n.CategoryLabel(entry.Category), entry.Body())
+	}
+}
+
+// runInteractiveJournal guides the user through recording their activity.
+// An empty templateName uses the template of the chosen category.
+// With --multi it keeps recording entries until the user is done, then saves them together.
+func runInteractiveJournal(templateName string) {
 	// Initialize database
 	database, err := db.NewDB(dbPath)
 	if err != nil {
-		fmt.Printf("データベースの初期化エラー: %v\nデータベースパス: %s を確認してください。\n", err, dbPath)
+		fmt.Println(i18n.T("error.db_init", err, dbPath))
 		return
 	}
 	defer database.Close()
 
-	// Create prompter
-	prompter := interactive.NewPrompter()
-
-	fmt.Println("🦭 ワモンアザラシの記録 🦭")
-	fmt.Println("------------------------")
-	fmt.Println("今日の活動を記録しましょう！")
-	fmt.Println("途中でやめたい場合は 'quit' と入力してください。")
-
-	// Ask for category
-	category, err := prompter.AskCategory()
+	// Run the configured hooks when entries change
+	database, err = withHooks(database, printHookWarning)
 	if err != nil {
-		fmt.Printf("入力エラー: %v\n再度試してみてください。\n", err)
+		fmt.Println(i18n.T("error.config_load", err))
 		return
 	}
 
-	// Check for quit
-	if category == "quit" {
-		fmt.Println("記録をキャンセルしました。またね！")
+	// Create prompter
+	prompter, err := newPrompter()
+	if err != nil {
+		fmt.Println(err)
 		return
 	}
 
-	// Prepare a new entry
-	entry := &models.Entry{
-		ID:        time.Now().Format("20060102150405"), // Use timestamp as ID
-		Category:  category,
-		CreatedAt: time.Now(),
-	}
+	fmt.Println(i18n.T("app.title"))
+	fmt.Println("------------------------")
+	fmt.Println(i18n.T("journal.intro"))
 
-	// Create initial content for the editor
-	initialContent := fmt.Sprintf("カテゴリ: %s\n\n", category)
-	if category == models.Research || category == models.ResearchAndProgram {
-		initialContent += "調べたこと:\n"
+	// Ask for the category, content and satisfaction, allowing back and retries
+	journal := prompter.NewJournal()
+	journal.TemplateName = templateName
+	journal.Recent = func(entry *models.Entry) ([]*models.Entry, error) {
+		return recentEntries(database, entry)
 	}
-	if category == models.Programming || category == models.ResearchAndProgram {
-		initialContent += "書いたプログラム:\n"
+	if scriptFile == "" {
+		// A script can be replayed, so only typed sessions keep a draft
+		journal.DraftPath = interactive.DraftPath()
 	}
 
-	// Edit content using external editor
-	editedContent, err := interactive.EditWithExternalEditor(initialContent)
-	if err != nil {
-		fmt.Printf("編集エラー: %v\n編集をキャンセルしました。\n", err)
-		return
+	// Entries finished in an earlier session that could not be saved come back first
+	entries := journal.Resume()
+	restored := len(entries)
+	if restored > 0 {
+		fmt.Println(i18n.T("journal.draft_restored", restored))
 	}
+	writeMore := restored == 0 || journal.Unfinished()
+	if !writeMore && multiEntry {
+		writeMore, err = prompter.AskYesNo(i18n.T("journal.add_another"), false)
+		if err != nil {
+			fmt.Println(i18n.T("error.input", err))
+			printDraftKept(journal)
+			return
+		}
+	}
+	for writeMore {
+		entry, err := journal.Run()
+		if err == interactive.ErrQuit && len(entries) > 0 {
+			// Quitting a further entry keeps the ones already written
+			break
+		}
+		if err == interactive.ErrQuit {
+			fmt.Println(i18n.T("journal.cancelled"))
+			printDraftKept(journal)
+			return
+		}
+		if err != nil {
+			fmt.Println(i18n.T("error.input", err))
+			printDraftKept(journal)
+			return
+		}
+		entries = append(entries, entry)
 
-	// Parse the edited content
-	lines := strings.Split(editedContent, "\n")
-	for i, line := range lines {
-		line = strings.TrimSpace(line)
-		if strings.HasPrefix(line, "調べたこと:") {
-			if i+1 < len(lines) {
-				entry.ResearchTopic = strings.TrimSpace(lines[i+1])
-			}
-		} else if strings.HasPrefix(line, "書いたプログラム:") {
-			if i+1 < len(lines) {
-				entry.ProgramTitle = strings.TrimSpace(lines[i+1])
-			}
+		if !multiEntry {
+			break
+		}
+		another, err := prompter.AskYesNo(i18n.T("journal.add_another"), false)
+		if err != nil {
+			fmt.Println(i18n.T("error.input", err))
+			printDraftKept(journal)
+			return
+		}
+		if !another {
+			break
 		}
 	}
 
-	// Ask for satisfaction
-	satisfaction, err := prompter.AskSatisfaction()
-	if err != nil {
-		fmt.Printf("入力エラー: %v\n再度試してみてください。\n", err)
-		return
+	if multiEntry || restored > 0 {
+		// Let the user look over everything before it is saved
+		printEntrySummary(entries)
+		confirmed, err := prompter.AskYesNo(i18n.T("journal.confirm_save", len(entries)), true)
+		if err != nil {
+			fmt.Println(i18n.T("error.input", err))
+			printDraftKept(journal)
+			return
+		}
+		if !confirmed {
+			fmt.Println(i18n.T("journal.cancelled"))
+			printDraftKept(journal)
+			return
+		}
 	}
-	entry.Satisfaction = satisfaction
 
-	// Save the entry
-	err = database.SaveEntry(entry)
+	// Save the entries together, so either all of them or none are recorded
+	err = database.SaveEntries(entries)
 	if err != nil {
-		fmt.Printf("データの保存エラー: %v\n再度試してみてください。\n", err)
+		fmt.Println(i18n.T("error.save", err))
+		printDraftKept(journal)
 		return
 	}
+	if err := journal.Finish(); err != nil {
+		fmt.Println(i18n.T("journal.draft_error", err))
+	}
+	printDraftKept(journal)
 
-	fmt.Println("\n記録しました！")
+	if len(entries) == 1 {
+		fmt.Println("\n" + i18n.T("journal.saved"))
+	} else {
+		fmt.Println("\n" + i18n.T("journal.saved_many", len(entries)))
+	}
 
-	// Show seal's message based on satisfaction
-	prompter.ShowSealMessage(satisfaction)
+	// Show one reaction of the seal to the new entries and the history before them
+	showSeal(prompter, sealFeedback(database, entries...))
 
 	// Display entry count
 	count, err := database.GetEntryCount()
 	if err != nil {
-		fmt.Printf("データ取得エラー: %v\n", err)
+		fmt.Println(i18n.T("error.count", err))
+	} else {
+		fmt.Println("\n" + i18n.T("journal.count", count))
+	}
+
+	// Display streak
+	streak, err := computeStreak(database)
+	if err != nil {
+		fmt.Println(i18n.T("error.streak", err))
 	} else {
-		fmt.Printf("\n現在の記録数: %d件\n", count)
+		fmt.Println(i18n.T("journal.streak", streak.Current, streak.Longest))
 	}
 }
 
-// getDefaultDBPath returns the default path for the database file
+// getDefaultDBPath returns the database path from WAMON_DB_PATH or the config file,
+// or ~/.wamon/wamon.db when none is configured
 func getDefaultDBPath() string {
-	// First try to get path from config or environment variable
-	appConfig, err := config.LoadConfig()
-	if err == nil && appConfig.DatabasePath != "" {
-		return appConfig.DatabasePath
+	if path := config.DatabasePath(); path != "" {
+		return path
 	}
 
 	// If not configured, use default location
-	home, err := os.UserHomeDir()
-	if err != nil {
+	path := config.DefaultDBPath()
+	if !filepath.IsAbs(path) {
 		return "wamon.db" // Fallback to current directory
 	}
 
-	// Create .wamon directory if it doesn't exist
-	wamonDir := filepath.Join(home, ".wamon")
-	if _, err := os.Stat(wamonDir); os.IsNotExist(err) {
-		if err := os.MkdirAll(wamonDir, 0755); err != nil {
-			return "wamon.db" // Fallback to current directory
-		}
+	// Completing only reads, so leave a missing directory alone
+	if completing() {
+		return path
 	}
 
-	return filepath.Join(wamonDir, "wamon.db")
+	// Create .wamon directory if it doesn't exist
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "wamon.db" // Fallback to current directory
+	}
+	return path
 }
 
 // setDBCmd saves the current database path to configuration
 var setDBCmd = &cobra.Command{
-	Use:   "set-db",
-	Short: "現在のデータベースパスを設定に保存",
-	Long: `現在のデータベースパスを設定ファイルに保存します。
-これにより、--dbオプションを毎回指定する必要がなくなります。`,
+	Use: "set-db",
 	Run: func(cmd *cobra.Command, args []string) {
 		// Save current DB path to config
 		err := config.SaveDatabasePath(dbPath)
 		if err != nil {
-			fmt.Printf("設定の保存エラー: %v\n再度試してみてください。\n", err)
+			fmt.Println(i18n.T("error.config_save", err))
 			return
 		}
 
-		fmt.Printf("データベースパス %s を設定に保存しました。\n", dbPath)
-		fmt.Println("今後は--dbオプションを指定しなくても、このパスが使用されます。")
+		fmt.Println(i18n.T("set_db.saved", dbPath))
 	},
 }
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"io"
 	"os"
 	"path/filepath"
@@ -9,8 +10,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/econron/wamon/internal/config"
 	"github.com/econron/wamon/internal/db"
 	"github.com/econron/wamon/internal/models"
+	"github.com/econron/wamon/internal/report"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
@@ -223,11 +226,145 @@ func TestReportCommandEmpty(t *testing.T) {
 
 	// The output should contain one of these messages
 	assert.True(t,
-		strings.Contains(output, "過去1週間の記録がありません") ||
+		strings.Contains(output, "の記録がありません") ||
 			strings.Contains(output, "SlackのBot User OAuth Token"),
 		"Output should either mention no entries or ask for Slack token")
 }
 
+// resetReportFlags restores the flags of the report command to their defaults
+func resetReportFlags() {
+	for _, name := range []string{"period", "since", "until", "current", "dry-run", "format"} {
+		flag := reportCmd.Flags().Lookup(name)
+		flag.Value.Set(flag.DefValue)
+		flag.Changed = false
+	}
+}
+
+// setReportFlags sets only the given flags of the report command and restores them when the test ends
+func setReportFlags(t *testing.T, values map[string]string) {
+	resetReportFlags()
+	t.Cleanup(resetReportFlags)
+	for name, value := range values {
+		assert.NoError(t, reportCmd.Flags().Set(name, value))
+	}
+}
+
+// TestReportPeriodFromFlags tests how the flags of the report command choose its period
+func TestReportPeriodFromFlags(t *testing.T) {
+	// Wednesday 2025-04-16
+	now := time.Date(2025, 4, 16, 10, 0, 0, 0, time.Local)
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2025, month, d, 0, 0, 0, 0, time.Local)
+	}
+
+	t.Run("last week by default", func(t *testing.T) {
+		setReportFlags(t, nil)
+		period, err := reportPeriodFromFlags(reportCmd, config.ReportConfig{}, now)
+		assert.NoError(t, err)
+		assert.Equal(t, report.KindWeek, period.Kind)
+		assert.Equal(t, day(4, 7), period.Start)
+		assert.Equal(t, day(4, 14), period.End)
+	})
+
+	t.Run("week start from the config", func(t *testing.T) {
+		setReportFlags(t, map[string]string{"current": "true"})
+		period, err := reportPeriodFromFlags(reportCmd, config.ReportConfig{WeekStart: "sun"}, now)
+		assert.NoError(t, err)
+		assert.Equal(t, day(4, 13), period.Start)
+		assert.Equal(t, day(4, 20), period.End)
+
+		_, err = reportPeriodFromFlags(reportCmd, config.ReportConfig{WeekStart: "someday"}, now)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "report.week_start")
+	})
+
+	t.Run("last month and quarter", func(t *testing.T) {
+		setReportFlags(t, map[string]string{"period": "month"})
+		period, err := reportPeriodFromFlags(reportCmd, config.ReportConfig{}, now)
+		assert.NoError(t, err)
+		assert.Equal(t, day(3, 1), period.Start)
+		assert.Equal(t, day(4, 1), period.End)
+
+		setReportFlags(t, map[string]string{"period": "quarter"})
+		period, err = reportPeriodFromFlags(reportCmd, config.ReportConfig{}, now)
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local), period.Start)
+		assert.Equal(t, day(4, 1), period.End)
+	})
+
+	t.Run("custom range", func(t *testing.T) {
+		setReportFlags(t, map[string]string{"since": "2025-04-01", "until": "2025-04-10"})
+		period, err := reportPeriodFromFlags(reportCmd, config.ReportConfig{}, now)
+		assert.NoError(t, err)
+		assert.Equal(t, report.KindCustom, period.Kind)
+		assert.Equal(t, day(4, 1), period.Start)
+		assert.Equal(t, day(4, 11), period.End)
+	})
+
+	t.Run("invalid flags", func(t *testing.T) {
+		setReportFlags(t, map[string]string{"period": "custom"})
+		_, err := reportPeriodFromFlags(reportCmd, config.ReportConfig{}, now)
+		assert.Error(t, err)
+
+		setReportFlags(t, map[string]string{"period": "month", "since": "2025-04-01"})
+		_, err = reportPeriodFromFlags(reportCmd, config.ReportConfig{}, now)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "--period custom")
+
+		setReportFlags(t, map[string]string{"period": "year"})
+		_, err = reportPeriodFromFlags(reportCmd, config.ReportConfig{}, now)
+		assert.Error(t, err)
+	})
+}
+
+// TestReportDryRun tests that a dry run prints the message without a Slack token
+func TestReportDryRun(t *testing.T) {
+	_, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("WAMON_SLACK_TOKEN", "")
+	viper.Reset()
+
+	database, err := db.NewDB(dbPath)
+	assert.NoError(t, err)
+	createTestEntries(t, database, 2)
+	database.Close()
+
+	today := time.Now().Format("2006-01-02")
+	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+
+	setReportFlags(t, map[string]string{"since": yesterday, "until": today, "dry-run": "true", "format": "text"})
+	output := captureOutput(func() {
+		reportCmd.Run(reportCmd, []string{})
+	})
+	assert.Contains(t, output, "🦭 ワモンアザラシの記録")
+	assert.Contains(t, output, "記録 2件")
+	assert.NotContains(t, output, "*2件*")
+	assert.NotContains(t, output, "SlackのBot User OAuth Token")
+
+	setReportFlags(t, map[string]string{"since": yesterday, "dry-run": "true"})
+	output = captureOutput(func() {
+		reportCmd.Run(reportCmd, []string{})
+	})
+	var payload map[string][]map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(output), &payload), output)
+	if assert.NotEmpty(t, payload["blocks"]) {
+		assert.Equal(t, "header", payload["blocks"][0]["type"])
+	}
+
+	setReportFlags(t, map[string]string{"since": yesterday, "dry-run": "true", "format": "html"})
+	output = captureOutput(func() {
+		reportCmd.Run(reportCmd, []string{})
+	})
+	assert.Contains(t, output, "不明な形式です: html")
+
+	setReportFlags(t, map[string]string{"since": yesterday, "format": "markdown"})
+	output = captureOutput(func() {
+		reportCmd.Run(reportCmd, []string{})
+	})
+	assert.Contains(t, output, "--format は --dry-run と一緒に指定してください")
+}
+
 // TestReportCommandDBError tests the report command with a database error
 func TestReportCommandDBError(t *testing.T) {
 	// Create an invalid database path
@@ -246,3 +383,55 @@ func TestGetDefaultDBPath(t *testing.T) {
 	assert.NotEmpty(t, path)
 	assert.Contains(t, path, "wamon.db")
 }
+
+// TestListCommandCategoryCodes tests filtering with category codes and aliases
+func TestListCommandCategoryCodes(t *testing.T) {
+	testDBPath, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+	defer func() { categoryFilter = "" }()
+
+	database, err := db.NewDB(testDBPath)
+	assert.NoError(t, err)
+	createTestEntries(t, database, 3)
+	database.Close()
+
+	categoryFilter = "programming"
+	output := captureOutput(func() {
+		listCmd.Run(listCmd, []string{})
+	})
+	assert.Contains(t, output, "カテゴリ: プログラマ")
+	assert.Contains(t, output, "合計: 1件の記録")
+
+	categoryFilter = "both"
+	output = captureOutput(func() {
+		listCmd.Run(listCmd, []string{})
+	})
+	assert.Contains(t, output, "記録がありません")
+}
+
+// TestListCommandTagFilter tests filtering the list by tag
+func TestListCommandTagFilter(t *testing.T) {
+	testDBPath, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+	defer func() { tagFilter = "" }()
+
+	database, err := db.NewDB(testDBPath)
+	assert.NoError(t, err)
+	entries := createTestEntries(t, database, 2)
+	entries[0].Tags = []string{"go"}
+	assert.NoError(t, database.UpdateEntry(entries[0]))
+	database.Close()
+
+	tagFilter = "#Go"
+	output := captureOutput(func() {
+		listCmd.Run(listCmd, []string{})
+	})
+	assert.Contains(t, output, "合計: 1件の記録")
+	assert.Contains(t, output, "タグ: go")
+
+	tagFilter = "rust"
+	output = captureOutput(func() {
+		listCmd.Run(listCmd, []string{})
+	})
+	assert.Contains(t, output, "記録がありません")
+}
@@ -38,7 +38,7 @@ func (m *mockPrompter) AskString() (string, error) {
 	return m.stringResp, m.err
 }
 
-func (m *mockPrompter) ShowSealMessage(satisfaction int) {
+func (m *mockPrompter) ShowSealMessage(message string) {
 	// Do nothing in mock
 }
 
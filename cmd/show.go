@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/econron/wamon/internal/db"
+	"github.com/econron/wamon/internal/i18n"
+	"github.com/econron/wamon/internal/models"
+	"github.com/spf13/cobra"
+)
+
+var showOutput string
+var showSimilarLimit int
+
+// showResult is the JSON representation printed by `show --output json`
+type showResult struct {
+	Entry         *models.Entry      `json:"entry"`
+	RevisionCount int                `json:"revision_count"`
+	Revisions     []*models.Revision `json:"revisions"`
+	SameDay       []*models.Entry    `json:"same_day"`
+	Similar       []*models.Entry    `json:"similar"`
+}
+
+// showCmd represents the show command
+var showCmd = &cobra.Command{
+	Use:               "show [ID]",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeEntryIDs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if showOutput != "text" && showOutput != "json" {
+			fmt.Println(i18n.T("error.invalid_output", showOutput))
+			return
+		}
+
+		// Initialize database
+		database, err := db.NewDB(dbPath)
+		if err != nil {
+			fmt.Println(i18n.T("error.db_init", err, dbPath))
+			return
+		}
+		defer database.Close()
+
+		result, err := buildShowResult(database, args[0], showSimilarLimit)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				fmt.Println(i18n.T("error.entry_not_found", args[0]))
+			} else {
+				fmt.Println(i18n.T("error.fetch", err))
+			}
+			return
+		}
+
+		if showOutput == "json" {
+			data, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				fmt.Println(i18n.T("error.json", err))
+				return
+			}
+			fmt.Println(string(data))
+			return
+		}
+
+		printShowResult(result)
+	},
+}
+
+// buildShowResult collects an entry together with its related context
+func buildShowResult(database db.DB, id string, similarLimit int) (*showResult, error) {
+	entry, err := database.GetEntryByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := database.GetEntryRevisions(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Neighbours are the other entries created on the same local calendar day
+	created := entry.CreatedAt.Local()
+	dayStart := time.Date(created.Year(), created.Month(), created.Day(), 0, 0, 0, 0, time.Local)
+	dayEntries, err := database.GetEntriesBetween(dayStart, dayStart.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	sameDay := []*models.Entry{}
+	for _, e := range dayEntries {
+		if e.ID != entry.ID {
+			sameDay = append(sameDay, e)
+		}
+	}
+
+	similar, err := database.FindSimilarEntries(entry, similarLimit)
+	if err != nil {
+		return nil, err
+	}
+	if similar == nil {
+		similar = []*models.Entry{}
+	}
+	if revisions == nil {
+		revisions = []*models.Revision{}
+	}
+
+	return &showResult{
+		Entry:         entry,
+		RevisionCount: len(revisions),
+		Revisions:     revisions,
+		SameDay:       sameDay,
+		Similar:       similar,
+	}, nil
+}
+
+// printShowResult renders the show command output for the terminal
+func printShowResult(result *showResult) {
+	entry := result.Entry
+
+	fmt.Println(i18n.T("show.title"))
+	fmt.Println("------------------------")
+	fmt.Println(i18n.T("show.id", entry.ID))
+	printEntryFields(entry)
+	fmt.Println(i18n.T("show.created_at", formatDate(entry.CreatedAt)))
+	if entry.UpdatedAt != nil {
+		fmt.Println(i18n.T("show.updated_at", formatDate(*entry.UpdatedAt)))
+	} else {
+		fmt.Println(i18n.T("show.updated_at", "-"))
+	}
+	fmt.Println(i18n.T("show.revision_count", result.RevisionCount))
+	fmt.Println("------------------------")
+
+	fmt.Println(i18n.T("show.same_day"))
+	if len(result.SameDay) == 0 {
+		fmt.Println(i18n.T("show.none"))
+	}
+	for _, e := range result.SameDay {
+		fmt.Printf("  [%s] %s %s: %s\n", e.ID, e.CreatedAt.Format("15:04"), i18n.CategoryLabel(e.Category), e.Body())
+	}
+
+	fmt.Println(i18n.T("show.similar"))
+	if len(result.Similar) == 0 {
+		fmt.Println(i18n.T("show.none"))
+	}
+	for _, e := range result.Similar {
+		fmt.Printf("  [%s] %s %s: %s\n", e.ID, formatDate(e.CreatedAt), i18n.CategoryLabel(e.Category), e.Body())
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(showCmd)
+
+	showCmd.Flags().StringVarP(&showOutput, "output", "o", "text", "")
+	showCmd.Flags().IntVar(&showSimilarLimit, "similar", 5, "")
+
+	localizeCommand(showCmd, "show")
+	localizeFlag(showCmd.Flags(), "output", "flag.output")
+	localizeFlag(showCmd.Flags(), "similar", "show.similar_flag")
+}
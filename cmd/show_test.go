@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/econron/wamon/internal/db"
+	"github.com/econron/wamon/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// createShowTestEntries saves a target entry with a same-day neighbour and a similar entry
+func createShowTestEntries(t *testing.T, testDBPath string) {
+	database, err := db.NewDB(testDBPath)
+	assert.NoError(t, err)
+	defer database.Close()
+
+	day := time.Date(2025, 4, 1, 0, 0, 0, 0, time.Local)
+	entries := []*models.Entry{
+		{ID: "20250401090000", Category: models.Research, ResearchTopic: "SQLiteのインデックス設計", Satisfaction: 4, CreatedAt: day.Add(9 * time.Hour)},
+		{ID: "20250401180000", Category: models.Programming, ProgramTitle: "CLIの引数パーサ", Satisfaction: 3, CreatedAt: day.Add(18 * time.Hour)},
+		{ID: "20250310120000", Category: models.Research, ResearchTopic: "SQLiteのインデックス", Satisfaction: 5, CreatedAt: day.AddDate(0, 0, -22)},
+	}
+	for _, e := range entries {
+		assert.NoError(t, database.SaveEntry(e))
+	}
+
+	// Edit the target once so it has a revision
+	entries[0].Satisfaction = 5
+	assert.NoError(t, database.UpdateEntry(entries[0]))
+}
+
+func TestShowCommand(t *testing.T) {
+	testDBPath, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+	createShowTestEntries(t, testDBPath)
+
+	showOutput = "text"
+	showSimilarLimit = 5
+	output := captureOutput(func() {
+		showCmd.Run(showCmd, []string{"20250401090000"})
+	})
+
+	assert.Contains(t, output, "記録ID: 20250401090000")
+	assert.Contains(t, output, "満足度: 5/5")
+	assert.Contains(t, output, "改訂回数: 1")
+	assert.Contains(t, output, "[20250401180000]")
+	assert.Contains(t, output, "[20250310120000]")
+}
+
+func TestShowCommandJSON(t *testing.T) {
+	testDBPath, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+	createShowTestEntries(t, testDBPath)
+
+	showOutput = "json"
+	showSimilarLimit = 5
+	defer func() { showOutput = "text" }()
+	output := captureOutput(func() {
+		showCmd.Run(showCmd, []string{"20250401090000"})
+	})
+
+	var result showResult
+	assert.NoError(t, json.Unmarshal([]byte(output), &result))
+	assert.Equal(t, "20250401090000", result.Entry.ID)
+	assert.NotNil(t, result.Entry.UpdatedAt)
+	assert.Equal(t, 1, result.RevisionCount)
+	assert.Equal(t, 4, result.Revisions[0].Entry.Satisfaction)
+	if assert.Len(t, result.SameDay, 1) {
+		assert.Equal(t, "20250401180000", result.SameDay[0].ID)
+	}
+	if assert.NotEmpty(t, result.Similar) {
+		assert.Equal(t, "20250310120000", result.Similar[0].ID)
+	}
+}
+
+func TestShowCommandNotFound(t *testing.T) {
+	_, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	showOutput = "text"
+	output := captureOutput(func() {
+		showCmd.Run(showCmd, []string{"missing"})
+	})
+	assert.Contains(t, output, "記録が見つかりません")
+}
+
+func TestShowCommandInvalidOutput(t *testing.T) {
+	_, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	showOutput = "xml"
+	defer func() { showOutput = "text" }()
+	output := captureOutput(func() {
+		showCmd.Run(showCmd, []string{"any"})
+	})
+	assert.Contains(t, output, "無効な出力形式です")
+}
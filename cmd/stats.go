@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/econron/wamon/internal/config"
+	"github.com/econron/wamon/internal/db"
+	"github.com/econron/wamon/internal/i18n"
+	"github.com/econron/wamon/internal/models"
+	"github.com/econron/wamon/internal/stats"
+	"github.com/spf13/cobra"
+)
+
+var statsSince string
+var statsTop int
+var statsOutput string
+
+// statsCmd represents the stats command
+var statsCmd = &cobra.Command{
+	Use:  "stats",
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if statsOutput != "text" && statsOutput != "json" {
+			fmt.Println(i18n.T("error.invalid_output", statsOutput))
+			return
+		}
+
+		now := time.Now()
+		since, err := parseSince(statsSince, now)
+		if err != nil {
+			fmt.Println(i18n.T("error.invalid_since", err))
+			return
+		}
+
+		// Initialize database
+		database, err := db.NewDB(dbPath)
+		if err != nil {
+			fmt.Println(i18n.T("error.db_init", err, dbPath))
+			return
+		}
+		defer database.Close()
+
+		entries, err := database.GetEntriesSince(since)
+		if err != nil {
+			fmt.Println(i18n.T("error.fetch", err))
+			return
+		}
+
+		calendar, err := loadCalendar()
+		if err != nil {
+			fmt.Println(i18n.T("error.calendar", err))
+		}
+
+		summary := stats.Compute(entries, stats.Options{Since: since, Until: now, TopN: statsTop, Calendar: calendar})
+
+		if statsOutput == "json" {
+			data, err := json.MarshalIndent(summary, "", "  ")
+			if err != nil {
+				fmt.Println(i18n.T("error.json", err))
+				return
+			}
+			fmt.Println(string(data))
+			return
+		}
+
+		printStats(summary)
+	},
+}
+
+// relativePeriodPattern matches periods such as "30d" or "2w"
+var relativePeriodPattern = regexp.MustCompile(`^(\d+)([dw])$`)
+
+// parseSince converts a period such as "30d", "2w", "168h" or "2025-04-01"
+// into the start time of that period
+func parseSince(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if m := relativePeriodPattern.FindStringSubmatch(value); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		if m[2] == "w" {
+			n *= 7
+		}
+		return now.AddDate(0, 0, -n), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return time.Time{}, errors.New(i18n.T("stats.since_example", value))
+	}
+	return now.Add(-duration), nil
+}
+
+// loadCalendar builds the streak calendar from the streak.* settings.
+// It returns nil, meaning every day is a working day, when nothing is configured.
+func loadCalendar() (*stats.Calendar, error) {
+	appConfig, err := config.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	streakConfig := appConfig.Streak
+	if len(streakConfig.NonWorkingDays) == 0 && streakConfig.Holidays == "" && streakConfig.HolidaysFile == "" {
+		return nil, nil
+	}
+
+	calendar, err := stats.NewCalendar(streakConfig.NonWorkingDays)
+	if err != nil {
+		return nil, err
+	}
+	if streakConfig.Holidays != "" {
+		if err := calendar.LoadBuiltinHolidays(streakConfig.Holidays); err != nil {
+			return nil, err
+		}
+	}
+	if streakConfig.HolidaysFile != "" {
+		if err := calendar.LoadHolidayFile(streakConfig.HolidaysFile); err != nil {
+			return nil, err
+		}
+	}
+	if streakConfig.Holidays != "" {
+		warnHolidaysEnded(os.Stderr, calendar, streakConfig.Holidays, time.Now())
+	}
+	return calendar, nil
+}
+
+// warnHolidaysEnded warns when the built-in holiday list ends before the day of now,
+// since holidays after it would break the streak. It is written to w so that JSON
+// output stays valid.
+func warnHolidaysEnded(w io.Writer, calendar *stats.Calendar, name string, now time.Time) {
+	if until := calendar.HolidaysUntil(); now.Year() > until {
+		fmt.Fprintln(w, i18n.T("stats.holidays_ended", name, until))
+	}
+}
+
+// computeStreak calculates the current and longest streak over every entry in the database
+func computeStreak(database db.DB) (stats.Streak, error) {
+	entries, err := database.GetAllEntries()
+	if err != nil {
+		return stats.Streak{}, err
+	}
+	calendar, err := loadCalendar()
+	if err != nil {
+		return stats.Streak{}, err
+	}
+	return stats.ComputeStreak(entries, time.Now(), calendar), nil
+}
+
+// printStats renders a stats summary for the terminal
+func printStats(summary *stats.Summary) {
+	fmt.Println(i18n.T("stats.title"))
+	fmt.Println(i18n.T("stats.period", summary.Since.Format("2006-01-02"), summary.Until.Format("2006-01-02")))
+	fmt.Println("------------------------")
+
+	if summary.Total == 0 {
+		fmt.Println(i18n.T("stats.empty"))
+		return
+	}
+
+	fmt.Println(i18n.T("stats.total", summary.Total, summary.ActiveDays))
+	fmt.Println(i18n.T("stats.satisfaction", summary.MeanSatisfaction, summary.MedianSatisfaction))
+	fmt.Println(i18n.T("stats.streak", summary.Streak.Current, summary.Streak.Longest))
+	fmt.Println("------------------------")
+
+	fmt.Println(i18n.T("stats.by_category"))
+	categoryMax := 0
+	for _, count := range summary.CategoryCounts {
+		categoryMax = max(categoryMax, count)
+	}
+	for _, category := range models.Categories() {
+		fmt.Printf("  %-10s %s %d\n", i18n.CategoryLabel(category), bar(summary.CategoryCounts[category], categoryMax), summary.CategoryCounts[category])
+	}
+
+	fmt.Println(i18n.T("stats.top_entries"))
+	for _, entry := range summary.TopEntries {
+		fmt.Printf("  [%s] %d/5 %s\n", entry.ID, entry.Satisfaction, entry.Body())
+	}
+
+	fmt.Println(i18n.T("stats.by_hour"))
+	hourMax := slices.Max(summary.HourOfDay[:])
+	for hour, count := range summary.HourOfDay {
+		if count > 0 {
+			fmt.Printf("  %s %s %d\n", i18n.T("stats.hour", hour), bar(count, hourMax), count)
+		}
+	}
+
+	fmt.Println(i18n.T("stats.by_weekday"))
+	// Show Monday first, Sunday last
+	weekdayMax := slices.Max(summary.Weekday[:])
+	for i := 1; i <= 7; i++ {
+		day := time.Weekday(i % 7)
+		count := summary.Weekday[day]
+		fmt.Printf("  %s %s %d\n", i18n.T(fmt.Sprintf("weekday.short.%d", day)), bar(count, weekdayMax), count)
+	}
+}
+
+// barWidth is the length of the bar of the largest count
+const barWidth = 30
+
+// bar draws a horizontal bar for a count, scaled so the largest count of the
+// section fills barWidth. Any count above zero gets at least one block.
+func bar(count, largest int) string {
+	if count <= 0 || largest <= 0 {
+		return ""
+	}
+	return strings.Repeat("█", (count*barWidth+largest-1)/largest)
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+
+	statsCmd.Flags().StringVar(&statsSince, "since", "30d", "")
+	statsCmd.Flags().IntVar(&statsTop, "top", stats.DefaultTopN, "")
+	statsCmd.Flags().StringVarP(&statsOutput, "output", "o", "text", "")
+
+	localizeCommand(statsCmd, "stats")
+	localizeFlag(statsCmd.Flags(), "since", "stats.since_flag")
+	localizeFlag(statsCmd.Flags(), "top", "stats.top_flag")
+	localizeFlag(statsCmd.Flags(), "output", "flag.output")
+}
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/econron/wamon/internal/db"
+	"github.com/econron/wamon/internal/i18n"
+	"github.com/econron/wamon/internal/models"
+	"github.com/econron/wamon/internal/stats"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2025, 4, 30, 12, 0, 0, 0, time.Local)
+
+	since, err := parseSince("30d", now)
+	assert.NoError(t, err)
+	assert.Equal(t, now.AddDate(0, 0, -30), since)
+
+	since, err = parseSince("2w", now)
+	assert.NoError(t, err)
+	assert.Equal(t, now.AddDate(0, 0, -14), since)
+
+	since, err = parseSince("24h", now)
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(-24*time.Hour), since)
+
+	since, err = parseSince("2025-04-01", now)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 4, 1, 0, 0, 0, 0, time.Local), since)
+
+	_, err = parseSince("yesterday", now)
+	assert.Error(t, err)
+}
+
+func TestStatsCommand(t *testing.T) {
+	testDBPath, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	database, err := db.NewDB(testDBPath)
+	assert.NoError(t, err)
+	createTestEntries(t, database, 3)
+	database.Close()
+
+	statsSince = "30d"
+	statsOutput = "text"
+	output := captureOutput(func() {
+		statsCmd.Run(statsCmd, []string{})
+	})
+	assert.Contains(t, output, "記録数: 3件")
+	assert.Contains(t, output, "カテゴリ別:")
+	assert.Contains(t, output, "曜日別:")
+}
+
+func TestStatsCommandJSON(t *testing.T) {
+	testDBPath, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	database, err := db.NewDB(testDBPath)
+	assert.NoError(t, err)
+	createTestEntries(t, database, 4)
+	database.Close()
+
+	statsSince = "30d"
+	statsOutput = "json"
+	defer func() { statsOutput = "text" }()
+	output := captureOutput(func() {
+		statsCmd.Run(statsCmd, []string{})
+	})
+
+	var summary stats.Summary
+	assert.NoError(t, json.Unmarshal([]byte(output), &summary))
+	assert.Equal(t, 4, summary.Total)
+	assert.Equal(t, 3.0, summary.MeanSatisfaction)
+}
+
+func TestStatsCommandEmptyAndInvalid(t *testing.T) {
+	_, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	statsSince = "30d"
+	statsOutput = "text"
+	output := captureOutput(func() {
+		statsCmd.Run(statsCmd, []string{})
+	})
+	assert.Contains(t, output, "この期間の記録がありません")
+
+	statsSince = "soon"
+	defer func() { statsSince = "30d" }()
+	output = captureOutput(func() {
+		statsCmd.Run(statsCmd, []string{})
+	})
+	assert.Contains(t, output, "期間の形式が不正です")
+}
+
+func TestLoadCalendar(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	// Nothing configured: every day counts
+	calendar, err := loadCalendar()
+	assert.NoError(t, err)
+	assert.Nil(t, calendar)
+
+	viper.Set("streak.non_working_days", []string{"sat", "sun"})
+	viper.Set("streak.holidays", "jp")
+	calendar, err = loadCalendar()
+	assert.NoError(t, err)
+	assert.False(t, calendar.IsWorkingDay(time.Date(2025, 4, 5, 9, 0, 0, 0, time.Local)))  // Saturday
+	assert.False(t, calendar.IsWorkingDay(time.Date(2025, 4, 29, 9, 0, 0, 0, time.Local))) // 昭和の日
+	assert.True(t, calendar.IsWorkingDay(time.Date(2025, 4, 30, 9, 0, 0, 0, time.Local)))
+
+	viper.Set("streak.holidays", "atlantis")
+	_, err = loadCalendar()
+	assert.Error(t, err)
+}
+
+func TestWarnHolidaysEnded(t *testing.T) {
+	calendar, err := stats.NewCalendar(nil)
+	assert.NoError(t, err)
+	assert.NoError(t, calendar.LoadBuiltinHolidays("jp"))
+
+	var out bytes.Buffer
+	warnHolidaysEnded(&out, calendar, "jp", time.Date(2027, 12, 31, 9, 0, 0, 0, time.Local))
+	assert.Empty(t, out.String())
+
+	// Past the last year of the list the streak would break on holidays
+	warnHolidaysEnded(&out, calendar, "jp", time.Date(2028, 1, 1, 9, 0, 0, 0, time.Local))
+	assert.Equal(t, i18n.T("stats.holidays_ended", "jp", 2027)+"\n", out.String())
+}
+
+func TestComputeStreakFromDatabase(t *testing.T) {
+	testDBPath, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+	viper.Reset()
+
+	database, err := db.NewDB(testDBPath)
+	assert.NoError(t, err)
+	defer database.Close()
+
+	now := time.Now()
+	for i, created := range []time.Time{now, now.AddDate(0, 0, -1), now.AddDate(0, 0, -3)} {
+		assert.NoError(t, database.SaveEntry(&models.Entry{
+			ID: fmt.Sprintf("streak-%d", i), Category: models.Research, ResearchTopic: "x", Satisfaction: 3, CreatedAt: created,
+		}))
+	}
+
+	streak, err := computeStreak(database)
+	assert.NoError(t, err)
+	assert.Equal(t, stats.Streak{Current: 2, Longest: 2}, streak)
+}
+
+func TestBar(t *testing.T) {
+	assert.Equal(t, "", bar(0, 10))
+	assert.Equal(t, "", bar(3, 0))
+	assert.Equal(t, strings.Repeat("█", barWidth), bar(500, 500))
+	assert.Equal(t, strings.Repeat("█", barWidth/2), bar(250, 500))
+
+	// Small counts still show, and bars never grow past the width
+	assert.Equal(t, "█", bar(1, 1000))
+	assert.Len(t, []rune(bar(3, 3)), barWidth)
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/econron/wamon/internal/db"
+	"github.com/econron/wamon/internal/i18n"
+	"github.com/econron/wamon/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// tuiCmd represents the tui command
+var tuiCmd = &cobra.Command{
+	Use:  "tui",
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		// Initialize database
+		database, err := db.NewDB(dbPath)
+		if err != nil {
+			fmt.Println(i18n.T("error.db_init", err, dbPath))
+			return
+		}
+		defer database.Close()
+
+		// Hook failures would garble the screen, so they are printed when the browser closes
+		var hookErrors []error
+		database, err = withHooks(database, func(err error) {
+			hookErrors = append(hookErrors, err)
+		})
+		if err != nil {
+			fmt.Println(i18n.T("error.config_load", err))
+			return
+		}
+		defer func() {
+			for _, err := range hookErrors {
+				printHookWarning(err)
+			}
+		}()
+
+		calendar, err := loadCalendar()
+		if err != nil {
+			fmt.Println(i18n.T("error.calendar", err))
+		}
+
+		browser, err := tui.NewBrowser(database, calendar)
+		if err != nil {
+			fmt.Println(i18n.T("error.fetch", err))
+			return
+		}
+
+		if err := browser.Run(); err != nil {
+			fmt.Println(i18n.T("tui.error", err))
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+
+	localizeCommand(tuiCmd, "tui")
+}
@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTuiCommandDBError tests the tui command with a database error
+func TestTuiCommandDBError(t *testing.T) {
+	originalDBPath := dbPath
+	defer func() { dbPath = originalDBPath }()
+
+	// A directory cannot be opened as a database file
+	dbPath = t.TempDir()
+
+	output := captureOutput(func() {
+		tuiCmd.Run(tuiCmd, []string{})
+	})
+	assert.Contains(t, output, "データベースの初期化エラー")
+}
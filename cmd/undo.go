@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/econron/wamon/internal/db"
+	"github.com/econron/wamon/internal/i18n"
+	"github.com/econron/wamon/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// undoPreviewIDs is how many entry IDs are shown for an operation
+const undoPreviewIDs = 5
+
+var undoList bool
+var undoLimit int
+
+// undoCmd reverts the most recent operation, or the one with the given ID
+var undoCmd = &cobra.Command{
+	Use:  "undo [OPERATION_ID]",
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var id int64
+		if len(args) == 1 {
+			parsed, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil || parsed <= 0 {
+				fmt.Println(i18n.T("undo.invalid_id", args[0]))
+				return
+			}
+			id = parsed
+		}
+
+		// Initialize database
+		database, err := db.NewDB(dbPath)
+		if err != nil {
+			fmt.Println(i18n.T("error.db_init", err, dbPath))
+			return
+		}
+		defer database.Close()
+
+		if undoList {
+			operations, err := database.GetOperations(undoLimit)
+			if err != nil {
+				fmt.Println(i18n.T("error.fetch", err))
+				return
+			}
+			printOperations(operations)
+			return
+		}
+
+		// Undoing runs the post hooks of the changes it makes
+		database, err = withHooks(database, printHookWarning)
+		if err != nil {
+			fmt.Println(i18n.T("error.config_load", err))
+			return
+		}
+
+		op, err := database.UndoOperation(id)
+		if err != nil {
+			printUndoError(err, id)
+			return
+		}
+
+		fmt.Println(i18n.T("undo.done", op.ID, operationKindLabel(op.Kind), len(op.EntryIDs)))
+		fmt.Println(i18n.T("undo.entries", operationEntryPreview(op)))
+	},
+}
+
+// printUndoError explains why an operation could not be undone
+func printUndoError(err error, id int64) {
+	var conflict *db.UndoConflictError
+	switch {
+	case errors.Is(err, db.ErrNothingToUndo):
+		fmt.Println(i18n.T("undo.nothing"))
+	case errors.Is(err, db.ErrAlreadyUndone):
+		fmt.Println(i18n.T("undo.already_undone", id))
+	case errors.Is(err, sql.ErrNoRows):
+		fmt.Println(i18n.T("undo.not_found", id))
+	case errors.As(err, &conflict):
+		fmt.Println(i18n.T("undo.conflict", conflict.OperationID, conflict.LaterID, conflict.EntryID, conflict.LaterID))
+	default:
+		fmt.Println(i18n.T("undo.error", err))
+	}
+}
+
+// printOperations prints the operation journal, newest first
+func printOperations(operations []*models.Operation) {
+	if len(operations) == 0 {
+		fmt.Println(i18n.T("undo.list_empty"))
+		return
+	}
+
+	fmt.Println(i18n.T("undo.list_title"))
+	fmt.Println("------------------------")
+	for _, op := range operations {
+		status := ""
+		if op.UndoneAt != nil {
+			status = " " + i18n.T("undo.undone_mark")
+		}
+		fmt.Printf("#%-5d %s  %s%s\n", op.ID, formatDate(op.CreatedAt), i18n.T("undo.summary", operationKindLabel(op.Kind), len(op.EntryIDs)), status)
+		fmt.Println("       " + operationEntryPreview(op))
+	}
+	fmt.Println("------------------------")
+	fmt.Println(i18n.T("undo.list_hint"))
+}
+
+// operationKindLabel returns the display name of an operation kind
+func operationKindLabel(kind models.OperationKind) string {
+	return i18n.T("operation." + string(kind))
+}
+
+// operationEntryPreview lists the first entry IDs of an operation
+func operationEntryPreview(op *models.Operation) string {
+	if len(op.EntryIDs) <= undoPreviewIDs {
+		return strings.Join(op.EntryIDs, ", ")
+	}
+	return i18n.T("undo.more_entries", strings.Join(op.EntryIDs[:undoPreviewIDs], ", "), len(op.EntryIDs)-undoPreviewIDs)
+}
+
+func init() {
+	rootCmd.AddCommand(undoCmd)
+
+	undoCmd.Flags().BoolVar(&undoList, "list", false, "")
+	undoCmd.Flags().IntVar(&undoLimit, "limit", 20, "")
+
+	localizeCommand(undoCmd, "undo")
+	localizeFlag(undoCmd.Flags(), "list", "undo.list_flag")
+	localizeFlag(undoCmd.Flags(), "limit", "undo.limit_flag")
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/econron/wamon/internal/db"
+	"github.com/econron/wamon/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// runUndo runs the undo command with the given flags and arguments and returns its output
+func runUndo(t *testing.T, list bool, args ...string) string {
+	undoList = list
+	defer func() { undoList = false }()
+	return captureOutput(func() {
+		undoCmd.Run(undoCmd, args)
+	})
+}
+
+func TestUndoCommand(t *testing.T) {
+	testDBPath, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	database, err := db.NewDB(testDBPath)
+	assert.NoError(t, err)
+	entry := &models.Entry{ID: "20250401090000", Category: models.Research, ResearchTopic: "元の内容", Satisfaction: 3, CreatedAt: time.Now()}
+	assert.NoError(t, database.SaveEntry(entry))
+	entry.ResearchTopic = "誤って上書き"
+	assert.NoError(t, database.UpdateEntry(entry))
+	database.Close()
+
+	output := runUndo(t, true)
+	assert.Contains(t, output, "#2")
+	assert.Contains(t, output, "編集 1件")
+	assert.Contains(t, output, "#1")
+	assert.Contains(t, output, "追加 1件")
+
+	output = runUndo(t, false)
+	assert.Contains(t, output, "操作 #2（編集, 1件）を取り消しました")
+	assert.Contains(t, output, "対象: 20250401090000")
+
+	database, err = db.NewDB(testDBPath)
+	assert.NoError(t, err)
+	restored, err := database.GetEntryByID(entry.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "元の内容", restored.ResearchTopic)
+	database.Close()
+
+	output = runUndo(t, true)
+	assert.Contains(t, output, "(取り消し済み)")
+
+	output = runUndo(t, false, "2")
+	assert.Contains(t, output, "すでに取り消されています")
+
+	output = runUndo(t, false, "1")
+	assert.Contains(t, output, "操作 #1（追加, 1件）を取り消しました")
+
+	output = runUndo(t, false)
+	assert.Contains(t, output, "取り消せる操作がありません")
+}
+
+func TestUndoCommandConflict(t *testing.T) {
+	testDBPath, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	database, err := db.NewDB(testDBPath)
+	assert.NoError(t, err)
+	entry := &models.Entry{ID: "1", Category: models.Research, ResearchTopic: "a", Satisfaction: 3, CreatedAt: time.Now()}
+	assert.NoError(t, database.SaveEntry(entry))
+	assert.NoError(t, database.DeleteEntry(entry.ID))
+	database.Close()
+
+	output := runUndo(t, false, "1")
+	assert.Contains(t, output, "操作 #2 が記録 1 を変更しています")
+	assert.Contains(t, output, "wamon undo 2")
+}
+
+func TestUndoCommandInvalidID(t *testing.T) {
+	_, cleanup := setupTestEnvironment(t)
+	defer cleanup()
+
+	assert.Contains(t, runUndo(t, false, "abc"), "操作IDが正しくありません")
+	assert.Contains(t, runUndo(t, false, "99"), "操作 #99 が見つかりません")
+	assert.Contains(t, runUndo(t, true), "記録された操作がありません")
+}
+
+func TestOperationEntryPreview(t *testing.T) {
+	op := &models.Operation{EntryIDs: []string{"a", "b", "c", "d", "e", "f", "g"}}
+	assert.Equal(t, "a, b, c, d, e ほか2件", operationEntryPreview(op))
+}
@@ -0,0 +1,192 @@
+package art
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/econron/wamon/internal/i18n"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed packs/*.yaml
+var builtinPacks embed.FS
+
+// DefaultPack is the art pack used when none is configured
+const DefaultPack = "seal"
+
+// DefaultMood is drawn when a pack has no frames for the requested mood
+const DefaultMood = "happy"
+
+// Moods are the expressions the built-in feedback rules use
+var Moods = []string{"happy", "excited", "proud", "calm", "caring"}
+
+// Extension is the file extension of user art packs
+const Extension = ".yaml"
+
+// Pack is a set of frames for each mood
+type Pack struct {
+	Name  string              `yaml:"name"`
+	Moods map[string][]string `yaml:"moods"`
+}
+
+// Options control how the seal is drawn
+type Options struct {
+	// Animate plays the frames in turn before the last one stays on screen.
+	// It rewrites lines with ANSI escapes, so it is only for terminals.
+	Animate bool
+	// Loops is how many times the frames are played; defaults to 2
+	Loops int
+	// Delay is the time each frame is shown; defaults to 150ms
+	Delay time.Duration
+	// Sleep waits between frames; defaults to time.Sleep
+	Sleep func(time.Duration)
+}
+
+// Parse parses and validates an art pack
+func Parse(name string, data []byte) (*Pack, error) {
+	pack := &Pack{}
+	if err := yaml.Unmarshal(data, pack); err != nil {
+		return nil, errors.New(i18n.T("art.parse_error", name, err))
+	}
+	if pack.Name == "" {
+		pack.Name = name
+	}
+	if len(pack.Moods[DefaultMood]) == 0 {
+		return nil, errors.New(i18n.T("art.missing_mood", name, DefaultMood))
+	}
+	for mood, frames := range pack.Moods {
+		for i, frame := range frames {
+			frames[i] = strings.TrimRight(frame, "\n")
+		}
+		pack.Moods[mood] = frames
+	}
+	return pack, nil
+}
+
+// Load returns the art pack called name. name can be the path of a YAML file,
+// the name of a pack in dir, or a built-in pack; empty means the default pack.
+func Load(name, dir string) (*Pack, error) {
+	if name == "" {
+		name = DefaultPack
+	}
+
+	if strings.ContainsRune(name, filepath.Separator) || filepath.Ext(name) == Extension {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			return nil, errors.New(i18n.T("art.read_error", name, err))
+		}
+		return Parse(strings.TrimSuffix(filepath.Base(name), Extension), data)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, name+Extension))
+	if err == nil {
+		return Parse(name, data)
+	}
+	if !os.IsNotExist(err) {
+		return nil, errors.New(i18n.T("art.read_error", name, err))
+	}
+
+	data, err = builtinPacks.ReadFile("packs/" + name + Extension)
+	if err != nil {
+		return nil, errors.New(i18n.T("art.not_found", name, dir))
+	}
+	return Parse(name, data)
+}
+
+// List returns the names of the built-in packs and the user packs in dir
+func List(dir string) []string {
+	seen := make(map[string]bool)
+	files, _ := builtinPacks.ReadDir("packs")
+	if userFiles, err := os.ReadDir(dir); err == nil {
+		files = append(files, userFiles...)
+	}
+	var names []string
+	for _, file := range files {
+		name := strings.TrimSuffix(file.Name(), Extension)
+		if !file.IsDir() && strings.HasSuffix(file.Name(), Extension) && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Dir returns the directory user art packs are read from
+func Dir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "art"
+	}
+	return filepath.Join(home, ".wamon", "art")
+}
+
+// Frames returns the frames for mood, falling back to the default mood
+func (p *Pack) Frames(mood string) []string {
+	if frames := p.Moods[mood]; len(frames) > 0 {
+		return frames
+	}
+	return p.Moods[DefaultMood]
+}
+
+// Render draws the seal with the given mood followed by the message.
+// When animating, the frames are redrawn in place before the final one is left on screen.
+func Render(w io.Writer, pack *Pack, mood, message string, opts Options) {
+	if opts.Loops <= 0 {
+		opts.Loops = 2
+	}
+	if opts.Delay <= 0 {
+		opts.Delay = 150 * time.Millisecond
+	}
+	if opts.Sleep == nil {
+		opts.Sleep = time.Sleep
+	}
+
+	frames := pack.Frames(mood)
+	height := 0
+	for _, frame := range frames {
+		if n := len(strings.Split(frame, "\n")); n > height {
+			height = n
+		}
+	}
+
+	fmt.Fprintln(w)
+	if opts.Animate && len(frames) > 1 {
+		for loop := 0; loop < opts.Loops; loop++ {
+			for _, frame := range frames {
+				drawFrame(w, frame, height, true)
+				opts.Sleep(opts.Delay)
+				// Move back to the top of the frame to draw the next one over it
+				fmt.Fprintf(w, "\x1b[%dA", height)
+			}
+		}
+	}
+	drawFrame(w, frames[0], height, opts.Animate)
+	if message != "" {
+		fmt.Fprintln(w, message)
+	}
+	fmt.Fprintln(w)
+}
+
+// drawFrame writes the frame padded to height lines.
+// With erase set, each line is erased first so a previous frame does not show through.
+func drawFrame(w io.Writer, frame string, height int, erase bool) {
+	lines := strings.Split(frame, "\n")
+	for i := 0; i < height; i++ {
+		line := ""
+		if i < len(lines) {
+			line = lines[i]
+		}
+		if erase {
+			line = "\x1b[2K" + line
+		}
+		fmt.Fprintln(w, line)
+	}
+}
@@ -0,0 +1,139 @@
+package art
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuiltinPacks(t *testing.T) {
+	for _, name := range []string{"seal", "tiny"} {
+		pack, err := Load(name, t.TempDir())
+		assert.NoError(t, err)
+		assert.Equal(t, name, pack.Name)
+
+		for _, mood := range Moods {
+			frames := pack.Moods[mood]
+			assert.NotEmpty(t, frames, "%s has no %s frames", name, mood)
+
+			// Every frame of a mood has the same height so animation redraws cleanly
+			for _, frame := range frames {
+				assert.Equal(t, strings.Count(frames[0], "\n"), strings.Count(frame, "\n"))
+				assert.False(t, strings.HasSuffix(frame, "\n"))
+			}
+		}
+	}
+
+	// The leading spaces of the first line are kept
+	pack, err := Load(DefaultPack, t.TempDir())
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(pack.Moods[DefaultMood][0], "  "))
+}
+
+func TestParse(t *testing.T) {
+	pack, err := Parse("mine", []byte("moods:\n  happy:\n    - \"(^_^)\"\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "mine", pack.Name)
+
+	_, err = Parse("mine", []byte("moods: ["))
+	assert.Error(t, err)
+
+	// Every pack needs the default mood to fall back to
+	_, err = Parse("mine", []byte("moods:\n  proud:\n    - \"(-_-)\"\n"))
+	assert.Error(t, err)
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "seal.yaml"), []byte("moods:\n  happy:\n    - \"my seal\"\n"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "other.yaml"), []byte("moods:\n  happy:\n    - \"other\"\n"), 0644))
+
+	// A user pack replaces the built-in one with the same name
+	pack, err := Load("seal", dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"my seal"}, pack.Moods["happy"])
+
+	// A path is read directly
+	pack, err = Load(filepath.Join(dir, "other.yaml"), t.TempDir())
+	assert.NoError(t, err)
+	assert.Equal(t, "other", pack.Name)
+
+	// An empty name is the default pack
+	pack, err = Load("", t.TempDir())
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultPack, pack.Name)
+
+	_, err = Load("missing", dir)
+	assert.Error(t, err)
+	_, err = Load(filepath.Join(dir, "missing.yaml"), dir)
+	assert.Error(t, err)
+}
+
+func TestList(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "mine.yaml"), []byte(""), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "seal.yaml"), []byte(""), 0644))
+
+	assert.Equal(t, []string{"mine", "seal", "tiny"}, List(dir))
+}
+
+func TestFrames(t *testing.T) {
+	pack := &Pack{Moods: map[string][]string{
+		"happy": {"happy"},
+		"proud": {"proud"},
+	}}
+
+	assert.Equal(t, []string{"proud"}, pack.Frames("proud"))
+	// Unknown and empty moods fall back to the default mood
+	assert.Equal(t, []string{"happy"}, pack.Frames("sleepy"))
+	assert.Equal(t, []string{"happy"}, pack.Frames(""))
+}
+
+func TestRender(t *testing.T) {
+	pack := &Pack{Moods: map[string][]string{
+		"happy": {"(^w^)/\n  ~", "(^w^)ノ\n  ~"},
+	}}
+
+	var buf bytes.Buffer
+	Render(&buf, pack, "happy", "Well done!", Options{})
+	assert.Equal(t, "\n(^w^)/\n  ~\nWell done!\n\n", buf.String())
+}
+
+func TestRenderAnimated(t *testing.T) {
+	pack := &Pack{Moods: map[string][]string{
+		"happy": {"(^w^)/", "(^w^)ノ"},
+	}}
+
+	var sleeps []time.Duration
+	var buf bytes.Buffer
+	Render(&buf, pack, "happy", "Well done!", Options{
+		Animate: true,
+		Loops:   3,
+		Sleep:   func(d time.Duration) { sleeps = append(sleeps, d) },
+	})
+
+	// Each frame is shown once per loop with the default delay
+	assert.Len(t, sleeps, 6)
+	assert.Equal(t, 150*time.Millisecond, sleeps[0])
+
+	output := buf.String()
+	assert.Equal(t, 6, strings.Count(output, "\x1b[1A"))
+	assert.Equal(t, 3, strings.Count(output, "(^w^)ノ"))
+	// The first frame is left on screen at the end
+	assert.True(t, strings.HasSuffix(output, "\x1b[2K(^w^)/\nWell done!\n\n"))
+}
+
+func TestRenderSingleFrameDoesNotAnimate(t *testing.T) {
+	pack := &Pack{Moods: map[string][]string{"happy": {"(^w^)"}}}
+
+	slept := false
+	var buf bytes.Buffer
+	Render(&buf, pack, "happy", "", Options{Animate: true, Sleep: func(time.Duration) { slept = true }})
+	assert.False(t, slept)
+	assert.Contains(t, buf.String(), "(^w^)")
+}
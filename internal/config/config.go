@@ -1,9 +1,12 @@
 package config
 
 import (
-	"os"
-	"path/filepath"
+	"errors"
+	"fmt"
+	"time"
 
+	"github.com/econron/wamon/internal/hooks"
+	"github.com/econron/wamon/internal/i18n"
 	"github.com/econron/wamon/internal/slack"
 	"github.com/spf13/viper"
 )
@@ -11,91 +14,225 @@ import (
 // AppConfig holds all application configuration
 type AppConfig struct {
 	Slack        slack.Config
+	SlackSecret  SlackSecretConfig
 	DatabasePath string
+	Streak       StreakConfig
+	Report       ReportConfig
+	Art          ArtConfig
+	Hooks        hooks.Config
+}
+
+// StreakConfig holds the days that don't break a streak
+type StreakConfig struct {
+	NonWorkingDays []string // weekdays off, e.g. ["sat", "sun"]
+	Holidays       string   // built-in holiday list, e.g. "jp"
+	HolidaysFile   string   // local file with one "YYYY-MM-DD name" per line
+}
+
+// ReportConfig holds how report periods are computed
+type ReportConfig struct {
+	WeekStart string // first day of a week, e.g. "mon" or "sun"
+}
+
+// ArtConfig holds how the seal is drawn after saving an entry
+type ArtConfig struct {
+	Pack    string // built-in pack name, pack in ~/.wamon/art, or path to a YAML file
+	Plain   bool   // print only the message, for scripts and screen readers
+	Animate bool   // play the frames when stdout is a terminal
 }
 
 // LoadConfig loads the application configuration from viper and environment variables
 func LoadConfig() (*AppConfig, error) {
-	// First try environment variables
-	slackToken := os.Getenv("WAMON_SLACK_TOKEN")
-	slackChannel := os.Getenv("WAMON_SLACK_CHANNEL")
-	dbPath := os.Getenv("WAMON_DB_PATH")
-
-	// If not set in env vars, use viper config
-	if slackToken == "" {
-		slackToken = viper.GetString("slack.token")
-	}
-	if slackChannel == "" {
-		slackChannel = viper.GetString("slack.channel")
-	}
-	if dbPath == "" {
-		dbPath = viper.GetString("database.path")
+	// Environment variables take precedence over the config file
+	slackToken := stringValue("slack.token")
+	slackChannel := stringValue("slack.channel")
+	dbPath := DatabasePath()
+
+	// The token file and command are only read by ResolveSlackToken
+	slackSecret := SlackSecretConfig{
+		TokenFile:    viper.GetString("slack.token_file"),
+		TokenCommand: viper.GetString("slack.token_command"),
 	}
 
 	// Set enabled if we have a token
-	enabled := slackToken != ""
+	enabled := slackToken != "" || slackSecret.TokenFile != "" || slackSecret.TokenCommand != ""
 
+	var err error
 	config := &AppConfig{
 		Slack: slack.Config{
 			Token:   slackToken,
 			Channel: slackChannel,
 			Enabled: enabled,
 		},
+		SlackSecret:  slackSecret,
 		DatabasePath: dbPath,
+		Streak: StreakConfig{
+			NonWorkingDays: viper.GetStringSlice("streak.non_working_days"),
+			Holidays:       viper.GetString("streak.holidays"),
+			HolidaysFile:   viper.GetString("streak.holidays_file"),
+		},
+		Report: ReportConfig{
+			WeekStart: viper.GetString("report.week_start"),
+		},
+		Art: ArtConfig{
+			Pack:    viper.GetString("art.pack"),
+			Plain:   viper.GetBool("art.plain"),
+			Animate: viper.GetBool("art.animate"),
+		},
+	}
+
+	config.Hooks, err = loadHooks()
+	if err != nil {
+		return nil, err
 	}
 
 	return config, nil
 }
 
-// SaveSlackConfig saves Slack configuration permanently
-func SaveSlackConfig(token, channel string) error {
-	// Set the values
-	viper.Set("slack.token", token)
-	viper.Set("slack.channel", channel)
-	viper.Set("slack.enabled", true)
-
-	// Ensure config directory exists
-	configDir := filepath.Join(os.Getenv("HOME"), ".wamon")
-	if err := os.MkdirAll(configDir, 0755); err != nil {
-		return err
-	}
+// DatabasePath returns the database path from WAMON_DB_PATH or the config file, or "" when it is not configured
+func DatabasePath() string {
+	return stringValue("database.path")
+}
 
-	// Save to file
-	configFile := filepath.Join(configDir, ".wamon.yaml")
-	if err := viper.WriteConfigAs(configFile); err != nil {
-		return err
+// loadHooks reads the hooks section. Each event has a list of hooks, written
+// either as a command path or as a map with command, args and timeout:
+//
+//	hooks:
+//	  timeout: 5s
+//	  pre-save:
+//	    - ~/bin/check-entry
+//	    - command: ~/bin/tag-entry
+//	      args: ["--auto"]
+//	      timeout: 2s
+func loadHooks() (hooks.Config, error) {
+	return parseHooks(viper.GetStringMap("hooks"))
+}
+
+// parseHooks reads the hooks section from its values
+func parseHooks(section map[string]interface{}) (hooks.Config, error) {
+	hookConfig := hooks.Config{Hooks: make(map[hooks.Event][]hooks.Hook)}
+
+	for key, value := range section {
+		if key == "timeout" {
+			timeout, err := parseDuration(value)
+			if err != nil {
+				return hookConfig, errors.New(i18n.T("hook.config_invalid_timeout", "hooks.timeout", value))
+			}
+			hookConfig.Timeout = timeout
+			continue
+		}
+
+		event, ok := hooks.ParseEvent(key)
+		if !ok {
+			return hookConfig, errors.New(i18n.T("hook.config_unknown_event", key))
+		}
+		items, ok := value.([]interface{})
+		if !ok {
+			return hookConfig, errors.New(i18n.T("hook.config_invalid", key))
+		}
+		for i, item := range items {
+			hook, err := parseHook(item)
+			if err != nil {
+				return hookConfig, errors.New(i18n.T("hook.config_invalid_hook", key, i+1, err))
+			}
+			hookConfig.Hooks[event] = append(hookConfig.Hooks[event], hook)
+		}
 	}
+	return hookConfig, nil
+}
 
-	// 設定を再読み込み
-	viper.SetConfigFile(configFile)
-	return viper.ReadInConfig()
+// parseHook reads one hook written as a command path or as a map
+func parseHook(item interface{}) (hooks.Hook, error) {
+	switch value := item.(type) {
+	case string:
+		if value == "" {
+			return hooks.Hook{}, errors.New(i18n.T("hook.config_missing_command"))
+		}
+		return hooks.Hook{Command: value}, nil
+	case map[string]interface{}:
+		hook := hooks.Hook{}
+		hook.Command, _ = value["command"].(string)
+		if hook.Command == "" {
+			return hook, errors.New(i18n.T("hook.config_missing_command"))
+		}
+		if args, ok := value["args"].([]interface{}); ok {
+			for _, arg := range args {
+				hook.Args = append(hook.Args, fmt.Sprint(arg))
+			}
+		}
+		if timeout, ok := value["timeout"]; ok {
+			d, err := parseDuration(timeout)
+			if err != nil {
+				return hook, errors.New(i18n.T("hook.config_invalid_timeout", "timeout", timeout))
+			}
+			hook.Timeout = d
+		}
+		return hook, nil
+	default:
+		return hooks.Hook{}, errors.New(i18n.T("hook.config_missing_command"))
+	}
 }
 
-// SaveDatabasePath saves the database path to configuration
-func SaveDatabasePath(path string) error {
-	// Set the value
-	viper.Set("database.path", path)
+// parseDuration accepts durations such as "5s" or a number of seconds
+func parseDuration(value interface{}) (time.Duration, error) {
+	switch v := value.(type) {
+	case int:
+		return time.Duration(v) * time.Second, nil
+	case int64:
+		return time.Duration(v) * time.Second, nil
+	case float64:
+		return time.Duration(v * float64(time.Second)), nil
+	case string:
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return 0, errors.New(v)
+		}
+		return d, nil
+	default:
+		return 0, fmt.Errorf("%v", value)
+	}
+}
 
-	// Ensure config directory exists
-	configDir := filepath.Join(os.Getenv("HOME"), ".wamon")
-	if err := os.MkdirAll(configDir, 0755); err != nil {
+// SaveSlackConfig saves Slack configuration permanently. The token is kept in
+// its own private file, which slack.token_file points to, and not in the config file.
+func SaveSlackConfig(token, channel string) error {
+	path := SecretPath()
+	if err := WriteSecretFile(path, token); err != nil {
 		return err
 	}
 
-	// Save to file
-	configFile := filepath.Join(configDir, ".wamon.yaml")
-	if err := viper.WriteConfigAs(configFile); err != nil {
+	file, err := ReadFile(ActivePath())
+	if err != nil {
 		return err
 	}
+	file.Unset("slack.token")
+	file.Unset("slack.token_command")
+	file.Set("slack.token_file", path)
+	file.Set("slack.channel", channel)
+	file.Set("slack.enabled", true)
+	if err := file.Write(); err != nil {
+		return err
+	}
+	return Use(file.Path)
+}
+
+// SaveSlackChannel saves the Slack channel, leaving the token where it is
+func SaveSlackChannel(channel string) error {
+	return saveValues(map[string]interface{}{"slack.channel": channel})
+}
 
-	// 設定を再読み込み
-	viper.SetConfigFile(configFile)
-	return viper.ReadInConfig()
+// SaveDatabasePath saves the database path to configuration
+func SaveDatabasePath(path string) error {
+	return saveValues(map[string]interface{}{"database.path": path})
 }
 
-// SetDefaults sets default values for the configuration
+// SetDefaults makes viper use the defaults of the schema, so LoadConfig reads
+// the values config list shows. Defaults that depend on the machine, such as the
+// language and the database path, are resolved where they are used.
 func SetDefaults() {
-	// Slack defaults
-	viper.SetDefault("slack.enabled", false)
-	viper.SetDefault("slack.channel", "general")
+	for _, field := range fields {
+		if field.Default != nil {
+			viper.SetDefault(field.Key, field.Default)
+		}
+	}
 }
@@ -3,8 +3,11 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/econron/wamon/internal/hooks"
 	"github.com/econron/wamon/internal/slack"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
@@ -19,7 +22,10 @@ func TestSetDefaults(t *testing.T) {
 
 	// Verify defaults are set
 	assert.False(t, viper.GetBool("slack.enabled"))
-	assert.Equal(t, "general", viper.GetString("slack.channel"))
+	assert.Equal(t, "", viper.GetString("slack.channel"))
+	assert.Equal(t, "mon", viper.GetString("report.week_start"))
+	assert.Equal(t, "seal", viper.GetString("art.pack"))
+	assert.True(t, viper.GetBool("art.animate"))
 }
 
 func TestLoadConfigFromEnvironment(t *testing.T) {
@@ -109,7 +115,7 @@ func TestLoadConfigWithoutToken(t *testing.T) {
 
 	// Verify Slack is disabled when token is empty
 	assert.Equal(t, "", config.Slack.Token)
-	assert.Equal(t, "general", config.Slack.Channel)
+	assert.Equal(t, "", config.Slack.Channel)
 	assert.False(t, config.Slack.Enabled)
 }
 
@@ -140,15 +146,25 @@ func TestSaveSlackConfig(t *testing.T) {
 	_, err = os.Stat(configFile)
 	assert.NoError(t, err)
 
-	// Verify config values were saved
-	assert.Equal(t, "new-token", viper.GetString("slack.token"))
+	// Verify config values were saved; the token is kept in its own private file
+	tokenFile := filepath.Join(tempDir, ".wamon", "slack-token")
+	assert.Equal(t, "", viper.GetString("slack.token"))
+	assert.Equal(t, tokenFile, viper.GetString("slack.token_file"))
 	assert.Equal(t, "new-channel", viper.GetString("slack.channel"))
 	assert.True(t, viper.GetBool("slack.enabled"))
 
+	info, err := os.Stat(tokenFile)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
 	// Load config to verify
+	t.Setenv("WAMON_SLACK_TOKEN", "")
 	config, err := LoadConfig()
 	assert.NoError(t, err)
 	assert.NotNil(t, config)
+	source, err := config.ResolveSlackToken()
+	assert.NoError(t, err)
+	assert.Equal(t, "slack.token_file", source)
 
 	// Check that the loaded config has the correct values
 	assert.Equal(t, slack.Config{
@@ -157,3 +173,99 @@ func TestSaveSlackConfig(t *testing.T) {
 		Enabled: true,
 	}, config.Slack)
 }
+
+func TestLoadStreakConfig(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	viper.Set("streak.non_working_days", []string{"sat", "sun"})
+	viper.Set("streak.holidays", "jp")
+	viper.Set("streak.holidays_file", "/tmp/holidays.txt")
+
+	config, err := LoadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, StreakConfig{
+		NonWorkingDays: []string{"sat", "sun"},
+		Holidays:       "jp",
+		HolidaysFile:   "/tmp/holidays.txt",
+	}, config.Streak)
+}
+
+func TestLoadArtConfig(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	SetDefaults()
+
+	// The seal is animated by default
+	config, err := LoadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, ArtConfig{Pack: "seal", Animate: true}, config.Art)
+
+	viper.Set("art.pack", "tiny")
+	viper.Set("art.plain", true)
+	viper.Set("art.animate", false)
+
+	config, err = LoadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, ArtConfig{Pack: "tiny", Plain: true, Animate: false}, config.Art)
+}
+
+func TestLoadHooksConfig(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(`
+hooks:
+  timeout: 5s
+  pre-save:
+    - ~/bin/check-entry
+    - command: ~/bin/tag-entry
+      args: ["--auto", 1]
+      timeout: 2
+  post-delete:
+    - command: /usr/local/bin/notify
+`))
+	assert.NoError(t, err)
+
+	config, err := LoadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, hooks.Config{
+		Timeout: 5 * time.Second,
+		Hooks: map[hooks.Event][]hooks.Hook{
+			hooks.PreSave: {
+				{Command: "~/bin/check-entry"},
+				{Command: "~/bin/tag-entry", Args: []string{"--auto", "1"}, Timeout: 2 * time.Second},
+			},
+			hooks.PostDelete: {
+				{Command: "/usr/local/bin/notify"},
+			},
+		},
+	}, config.Hooks)
+}
+
+func TestLoadHooksConfigErrors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config string
+	}{
+		{name: "Unknown event", config: "hooks:\n  on-save:\n    - /bin/true\n"},
+		{name: "Not a list", config: "hooks:\n  pre-save: /bin/true\n"},
+		{name: "Missing command", config: "hooks:\n  pre-save:\n    - args: [x]\n"},
+		{name: "Invalid timeout", config: "hooks:\n  timeout: soon\n"},
+		{name: "Invalid hook timeout", config: "hooks:\n  pre-save:\n    - command: /bin/true\n      timeout: -1s\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Reset()
+			defer viper.Reset()
+
+			viper.SetConfigType("yaml")
+			assert.NoError(t, viper.ReadConfig(strings.NewReader(tc.config)))
+
+			_, err := LoadConfig()
+			assert.Error(t, err)
+		})
+	}
+}
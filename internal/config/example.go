@@ -0,0 +1,74 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/econron/wamon/internal/i18n"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// exampleHeader opens the example config file
+const exampleHeader = `# Example configuration of wamon.
+# Copy it to ~/.wamon/.wamon.toml and uncomment what you need, or use
+# "wamon config set <key> <value>". A YAML file (~/.wamon/.wamon.yaml) works as well.
+#
+# This file is generated from the config schema; to update it, run
+#   go test ./internal/config -run TestExampleFile -update
+`
+
+// Example returns a TOML config file with every key of the schema and its
+// description. Keys with a default are written with it; the others are
+// commented out with an example value.
+func Example() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(exampleHeader)
+
+	section := ""
+	for _, field := range fields {
+		table, name := splitKey(field.Key)
+		if field.Kind == KindSection {
+			table, name = field.Key, ""
+		}
+		if table != section {
+			fmt.Fprintf(&buf, "\n[%s]\n", table)
+			section = table
+		}
+
+		buf.WriteString("\n")
+		for _, line := range strings.Split(i18n.TL(i18n.English, "config.field."+field.Key), "\n") {
+			fmt.Fprintf(&buf, "# %s\n", line)
+		}
+
+		value, commented := field.Default, false
+		if value == nil {
+			value, commented = field.Example, true
+		}
+		var data []byte
+		var err error
+		if name == "" {
+			data, err = toml.Marshal(value)
+		} else {
+			data, err = toml.Marshal(map[string]interface{}{name: value})
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+			if commented {
+				line = "# " + line
+			}
+			buf.WriteString(line + "\n")
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// splitKey splits a dotted key into its table and name; top-level keys have no table
+func splitKey(key string) (string, string) {
+	if i := strings.LastIndex(key, "."); i >= 0 {
+		return key[:i], key[i+1:]
+	}
+	return "", key
+}
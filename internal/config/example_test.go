@@ -0,0 +1,32 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var update = flag.Bool("update", false, "update the example config file")
+
+// exampleFile is the example config file shipped with the repository
+const exampleFile = "../../config/config.toml.example"
+
+func TestExampleFile(t *testing.T) {
+	data, err := Example()
+	assert.NoError(t, err)
+
+	if *update {
+		assert.NoError(t, os.WriteFile(exampleFile, data, 0644))
+	}
+
+	want, err := os.ReadFile(exampleFile)
+	assert.NoError(t, err)
+	assert.Equal(t, string(want), string(data), "run go test ./internal/config -run TestExampleFile -update")
+
+	// The example is a valid config file once uncommented
+	values, err := Parse(data, FormatTOML)
+	assert.NoError(t, err)
+	assert.Empty(t, Validate(values))
+}
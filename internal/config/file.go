@@ -0,0 +1,361 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/econron/wamon/internal/i18n"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// Supported formats of the config file
+const (
+	FormatYAML = "yaml"
+	FormatTOML = "toml"
+)
+
+// FileName is the name of the config file, without the extension
+const FileName = ".wamon"
+
+// fileNames are the config file names looked for in each directory, in order
+var fileNames = []string{FileName + ".yaml", FileName + ".yml", FileName + ".toml", FileName}
+
+// Format returns the format of a config file from its extension.
+// Files without the TOML extension are read as YAML.
+func Format(path string) string {
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		return FormatTOML
+	}
+	return FormatYAML
+}
+
+// Find returns the first config file in dirs, or "" when there is none
+func Find(dirs []string) string {
+	for _, dir := range dirs {
+		for _, name := range fileNames {
+			if path := filepath.Join(dir, name); isFile(path) {
+				return path
+			}
+		}
+	}
+	return ""
+}
+
+// DefaultPath returns where the config file is created when there is none yet
+func DefaultPath() string {
+	return filepath.Join(homeDir(), ".wamon", FileName+".yaml")
+}
+
+// ActivePath returns the config file in use, or the one Resolve finds
+func ActivePath() string {
+	if path := viper.ConfigFileUsed(); path != "" {
+		return path
+	}
+	return Resolve("").Path
+}
+
+// File is the content of a config file as plain values, so keys can be changed
+// and written back without the defaults and environment variables viper adds
+type File struct {
+	Path   string
+	values map[string]interface{}
+}
+
+// ReadFile reads a config file; a missing file is read as empty
+func ReadFile(path string) (*File, error) {
+	file := &File{Path: path, values: make(map[string]interface{})}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return file, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	file.values, err = Parse(data, Format(path))
+	if err != nil {
+		return nil, errors.New(i18n.T("config.parse_error", path, err))
+	}
+	return file, nil
+}
+
+// Parse decodes the content of a config file
+func Parse(data []byte, format string) (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+	var err error
+	if format == FormatTOML {
+		err = toml.Unmarshal(data, &values)
+	} else {
+		err = yaml.Unmarshal(data, &values)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if values == nil {
+		// An empty YAML document
+		values = make(map[string]interface{})
+	}
+	return values, nil
+}
+
+// KeyLines returns the index of the line each key is written on, by its dotted
+// name in lower case, so problems can be shown next to the key. Keys that cannot
+// be located are left out.
+func KeyLines(data []byte, format string) map[string]int {
+	lines := make(map[string]int)
+	if format == FormatTOML {
+		tomlKeyLines(string(data), lines)
+		return lines
+	}
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil || len(document.Content) == 0 {
+		return lines
+	}
+	yamlKeyLines(document.Content[0], "", lines)
+	return lines
+}
+
+// yamlKeyLines records the lines of the keys of a mapping node and the mappings below it
+func yamlKeyLines(node *yaml.Node, prefix string, lines map[string]int) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		full := prefix + strings.ToLower(key.Value)
+		lines[full] = key.Line - 1
+		yamlKeyLines(value, full+".", lines)
+	}
+}
+
+// tomlKeyLines records the lines of the tables and keys of a TOML document.
+// Multi-line strings are skipped.
+func tomlKeyLines(content string, lines map[string]int) {
+	table := ""
+	inString := ""
+	for i, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if inString != "" {
+			if strings.Count(line, inString)%2 == 1 {
+				inString = ""
+			}
+			continue
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			name, _, _ := strings.Cut(strings.TrimLeft(line, "["), "]")
+			table = tomlKey(name)
+			recordKeyLine(lines, table, i)
+			continue
+		}
+		name, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		key := tomlKey(name)
+		if table != "" {
+			key = table + "." + key
+		}
+		recordKeyLine(lines, key, i)
+		for _, quote := range []string{`"""`, "'''"} {
+			if strings.Count(value, quote)%2 == 1 {
+				inString = quote
+			}
+		}
+	}
+}
+
+// tomlKey normalizes a possibly dotted and quoted TOML key
+func tomlKey(name string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = strings.ToLower(strings.Trim(strings.TrimSpace(part), `"'`))
+	}
+	return strings.Join(parts, ".")
+}
+
+// recordKeyLine records the line of a key and of the tables above it that have no line yet
+func recordKeyLine(lines map[string]int, key string, line int) {
+	lines[key] = line
+	for i := strings.LastIndex(key, "."); i > 0; i = strings.LastIndex(key[:i], ".") {
+		if _, ok := lines[key[:i]]; !ok {
+			lines[key[:i]] = line
+		}
+	}
+}
+
+// Values returns the values of the file
+func (f *File) Values() map[string]interface{} {
+	return f.values
+}
+
+// Get returns the value of a dotted key such as "slack.channel"
+func (f *File) Get(key string) (interface{}, bool) {
+	var current interface{} = f.values
+	for _, part := range strings.Split(key, ".") {
+		section, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = section[part]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// Set sets the value of a dotted key, creating the sections on the way
+func (f *File) Set(key string, value interface{}) {
+	parts := strings.Split(key, ".")
+	section := f.values
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := section[part].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			section[part] = next
+		}
+		section = next
+	}
+	section[parts[len(parts)-1]] = value
+}
+
+// Unset removes a dotted key and the sections it leaves empty.
+// It reports whether the key was set.
+func (f *File) Unset(key string) bool {
+	return unset(f.values, strings.Split(key, "."))
+}
+
+// unset removes the key at parts from section
+func unset(section map[string]interface{}, parts []string) bool {
+	if len(parts) == 1 {
+		_, ok := section[parts[0]]
+		delete(section, parts[0])
+		return ok
+	}
+	next, ok := section[parts[0]].(map[string]interface{})
+	if !ok || !unset(next, parts[1:]) {
+		return false
+	}
+	if len(next) == 0 {
+		delete(section, parts[0])
+	}
+	return true
+}
+
+// Keys returns the dotted keys set in the file; sections of the schema are not expanded
+func (f *File) Keys() []string {
+	var keys []string
+	collectKeys(f.values, "", &keys)
+	sort.Strings(keys)
+	return keys
+}
+
+// collectKeys adds the keys under prefix
+func collectKeys(values map[string]interface{}, prefix string, keys *[]string) {
+	for key, value := range values {
+		full := prefix + key
+		if nested, ok := value.(map[string]interface{}); ok && isGroup(full) {
+			collectKeys(nested, full+".", keys)
+			continue
+		}
+		*keys = append(*keys, full)
+	}
+}
+
+// Encode returns the content of the file in its format
+func (f *File) Encode() ([]byte, error) {
+	if Format(f.Path) == FormatTOML {
+		return toml.Marshal(f.values)
+	}
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(f.values); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), encoder.Close()
+}
+
+// Write saves the file, creating its directory if needed
+func (f *File) Write() error {
+	data, err := f.Encode()
+	if err != nil {
+		return err
+	}
+	return WriteRaw(f.Path, data)
+}
+
+// WriteRaw saves data as the config file at path, creating its directory if needed.
+// A file holding a secret is made readable by its owner only.
+func WriteRaw(path string, data []byte) error {
+	perm := os.FileMode(0644)
+	if values, err := Parse(data, Format(path)); err != nil || hasSecrets(values) {
+		// Keep the file private when it can't be told whether it holds a secret
+		perm = secretPerm
+	}
+	slog.Info("config written", "path", path, "mode", perm)
+	return writeFile(path, data, perm)
+}
+
+// hasSecrets reports whether values set a secret key
+func hasSecrets(values map[string]interface{}) bool {
+	file := &File{values: values}
+	for _, field := range fields {
+		if value, ok := file.Get(field.Key); ok && field.Secret && value != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// writeFile saves data at path. A file already there keeps its permissions
+// unless they have to be tightened to the secret ones.
+func writeFile(path string, data []byte, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, perm); err != nil {
+		return err
+	}
+	if perm == secretPerm {
+		return os.Chmod(path, perm)
+	}
+	return nil
+}
+
+// Use makes viper read the config file at path
+func Use(path string) error {
+	viper.SetConfigFile(path)
+	viper.SetConfigType(Format(path))
+	return viper.ReadInConfig()
+}
+
+// saveValues sets keys in the config file in use and reloads it
+func saveValues(values map[string]interface{}) error {
+	path := ActivePath()
+	file, err := ReadFile(path)
+	if err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		file.Set(key, values[key])
+	}
+	if err := file.Write(); err != nil {
+		return err
+	}
+	return Use(path)
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	assert.Equal(t, FormatTOML, Format("/home/user/.wamon/.wamon.toml"))
+	assert.Equal(t, FormatTOML, Format("config.TOML"))
+	assert.Equal(t, FormatYAML, Format("/home/user/.wamon/.wamon.yaml"))
+	assert.Equal(t, FormatYAML, Format("/home/user/.wamon"))
+}
+
+func TestFind(t *testing.T) {
+	first, second := t.TempDir(), t.TempDir()
+	assert.Equal(t, "", Find([]string{first, second}))
+
+	toml := filepath.Join(second, ".wamon.toml")
+	assert.NoError(t, os.WriteFile(toml, nil, 0644))
+	assert.Equal(t, toml, Find([]string{first, second}))
+
+	// YAML is preferred within a directory, and earlier directories come first
+	yaml := filepath.Join(second, ".wamon.yaml")
+	assert.NoError(t, os.WriteFile(yaml, nil, 0644))
+	assert.Equal(t, yaml, Find([]string{first, second}))
+
+	// A directory named like the config file is skipped
+	assert.NoError(t, os.Mkdir(filepath.Join(first, ".wamon"), 0755))
+	assert.Equal(t, yaml, Find([]string{first, second}))
+}
+
+func TestFileRoundTrip(t *testing.T) {
+	for _, name := range []string{".wamon.yaml", ".wamon.toml"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+
+			// A missing file is read as empty
+			file, err := ReadFile(path)
+			assert.NoError(t, err)
+			assert.Empty(t, file.Keys())
+
+			file.Set("lang", "en")
+			file.Set("slack.channel", "random")
+			file.Set("slack.enabled", true)
+			file.Set("streak.non_working_days", []string{"sat", "sun"})
+			assert.NoError(t, file.Write())
+
+			file, err = ReadFile(path)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"lang", "slack.channel", "slack.enabled", "streak.non_working_days"}, file.Keys())
+			value, ok := file.Get("slack.channel")
+			assert.True(t, ok)
+			assert.Equal(t, "random", value)
+			assert.Empty(t, Validate(file.Values()))
+
+			// Unsetting the last key of a section removes the section
+			assert.True(t, file.Unset("streak.non_working_days"))
+			assert.False(t, file.Unset("streak.non_working_days"))
+			_, ok = file.Get("streak")
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestReadFileParseError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".wamon.toml")
+	assert.NoError(t, os.WriteFile(path, []byte("lang = \n"), 0644))
+
+	_, err := ReadFile(path)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), path)
+}
+
+func TestSaveSlackConfigToTOML(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	t.Setenv("HOME", t.TempDir())
+
+	path := filepath.Join(t.TempDir(), ".wamon.toml")
+	assert.NoError(t, os.WriteFile(path, []byte("lang = 'en'\n"), 0644))
+	assert.NoError(t, Use(path))
+
+	assert.NoError(t, SaveSlackConfig("xoxb-token", "random"))
+
+	// The TOML file in use is updated and the other keys are kept
+	file, err := ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"lang", "slack.channel", "slack.enabled", "slack.token_file"}, file.Keys())
+	assert.Equal(t, "random", viper.GetString("slack.channel"))
+	assert.Equal(t, "en", viper.GetString("lang"))
+}
+
+func TestKeyLines(t *testing.T) {
+	yamlContent := "# settings\nlang: ja\nslack:\n  # the bot\n  Token: secret\n  channel: \"#wamon\"\n"
+	assert.Equal(t, map[string]int{"lang": 1, "slack": 2, "slack.token": 4, "slack.channel": 5}, KeyLines([]byte(yamlContent), FormatYAML))
+
+	tomlContent := "lang = \"ja\"\nnote = \"\"\"\nkey = not a key\n\"\"\"\n\n[slack]\n\"token\" = \"secret\"\n\n[streak]\nholidays.country = \"jp\"\n"
+	assert.Equal(t, map[string]int{"lang": 0, "note": 1, "slack": 5, "slack.token": 6, "streak": 8, "streak.holidays": 9, "streak.holidays.country": 9},
+		KeyLines([]byte(tomlContent), FormatTOML))
+
+	// Content that cannot be parsed has no lines
+	assert.Empty(t, KeyLines([]byte("lang: [ja"), FormatYAML))
+}
@@ -0,0 +1,220 @@
+package config
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
+)
+
+// EnvConfig is the environment variable that names the config file
+const EnvConfig = "WAMON_CONFIG"
+
+// Origin tells how the config file was chosen
+type Origin string
+
+const (
+	OriginFlag     Origin = "flag"      // --config
+	OriginEnv      Origin = "env"       // WAMON_CONFIG
+	OriginXDGHome  Origin = "xdg_home"  // $XDG_CONFIG_HOME/wamon
+	OriginLegacy   Origin = "legacy"    // ~/.wamon/.wamon.yaml and ~/.wamon.yaml
+	OriginXDGDirs  Origin = "xdg_dirs"  // $XDG_CONFIG_DIRS/wamon
+	OriginNotFound Origin = "not_found" // no file yet; it is created at the default path
+)
+
+// xdgFileNames are the config file names looked for in the XDG config directories
+var xdgFileNames = []string{"config.yaml", "config.yml", "config.toml"}
+
+// Candidate is a path a config file is looked for at
+type Candidate struct {
+	Path   string
+	Origin Origin
+}
+
+// Resolution is the config file in use and how it was found
+type Resolution struct {
+	Path   string
+	Origin Origin
+	Exists bool
+	// Searched lists the paths looked at, in order; it is empty when the file was named by --config or WAMON_CONFIG
+	Searched []Candidate
+}
+
+// Resolve finds the config file. The first of these is used:
+//  1. the --config flag (flagPath)
+//  2. the WAMON_CONFIG environment variable
+//  3. $XDG_CONFIG_HOME/wamon/config.{yaml,yml,toml} (~/.config/wamon by default)
+//  4. the legacy files ~/.wamon/.wamon.{yaml,yml,toml} and ~/.wamon.{yaml,yml,toml}
+//  5. $XDG_CONFIG_DIRS/wamon/config.{yaml,yml,toml} (/etc/xdg/wamon by default)
+//
+// When there is no file, the resolution points to ~/.wamon/.wamon.yaml, where it is created.
+func Resolve(flagPath string) Resolution {
+	if flagPath != "" {
+		return named(flagPath, OriginFlag)
+	}
+	if path := os.Getenv(EnvConfig); path != "" {
+		return named(path, OriginEnv)
+	}
+
+	searched := SearchPaths()
+	for _, candidate := range searched {
+		if isFile(candidate.Path) {
+			return Resolution{Path: candidate.Path, Origin: candidate.Origin, Exists: true, Searched: searched}
+		}
+	}
+	return Resolution{Path: DefaultPath(), Origin: OriginNotFound, Searched: searched}
+}
+
+// named resolves a config file given by the user, which may not exist yet
+func named(path string, origin Origin) Resolution {
+	path = expandHome(path)
+	return Resolution{Path: path, Origin: origin, Exists: isFile(path)}
+}
+
+// SearchPaths returns the paths a config file is looked for at, in order
+func SearchPaths() []Candidate {
+	home := homeDir()
+	var candidates []Candidate
+	add := func(dir string, names []string, origin Origin) {
+		for _, name := range names {
+			candidates = append(candidates, Candidate{Path: filepath.Join(dir, name), Origin: origin})
+		}
+	}
+
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		configHome = filepath.Join(home, ".config")
+	}
+	add(filepath.Join(configHome, "wamon"), xdgFileNames, OriginXDGHome)
+
+	add(filepath.Join(home, ".wamon"), fileNames, OriginLegacy)
+	add(home, fileNames, OriginLegacy)
+
+	configDirs := os.Getenv("XDG_CONFIG_DIRS")
+	if configDirs == "" {
+		configDirs = "/etc/xdg"
+	}
+	for _, dir := range filepath.SplitList(configDirs) {
+		if dir != "" {
+			add(filepath.Join(dir, "wamon"), xdgFileNames, OriginXDGDirs)
+		}
+	}
+	return candidates
+}
+
+// Load makes viper read the config file of a resolution.
+// A file that does not exist yet is not an error.
+func Load(resolution Resolution) error {
+	slog.Debug("config file", "path", resolution.Path, "origin", resolution.Origin, "exists", resolution.Exists, "searched", len(resolution.Searched))
+	viper.SetConfigFile(resolution.Path)
+	viper.SetConfigType(Format(resolution.Path))
+	if !resolution.Exists {
+		return nil
+	}
+	return viper.ReadInConfig()
+}
+
+// Flag is a value of a key given on the command line
+type Flag struct {
+	Name  string
+	Value string
+}
+
+// Setting is the value of a key and where it came from
+type Setting struct {
+	Field Field
+	Value interface{}
+	// Source is a flag such as "--db", an environment variable, SourceFile or SourceDefault;
+	// it is empty when the key has no value
+	Source string
+}
+
+// Sources of a setting other than flags and environment variables
+const (
+	SourceFile    = "file"
+	SourceDefault = "default"
+)
+
+// Explain resolves every key of the schema the way wamon reads it: flags given
+// on the command line first, then the environment variables that override the
+// file, the config file, the environment variables used as a fallback and the default.
+func Explain(file *File, flags map[string]Flag) []Setting {
+	settings := make([]Setting, len(fields))
+	for i, field := range fields {
+		settings[i] = explainField(field, file, flags)
+	}
+	return settings
+}
+
+// explainField resolves one key
+func explainField(field Field, file *File, flags map[string]Flag) Setting {
+	if flag, ok := flags[field.Key]; ok {
+		return Setting{Field: field, Value: flag.Value, Source: flag.Name}
+	}
+	if name, value, ok := lookupEnv(field.Env); ok {
+		return Setting{Field: field, Value: value, Source: name}
+	}
+	if value, ok := file.Get(field.Key); ok {
+		return Setting{Field: field, Value: value, Source: SourceFile}
+	}
+	for _, name := range field.FallbackEnv {
+		if value := os.Getenv(name); value != "" && field.Validate(value) == nil {
+			return Setting{Field: field, Value: value, Source: name}
+		}
+	}
+	if value := field.DefaultValue(); value != nil {
+		return Setting{Field: field, Value: value, Source: SourceDefault}
+	}
+	return Setting{Field: field}
+}
+
+// lookupEnv returns the first of the environment variables that is set
+func lookupEnv(names []string) (string, string, bool) {
+	for _, name := range names {
+		if value := os.Getenv(name); value != "" {
+			return name, value, true
+		}
+	}
+	return "", "", false
+}
+
+// stringValue returns a string key from its environment variables or viper
+func stringValue(key string) string {
+	if field, ok := Lookup(key); ok {
+		if _, value, ok := lookupEnv(field.Env); ok {
+			return value
+		}
+	}
+	return cast.ToString(viper.Get(key))
+}
+
+// DefaultDBPath returns the database used when no path is configured
+func DefaultDBPath() string {
+	return filepath.Join(homeDir(), ".wamon", "wamon.db")
+}
+
+// homeDir returns the home directory, or "" when it is unknown
+func homeDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return home
+}
+
+// expandHome replaces a leading "~" with the home directory
+func expandHome(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		return filepath.Join(homeDir(), path[1:])
+	}
+	return path
+}
+
+// isFile reports whether path is a regular file
+func isFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
@@ -0,0 +1,152 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupResolveEnv points the home and XDG directories to temporary directories
+func setupResolveEnv(t *testing.T) (home, configHome, configDir string) {
+	home, configHome, configDir = t.TempDir(), t.TempDir(), t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	t.Setenv("XDG_CONFIG_DIRS", configDir)
+	t.Setenv(EnvConfig, "")
+	return home, configHome, configDir
+}
+
+// writeConfig creates a config file and its directory
+func writeConfig(t *testing.T, path, content string) {
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestResolve(t *testing.T) {
+	home, configHome, configDir := setupResolveEnv(t)
+
+	// Without a file, it is created at the default path
+	resolution := Resolve("")
+	assert.Equal(t, filepath.Join(home, ".wamon", ".wamon.yaml"), resolution.Path)
+	assert.Equal(t, OriginNotFound, resolution.Origin)
+	assert.False(t, resolution.Exists)
+	assert.NotEmpty(t, resolution.Searched)
+
+	system := filepath.Join(configDir, "wamon", "config.toml")
+	writeConfig(t, system, "")
+	resolution = Resolve("")
+	assert.Equal(t, system, resolution.Path)
+	assert.Equal(t, OriginXDGDirs, resolution.Origin)
+
+	// The files in the home directory come before the system ones
+	legacyHome := filepath.Join(home, ".wamon.yaml")
+	writeConfig(t, legacyHome, "")
+	assert.Equal(t, legacyHome, Resolve("").Path)
+
+	legacy := filepath.Join(home, ".wamon", ".wamon.yaml")
+	writeConfig(t, legacy, "")
+	resolution = Resolve("")
+	assert.Equal(t, legacy, resolution.Path)
+	assert.Equal(t, OriginLegacy, resolution.Origin)
+
+	xdg := filepath.Join(configHome, "wamon", "config.yaml")
+	writeConfig(t, xdg, "")
+	resolution = Resolve("")
+	assert.Equal(t, xdg, resolution.Path)
+	assert.Equal(t, OriginXDGHome, resolution.Origin)
+	assert.True(t, resolution.Exists)
+
+	// WAMON_CONFIG and --config name the file, even one that doesn't exist yet
+	t.Setenv(EnvConfig, "~/wamon.toml")
+	resolution = Resolve("")
+	assert.Equal(t, filepath.Join(home, "wamon.toml"), resolution.Path)
+	assert.Equal(t, OriginEnv, resolution.Origin)
+	assert.False(t, resolution.Exists)
+	assert.Empty(t, resolution.Searched)
+
+	resolution = Resolve(legacy)
+	assert.Equal(t, legacy, resolution.Path)
+	assert.Equal(t, OriginFlag, resolution.Origin)
+	assert.True(t, resolution.Exists)
+}
+
+func TestExplain(t *testing.T) {
+	home, _, _ := setupResolveEnv(t)
+	t.Setenv("WAMON_DB_PATH", "")
+	t.Setenv("WAMON_SLACK_TOKEN", "")
+	t.Setenv("WAMON_SLACK_CHANNEL", "dev")
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "C.UTF-8")
+
+	file := &File{values: map[string]interface{}{
+		"slack": map[string]interface{}{"channel": "random", "token": "xoxb-123"},
+		"art":   map[string]interface{}{"plain": true},
+	}}
+	flags := map[string]Flag{"lang": {Name: "--lang", Value: "en"}}
+
+	settings := make(map[string]Setting)
+	for _, setting := range Explain(file, flags) {
+		settings[setting.Field.Key] = setting
+	}
+	assert.Len(t, settings, len(Fields()))
+
+	assert.Equal(t, "--lang", settings["lang"].Source)
+	assert.Equal(t, "WAMON_SLACK_CHANNEL", settings["slack.channel"].Source)
+	assert.Equal(t, "dev", settings["slack.channel"].Value)
+	assert.Equal(t, SourceFile, settings["slack.token"].Source)
+	assert.Equal(t, true, settings["art.plain"].Value)
+	assert.Equal(t, SourceDefault, settings["art.pack"].Source)
+	assert.Equal(t, filepath.Join(home, ".wamon", "wamon.db"), settings["database.path"].Value)
+	assert.Equal(t, "", settings["hooks"].Source)
+	assert.Nil(t, settings["hooks"].Value)
+
+	// A locale that is not a supported language falls back to the default
+	settings["lang"] = explainField(settings["lang"].Field, file, nil)
+	assert.Equal(t, SourceDefault, settings["lang"].Source)
+
+	t.Setenv("LANG", "en_US.UTF-8")
+	settings["lang"] = explainField(settings["lang"].Field, file, nil)
+	assert.Equal(t, "LANG", settings["lang"].Source)
+}
+
+func TestExplainMatchesLoadConfig(t *testing.T) {
+	home, _, _ := setupResolveEnv(t)
+	t.Setenv("WAMON_SLACK_CHANNEL", "")
+	viper.Reset()
+	defer viper.Reset()
+
+	path := filepath.Join(home, ".wamon", ".wamon.yaml")
+	writeConfig(t, path, "report:\n  week_start: sun\nart:\n  plain: true\n")
+	file, err := ReadFile(path)
+	assert.NoError(t, err)
+
+	SetDefaults()
+	assert.NoError(t, Load(Resolution{Path: path, Exists: true}))
+	loaded, err := LoadConfig()
+	assert.NoError(t, err)
+
+	settings := make(map[string]Setting)
+	for _, setting := range Explain(file, nil) {
+		settings[setting.Field.Key] = setting
+	}
+
+	// config list shows the values the commands read, set or not
+	for key, value := range map[string]interface{}{
+		"slack.channel":     loaded.Slack.Channel,
+		"streak.holidays":   loaded.Streak.Holidays,
+		"report.week_start": loaded.Report.WeekStart,
+		"art.pack":          loaded.Art.Pack,
+		"art.plain":         loaded.Art.Plain,
+		"art.animate":       loaded.Art.Animate,
+	} {
+		shown := settings[key].Value
+		if shown == nil {
+			shown = ""
+		}
+		assert.Equal(t, shown, value, key)
+	}
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookup(t *testing.T) {
+	field, ok := Lookup("Slack.Channel")
+	assert.True(t, ok)
+	assert.Equal(t, "slack.channel", field.Key)
+	assert.Nil(t, field.Default)
+	assert.Equal(t, "general", field.Example)
+
+	_, ok = Lookup("slack.webhook")
+	assert.False(t, ok)
+
+	// Every key has a description in the catalogs
+	for _, field := range Fields() {
+		assert.NotEqual(t, "config.field."+field.Key, field.Description(), field.Key)
+	}
+}
+
+func TestFieldParse(t *testing.T) {
+	holidays := filepath.Join(t.TempDir(), "holidays.txt")
+	assert.NoError(t, os.WriteFile(holidays, []byte("2025-01-01\n"), 0644))
+
+	testCases := []struct {
+		name    string
+		key     string
+		raw     string
+		want    interface{}
+		wantErr bool
+	}{
+		{name: "String", key: "slack.channel", raw: " random ", want: "random"},
+		{name: "Bool", key: "slack.enabled", raw: "true", want: true},
+		{name: "Invalid bool", key: "art.plain", raw: "maybe", wantErr: true},
+		{name: "List", key: "streak.non_working_days", raw: "sat, sun,", want: []string{"sat", "sun"}},
+		{name: "Invalid weekday", key: "streak.non_working_days", raw: "sat,someday", wantErr: true},
+		{name: "Bot token", key: "slack.token", raw: "xoxb-123", want: "xoxb-123"},
+		{name: "Bad token prefix", key: "slack.token", raw: "xoxa-123", wantErr: true},
+		{name: "Channel with spaces", key: "slack.channel", raw: "my channel", wantErr: true},
+		{name: "Language", key: "lang", raw: "ja", want: "ja"},
+		{name: "Unknown language", key: "lang", raw: "xx", wantErr: true},
+		{name: "Holidays file", key: "streak.holidays_file", raw: holidays, want: holidays},
+		{name: "Missing holidays file", key: "streak.holidays_file", raw: holidays + ".missing", wantErr: true},
+		{name: "Database path is a directory", key: "database.path", raw: t.TempDir(), wantErr: true},
+		{name: "Unknown art pack", key: "art.pack", raw: "no-such-pack", wantErr: true},
+		{name: "Section", key: "hooks", raw: "x", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			field, ok := Lookup(tc.key)
+			assert.True(t, ok)
+
+			value, err := field.Parse(tc.raw)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, value)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	errs := Validate(map[string]interface{}{
+		"lang": "en",
+		"slack": map[string]interface{}{
+			"token":   "xoxb-123",
+			"enabled": true,
+		},
+		"hooks": map[string]interface{}{
+			"pre-save": []interface{}{"/bin/true"},
+		},
+	})
+	assert.Empty(t, errs)
+
+	errs = Validate(map[string]interface{}{
+		"colour": "blue",
+		"slack": map[string]interface{}{
+			"token":   "secret",
+			"webhook": "https://example.com",
+		},
+		"hooks": map[string]interface{}{
+			"on-save": []interface{}{"/bin/true"},
+		},
+	})
+	if assert.Len(t, errs, 4) {
+		// Problems are reported in the order of the keys
+		assert.Equal(t, &UnknownKeyError{Key: "colour"}, errs[0])
+		assert.Contains(t, errs[1].Error(), "hooks")
+		assert.Contains(t, errs[2].Error(), "slack.token")
+		assert.Equal(t, &UnknownKeyError{Key: "slack.webhook"}, errs[3])
+
+		// Each problem tells the key it is about
+		assert.Equal(t, "colour", ErrorKey(errs[0]))
+		assert.Equal(t, "slack.token", ErrorKey(errs[2]))
+	}
+	assert.Empty(t, ErrorKey(errors.New("yaml: line 1")))
+}
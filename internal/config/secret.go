@@ -0,0 +1,155 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/econron/wamon/internal/i18n"
+	"github.com/econron/wamon/internal/logging"
+)
+
+// secretPerm is the permission of files wamon writes secrets into
+const secretPerm = 0600
+
+// tokenCommandTimeout bounds how long slack.token_command may run
+const tokenCommandTimeout = 10 * time.Second
+
+// SlackSecretConfig holds where the Slack token is read from when it is not in the config file
+type SlackSecretConfig struct {
+	TokenFile    string // file holding only the token
+	TokenCommand string // shell command printing the token, such as "pass show slack/wamon"
+}
+
+// ResolveSlackToken reads the Slack token from WAMON_SLACK_TOKEN, slack.token_command,
+// slack.token_file or slack.token, in this order, and stores it in c.Slack.Token.
+// It returns the source of the token; the command and the file are only read here,
+// so commands that don't send to Slack never run them.
+func (c *AppConfig) ResolveSlackToken() (string, error) {
+	field, _ := Lookup("slack.token")
+	if name, token, ok := lookupEnv(field.Env); ok {
+		slog.Debug("slack token", "source", name, "token", token)
+		c.Slack.Token = token
+		return name, nil
+	}
+
+	var token, source string
+	var err error
+	switch {
+	case c.SlackSecret.TokenCommand != "":
+		source = "slack.token_command"
+		token, err = runTokenCommand(c.SlackSecret.TokenCommand)
+	case c.SlackSecret.TokenFile != "":
+		source = "slack.token_file"
+		token, err = ReadSecretFile(c.SlackSecret.TokenFile)
+	default:
+		if c.Slack.Token != "" {
+			slog.Debug("slack token", "source", "slack.token", "token", c.Slack.Token)
+		}
+		return "slack.token", nil
+	}
+	if err != nil {
+		return source, err
+	}
+	if token == "" {
+		return source, errors.New(i18n.T("config.token_empty", source))
+	}
+	slog.Debug("slack token", "source", source, "token", token)
+	c.Slack.Token = token
+	return source, nil
+}
+
+// runTokenCommand runs a command with the shell and returns what it prints.
+// Its stderr goes to the terminal, so password managers can ask for a passphrase.
+func runTokenCommand(command string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), tokenCommandTimeout)
+	defer cancel()
+
+	var stdout bytes.Buffer
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", errors.New(i18n.T("config.token_command_failed", err))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// ReadSecretFile reads a file holding a secret. Files other users can read are refused.
+func ReadSecretFile(path string) (string, error) {
+	path = expandHome(path)
+	if err := checkSecretPermissions(path); err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// checkSecretPermissions requires a secret file to be readable by its owner only
+func checkSecretPermissions(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return errors.New(i18n.T("config.path_not_found", path))
+	}
+	if info.IsDir() {
+		return errors.New(i18n.T("config.path_is_directory", path))
+	}
+	if info.Mode().Perm()&0077 != 0 {
+		return errors.New(i18n.T("config.secret_permissions", path, info.Mode().Perm(), path))
+	}
+	return nil
+}
+
+// SecretPath returns where wamon keeps the Slack token it is given
+func SecretPath() string {
+	return filepath.Join(homeDir(), ".wamon", "slack-token")
+}
+
+// WriteSecretFile saves a secret readable by its owner only, tightening the
+// permissions of a file that already exists
+func WriteSecretFile(path, secret string) error {
+	return writeFile(path, []byte(secret+"\n"), secretPerm)
+}
+
+// Redact hides a secret in output, keeping its kind and last characters so it can be recognized
+func Redact(secret string) string {
+	return logging.Redact(secret)
+}
+
+// MigrateSecrets moves a token written in the config file to the secret file
+// and points slack.token_file to it. It returns false when there was nothing to move.
+func MigrateSecrets(file *File, secretPath string) (bool, error) {
+	value, ok := file.Get("slack.token")
+	token := strings.TrimSpace(asString(value))
+	if !ok || token == "" {
+		return false, nil
+	}
+
+	// Don't overwrite another token kept in the secret file
+	if existing, err := os.ReadFile(secretPath); err == nil && strings.TrimSpace(string(existing)) != token {
+		return false, errors.New(i18n.T("config.secret_file_exists", secretPath))
+	}
+	if err := WriteSecretFile(secretPath, token); err != nil {
+		return false, err
+	}
+
+	file.Unset("slack.token")
+	file.Set("slack.token_file", secretPath)
+	return true, file.Write()
+}
+
+// asString returns a value as a string, or "" when it is not one
+func asString(value interface{}) string {
+	s, _ := value.(string)
+	return s
+}
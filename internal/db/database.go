@@ -5,8 +5,11 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"log/slog"
+	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -19,19 +22,31 @@ import (
 // DB is the interface for database operations
 type DB interface {
 	SaveEntry(entry *models.Entry) error
+	SaveEntries(entries []*models.Entry) error
 	UpdateEntry(entry *models.Entry) error
+	UpdateEntries(entries []*models.Entry) error
+	DeleteEntry(id string) error
 	GetAllEntries() ([]*models.Entry, error)
 	GetEntriesByCategory(category models.Category) ([]*models.Entry, error)
 	GetEntryByID(id string) (*models.Entry, error)
 	GetEntryCount() (int, error)
 	GetEntriesFromLastWeek() ([]*models.Entry, error)
 	GetEntriesSince(since time.Time) ([]*models.Entry, error)
+	GetEntriesBetween(start, end time.Time) ([]*models.Entry, error)
+	GetEntryRevisions(id string) ([]*models.Revision, error)
+	FindSimilarEntries(entry *models.Entry, limit int) ([]*models.Entry, error)
 	ExportEntries(filePath string) error
 	ExportEntriesSince(filePath string, since time.Time) error
 	ImportEntries(filePath string) (int, error)
+	GetAllTags() ([]string, error)
+	GetOperations(limit int) ([]*models.Operation, error)
+	UndoOperation(id int64) (*models.Operation, error)
 	Close() error
 }
 
+// entryColumns is the column list shared by every query that returns entries
+const entryColumns = "id, category, research_topic, program_title, satisfaction, created_at, updated_at, tags"
+
 // SQLiteDB implements the DB interface with SQLite
 type SQLiteDB struct {
 	db *sql.DB
@@ -52,6 +67,25 @@ func NewDB(dbPath string) (DB, error) {
 	return &SQLiteDB{db: db}, nil
 }
 
+// OpenReadOnly opens an existing database without creating, migrating or writing
+// anything, for callers such as shell completion that only read. It returns an
+// error wrapping os.ErrNotExist when there is no database at dbPath.
+func OpenReadOnly(dbPath string) (DB, error) {
+	if _, err := os.Stat(dbPath); err != nil {
+		return nil, err
+	}
+	uri := &url.URL{Scheme: "file", Path: dbPath, RawQuery: "mode=ro"}
+	db, err := sql.Open(driverName, uri.String())
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &SQLiteDB{db: db}, nil
+}
+
 // GetDB returns a global singleton instance of the database
 // This is maintained for backward compatibility but should be avoided
 // in favor of dependency injection with NewDB
@@ -86,11 +120,17 @@ func initDB(dbPath string) (*sql.DB, error) {
 	}
 
 	// Open the database
-	db, err := sql.Open("sqlite3", dbPath)
+	start := time.Now()
+	db, err := sql.Open(driverName, dbPath)
 	if err != nil {
 		return nil, err
 	}
 
+	// Every connection to ":memory:" is a separate database, so keep a single one
+	if dbPath == ":memory:" {
+		db.SetMaxOpenConns(1)
+	}
+
 	// Create tables if they don't exist
 	err = createTables(db)
 	if err != nil {
@@ -98,6 +138,7 @@ func initDB(dbPath string) (*sql.DB, error) {
 		return nil, err
 	}
 
+	slog.Debug("database opened", "path", dbPath, "duration", time.Since(start))
 	return db, nil
 }
 
@@ -119,12 +160,175 @@ func createTables(db *sql.DB) error {
 			research_topic TEXT,
 			program_title TEXT,
 			satisfaction INTEGER NOT NULL,
-			created_at TIMESTAMP NOT NULL
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP,
+			tags TEXT
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	// Databases created before updated_at existed need the column added
+	if err := addColumnIfNotExists(db, "entries", "updated_at", "TIMESTAMP"); err != nil {
+		return err
+	}
+	if err := addColumnIfNotExists(db, "entries", "tags", "TEXT"); err != nil {
+		return err
+	}
+
+	// Create revisions table holding the previous state of edited entries
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS entry_revisions (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			entry_id TEXT NOT NULL,
+			category TEXT NOT NULL,
+			research_topic TEXT,
+			program_title TEXT,
+			satisfaction INTEGER NOT NULL,
+			created_at TIMESTAMP NOT NULL,
+			revised_at TIMESTAMP NOT NULL,
+			tags TEXT
 		)
 	`)
+	if err != nil {
+		return err
+	}
+	if err := addColumnIfNotExists(db, "entry_revisions", "tags", "TEXT"); err != nil {
+		return err
+	}
+	_, err = db.Exec(`CREATE INDEX IF NOT EXISTS idx_entry_revisions_entry_id ON entry_revisions(entry_id)`)
+	if err != nil {
+		return err
+	}
+
+	if err := createOperationTables(db); err != nil {
+		return err
+	}
+
+	// Earlier versions stored the Japanese display names instead of category codes
+	return migrateCategoryCodes(db)
+}
+
+// categoryCodesVersion is the user_version recorded once the category codes are migrated
+const categoryCodesVersion = 1
+
+// migrateCategoryCodes rewrites legacy category display names to category codes.
+// It records categoryCodesVersion in PRAGMA user_version, so it only runs once per database.
+func migrateCategoryCodes(db *sql.DB) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var version int
+	if err := tx.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return err
+	}
+	if version >= categoryCodesVersion {
+		return nil
+	}
+	slog.Debug("migrating category codes", "version", version)
+
+	for _, table := range []string{"entries", "entry_revisions"} {
+		for legacy, code := range models.LegacyCategories {
+			_, err := tx.Exec(fmt.Sprintf("UPDATE %s SET category = ? WHERE category = ?", table), code, legacy)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if _, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", categoryCodesVersion)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// addColumnIfNotExists adds a column to a table unless it is already present
+func addColumnIfNotExists(db *sql.DB, table, column, definition string) error {
+	var count int
+	err := db.QueryRow(
+		`SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?`, table, column,
+	).Scan(&count)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+	_, err = db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
 	return err
 }
 
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanEntry reads a single entry selected with entryColumns
+func scanEntry(row rowScanner) (*models.Entry, error) {
+	entry := &models.Entry{}
+	var category string
+	var researchTopic, programTitle, tags sql.NullString
+	var updatedAt sql.NullTime
+	err := row.Scan(
+		&entry.ID,
+		&category,
+		&researchTopic,
+		&programTitle,
+		&entry.Satisfaction,
+		&entry.CreatedAt,
+		&updatedAt,
+		&tags,
+	)
+	if err != nil {
+		return nil, err
+	}
+	entry.Category = models.Category(category)
+	entry.ResearchTopic = researchTopic.String
+	entry.ProgramTitle = programTitle.String
+	if updatedAt.Valid {
+		t := updatedAt.Time
+		entry.UpdatedAt = &t
+	}
+	entry.Tags = splitTags(tags.String)
+	return entry, nil
+}
+
+// joinTags converts tags to the comma-separated form stored in the tags column
+func joinTags(tags []string) string {
+	return strings.Join(models.NormalizeTags(tags), ",")
+}
+
+// splitTags converts the tags column back to a slice
+func splitTags(column string) []string {
+	if column == "" {
+		return nil
+	}
+	return strings.Split(column, ",")
+}
+
+// queryEntries runs a query selecting entryColumns and collects the results
+func (s *SQLiteDB) queryEntries(query string, args ...interface{}) ([]*models.Entry, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*models.Entry
+	for rows.Next() {
+		entry, err := scanEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
 // Close closes the database connection
 func (s *SQLiteDB) Close() error {
 	if s.db != nil {
@@ -135,137 +339,180 @@ func (s *SQLiteDB) Close() error {
 
 // SaveEntry saves an entry to the database
 func (s *SQLiteDB) SaveEntry(entry *models.Entry) error {
-	_, err := s.db.Exec(
-		`INSERT INTO entries (id, category, research_topic, program_title, satisfaction, created_at)
-		 VALUES (?, ?, ?, ?, ?, ?)`,
-		entry.ID,
-		entry.Category,
-		entry.ResearchTopic,
-		entry.ProgramTitle,
-		entry.Satisfaction,
-		entry.CreatedAt,
-	)
-	return err
+	return s.SaveEntries([]*models.Entry{entry})
+}
+
+// SaveEntries saves several new entries in a single transaction, recorded as one operation.
+// Nothing is saved when one of the entries cannot be.
+func (s *SQLiteDB) SaveEntries(entries []*models.Entry) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var changes []operationChange
+	for _, entry := range entries {
+		_, err = tx.Exec(
+			`INSERT INTO entries (id, category, research_topic, program_title, satisfaction, created_at, tags)
+			 VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			entry.ID,
+			entry.Category,
+			entry.ResearchTopic,
+			entry.ProgramTitle,
+			entry.Satisfaction,
+			entry.CreatedAt,
+			joinTags(entry.Tags),
+		)
+		if err != nil {
+			return err
+		}
+		changes = append(changes, operationChange{entryID: entry.ID})
+	}
+
+	if err := recordOperation(tx, models.OperationSave, time.Now(), changes); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 // UpdateEntry updates an existing entry in the database
+// The previous state of the entry is kept as a revision
 func (s *SQLiteDB) UpdateEntry(entry *models.Entry) error {
-	result, err := s.db.Exec(
+	return s.UpdateEntries([]*models.Entry{entry})
+}
+
+// UpdateEntries updates several entries in a single transaction, saving a revision of each.
+// Nothing is changed when one of the entries does not exist.
+func (s *SQLiteDB) UpdateEntries(entries []*models.Entry) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	var changes []operationChange
+	for _, entry := range entries {
+		change, err := updateEntry(tx, entry, now)
+		if err != nil {
+			return err
+		}
+		changes = append(changes, change)
+	}
+	if err := recordOperation(tx, models.OperationUpdate, now, changes); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		entry.UpdatedAt = &now
+	}
+	return nil
+}
+
+// updateEntry snapshots the stored entry as a revision and overwrites it within tx.
+// It returns the journal record needed to undo the update.
+func updateEntry(tx *sql.Tx, entry *models.Entry, now time.Time) (operationChange, error) {
+	change := operationChange{entryID: entry.ID}
+	previous, err := scanEntry(tx.QueryRow(`SELECT `+entryColumns+` FROM entries WHERE id = ?`, entry.ID))
+	if err != nil {
+		return change, err
+	}
+	change.previous = previous
+
+	// Snapshot the current state before overwriting it
+	result, err := tx.Exec(
+		`INSERT INTO entry_revisions (entry_id, category, research_topic, program_title, satisfaction, created_at, revised_at, tags)
+		 SELECT id, category, research_topic, program_title, satisfaction, created_at, ?, tags
+		 FROM entries WHERE id = ?`,
+		now,
+		entry.ID,
+	)
+	if err != nil {
+		return change, err
+	}
+	change.revisionID, err = result.LastInsertId()
+	if err != nil {
+		return change, err
+	}
+
+	_, err = tx.Exec(
 		`UPDATE entries 
-		 SET category = ?, research_topic = ?, program_title = ?, satisfaction = ?
+		 SET category = ?, research_topic = ?, program_title = ?, satisfaction = ?, created_at = ?, updated_at = ?, tags = ?
 		 WHERE id = ?`,
 		entry.Category,
 		entry.ResearchTopic,
 		entry.ProgramTitle,
 		entry.Satisfaction,
+		entry.CreatedAt,
+		now,
+		joinTags(entry.Tags),
 		entry.ID,
 	)
+	return change, err
+}
+
+// DeleteEntry removes an entry and its revisions from the database
+func (s *SQLiteDB) DeleteEntry(id string) error {
+	tx, err := s.db.Begin()
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
 
-	// Check if the entry was actually updated
-	rowsAffected, err := result.RowsAffected()
+	// Keep the entry and its revisions in the journal so the deletion can be undone
+	previous, err := scanEntry(tx.QueryRow(`SELECT `+entryColumns+` FROM entries WHERE id = ?`, id))
 	if err != nil {
 		return err
 	}
-	if rowsAffected == 0 {
-		return sql.ErrNoRows
+	revisions, err := queryRevisions(tx, id)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	if _, err := tx.Exec(`DELETE FROM entries WHERE id = ?`, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM entry_revisions WHERE entry_id = ?`, id); err != nil {
+		return err
+	}
+
+	change := operationChange{entryID: id, previous: previous, revisions: revisions}
+	if err := recordOperation(tx, models.OperationDelete, time.Now(), []operationChange{change}); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 // GetAllEntries retrieves all entries from the database
 func (s *SQLiteDB) GetAllEntries() ([]*models.Entry, error) {
-	rows, err := s.db.Query(`
-		SELECT id, category, research_topic, program_title, satisfaction, created_at
+	return s.queryEntries(`
+		SELECT ` + entryColumns + `
 		FROM entries
 		ORDER BY created_at DESC
 	`)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var entries []*models.Entry
-	for rows.Next() {
-		entry := &models.Entry{}
-		var category string
-		err := rows.Scan(
-			&entry.ID,
-			&category,
-			&entry.ResearchTopic,
-			&entry.ProgramTitle,
-			&entry.Satisfaction,
-			&entry.CreatedAt,
-		)
-		if err != nil {
-			return nil, err
-		}
-		entry.Category = models.Category(category)
-		entries = append(entries, entry)
-	}
-
-	return entries, nil
 }
 
 // GetEntriesByCategory retrieves entries by category
 func (s *SQLiteDB) GetEntriesByCategory(category models.Category) ([]*models.Entry, error) {
-	rows, err := s.db.Query(`
-		SELECT id, category, research_topic, program_title, satisfaction, created_at
+	return s.queryEntries(`
+		SELECT `+entryColumns+`
 		FROM entries
 		WHERE category = ?
 		ORDER BY created_at DESC
 	`, category)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var entries []*models.Entry
-	for rows.Next() {
-		entry := &models.Entry{}
-		var category string
-		err := rows.Scan(
-			&entry.ID,
-			&category,
-			&entry.ResearchTopic,
-			&entry.ProgramTitle,
-			&entry.Satisfaction,
-			&entry.CreatedAt,
-		)
-		if err != nil {
-			return nil, err
-		}
-		entry.Category = models.Category(category)
-		entries = append(entries, entry)
-	}
-
-	return entries, nil
 }
 
 // GetEntryByID retrieves an entry by ID
 func (s *SQLiteDB) GetEntryByID(id string) (*models.Entry, error) {
-	entry := &models.Entry{}
-	var category string
-	err := s.db.QueryRow(`
-		SELECT id, category, research_topic, program_title, satisfaction, created_at
+	return scanEntry(s.db.QueryRow(`
+		SELECT `+entryColumns+`
 		FROM entries
 		WHERE id = ?
-	`, id).Scan(
-		&entry.ID,
-		&category,
-		&entry.ResearchTopic,
-		&entry.ProgramTitle,
-		&entry.Satisfaction,
-		&entry.CreatedAt,
-	)
-	if err != nil {
-		return nil, err
-	}
-	entry.Category = models.Category(category)
-	return entry, nil
+	`, id))
 }
 
 // GetEntryCount returns the total number of entries
@@ -279,73 +526,100 @@ func (s *SQLiteDB) GetEntryCount() (int, error) {
 func (s *SQLiteDB) GetEntriesFromLastWeek() ([]*models.Entry, error) {
 	// Calculate the timestamp for 7 days ago
 	oneWeekAgo := time.Now().AddDate(0, 0, -7)
+	return s.GetEntriesSince(oneWeekAgo)
+}
 
-	rows, err := s.db.Query(`
-		SELECT id, category, research_topic, program_title, satisfaction, created_at
+// GetEntriesSince retrieves entries created after the specified time
+func (s *SQLiteDB) GetEntriesSince(since time.Time) ([]*models.Entry, error) {
+	return s.queryEntries(`
+		SELECT `+entryColumns+`
 		FROM entries
 		WHERE created_at >= ?
 		ORDER BY created_at DESC
-	`, oneWeekAgo)
+	`, since)
+}
+
+// GetEntriesBetween retrieves entries created in the half-open range [start, end)
+func (s *SQLiteDB) GetEntriesBetween(start, end time.Time) ([]*models.Entry, error) {
+	return s.queryEntries(`
+		SELECT `+entryColumns+`
+		FROM entries
+		WHERE created_at >= ? AND created_at < ?
+		ORDER BY created_at ASC
+	`, start, end)
+}
+
+// GetEntryRevisions returns the saved revisions of an entry, oldest first
+func (s *SQLiteDB) GetEntryRevisions(id string) ([]*models.Revision, error) {
+	return queryRevisions(s.db, id)
+}
+
+// queryRevisions reads the revisions of an entry through q, oldest first
+func queryRevisions(q querier, id string) ([]*models.Revision, error) {
+	rows, err := q.Query(`
+		SELECT entry_id, category, research_topic, program_title, satisfaction, created_at, revised_at, tags
+		FROM entry_revisions
+		WHERE entry_id = ?
+		ORDER BY id ASC
+	`, id)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var entries []*models.Entry
+	var revisions []*models.Revision
 	for rows.Next() {
-		entry := &models.Entry{}
+		rev := &models.Revision{Number: len(revisions) + 1}
 		var category string
+		var researchTopic, programTitle, tags sql.NullString
 		err := rows.Scan(
-			&entry.ID,
+			&rev.EntryID,
 			&category,
-			&entry.ResearchTopic,
-			&entry.ProgramTitle,
-			&entry.Satisfaction,
-			&entry.CreatedAt,
+			&researchTopic,
+			&programTitle,
+			&rev.Entry.Satisfaction,
+			&rev.Entry.CreatedAt,
+			&rev.RevisedAt,
+			&tags,
 		)
 		if err != nil {
 			return nil, err
 		}
-		entry.Category = models.Category(category)
-		entries = append(entries, entry)
+		rev.Entry.ID = rev.EntryID
+		rev.Entry.Category = models.Category(category)
+		rev.Entry.ResearchTopic = researchTopic.String
+		rev.Entry.ProgramTitle = programTitle.String
+		rev.Entry.Tags = splitTags(tags.String)
+		revisions = append(revisions, rev)
 	}
 
-	return entries, nil
+	return revisions, rows.Err()
 }
 
-// GetEntriesSince retrieves entries created after the specified time
-func (s *SQLiteDB) GetEntriesSince(since time.Time) ([]*models.Entry, error) {
-	rows, err := s.db.Query(`
-		SELECT id, category, research_topic, program_title, satisfaction, created_at
-		FROM entries
-		WHERE created_at >= ?
-		ORDER BY created_at DESC
-	`, since)
+// GetAllTags returns every tag in use, sorted alphabetically
+func (s *SQLiteDB) GetAllTags() ([]string, error) {
+	rows, err := s.db.Query(`SELECT DISTINCT tags FROM entries WHERE tags IS NOT NULL AND tags != ''`)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var entries []*models.Entry
+	seen := make(map[string]bool)
+	var tags []string
 	for rows.Next() {
-		entry := &models.Entry{}
-		var category string
-		err := rows.Scan(
-			&entry.ID,
-			&category,
-			&entry.ResearchTopic,
-			&entry.ProgramTitle,
-			&entry.Satisfaction,
-			&entry.CreatedAt,
-		)
-		if err != nil {
+		var column string
+		if err := rows.Scan(&column); err != nil {
 			return nil, err
 		}
-		entry.Category = models.Category(category)
-		entries = append(entries, entry)
+		for _, tag := range splitTags(column) {
+			if !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
 	}
-
-	return entries, nil
+	sort.Strings(tags)
+	return tags, rows.Err()
 }
 
 // ExportEntries exports all entries from the database to a JSON file
@@ -384,7 +658,7 @@ func (s *SQLiteDB) ExportEntries(filePath string) error {
 
 	// Write each entry as a JSON object on its own line
 	for _, entry := range entries {
-		// Use the original category string directly
+		// Categories are stored as codes, so they are exported as-is
 		catStr := string(entry.Category)
 
 		// Create simplified export format object
@@ -403,6 +677,9 @@ func (s *SQLiteDB) ExportEntries(filePath string) error {
 		case models.ResearchAndProgram:
 			exportEntry["body"] = entry.ResearchTopic + " - " + entry.ProgramTitle
 		}
+		if len(entry.Tags) > 0 {
+			exportEntry["tags"] = entry.Tags
+		}
 
 		// Convert to JSON
 		jsonData, err := json.Marshal(exportEntry)
@@ -462,7 +739,7 @@ func (s *SQLiteDB) ExportEntriesSince(filePath string, since time.Time) error {
 
 	// Write each entry as a JSON object on its own line
 	for _, entry := range entries {
-		// Use the original category string directly
+		// Categories are stored as codes, so they are exported as-is
 		catStr := string(entry.Category)
 
 		// Create simplified export format object
@@ -481,6 +758,9 @@ func (s *SQLiteDB) ExportEntriesSince(filePath string, since time.Time) error {
 		case models.ResearchAndProgram:
 			exportEntry["body"] = entry.ResearchTopic + " - " + entry.ProgramTitle
 		}
+		if len(entry.Tags) > 0 {
+			exportEntry["tags"] = entry.Tags
+		}
 
 		// Convert to JSON
 		jsonData, err := json.Marshal(exportEntry)
@@ -519,16 +799,12 @@ func (s *SQLiteDB) ImportEntries(filePath string) (int, error) {
 		return 0, fmt.Errorf("トランザクション開始エラー: %v", err)
 	}
 
+	defer tx.Rollback()
+
 	// Read the file line by line
 	scanner := bufio.NewScanner(file)
 	importedCount := 0
-
-	// Prepare function for rollback in case of error
-	defer func() {
-		if err != nil {
-			tx.Rollback()
-		}
-	}()
+	var changes []operationChange
 
 	for scanner.Scan() {
 		line := scanner.Text()
@@ -576,16 +852,9 @@ func (s *SQLiteDB) ImportEntries(filePath string) (int, error) {
 			return importedCount, fmt.Errorf("不正なカテゴリ形式: %v", data["cat"])
 		}
 
-		// Map the export category names back to internal model categories
-		var category models.Category
-		switch catStr {
-		case "research", "調べ物":
-			category = models.Research
-		case "programming", "プログラマ":
-			category = models.Programming
-		case "research_and_programming", "調べてプログラマ":
-			category = models.ResearchAndProgram
-		default:
+		// Older exports contain the Japanese display names instead of codes
+		category, ok := models.ParseCategory(catStr)
+		if !ok {
 			return importedCount, fmt.Errorf("不明なカテゴリ: %v", catStr)
 		}
 
@@ -603,6 +872,15 @@ func (s *SQLiteDB) ImportEntries(filePath string) (int, error) {
 			Satisfaction: 3, // Default satisfaction if not specified
 		}
 
+		// Tags are optional
+		if rawTags, ok := data["tags"].([]interface{}); ok {
+			for _, rawTag := range rawTags {
+				if tag, ok := rawTag.(string); ok {
+					entry.Tags = append(entry.Tags, tag)
+				}
+			}
+		}
+
 		// Set content based on category
 		switch category {
 		case models.Research:
@@ -623,19 +901,21 @@ func (s *SQLiteDB) ImportEntries(filePath string) (int, error) {
 
 		// Save the entry within transaction
 		_, err = tx.Exec(
-			`INSERT INTO entries (id, category, research_topic, program_title, satisfaction, created_at)
-			VALUES (?, ?, ?, ?, ?, ?)`,
+			`INSERT INTO entries (id, category, research_topic, program_title, satisfaction, created_at, tags)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`,
 			entry.ID,
 			entry.Category,
 			entry.ResearchTopic,
 			entry.ProgramTitle,
 			entry.Satisfaction,
 			entry.CreatedAt,
+			joinTags(entry.Tags),
 		)
 		if err != nil {
 			return importedCount, fmt.Errorf("エントリの保存エラー: %v", err)
 		}
 
+		changes = append(changes, operationChange{entryID: entry.ID})
 		importedCount++
 	}
 
@@ -643,6 +923,13 @@ func (s *SQLiteDB) ImportEntries(filePath string) (int, error) {
 		return importedCount, fmt.Errorf("ファイル読み込みエラー: %v", err)
 	}
 
+	// The whole batch is a single operation so it can be undone at once
+	if len(changes) > 0 {
+		if err := recordOperation(tx, models.OperationImport, time.Now(), changes); err != nil {
+			return importedCount, err
+		}
+	}
+
 	// Commit transaction
 	if err := tx.Commit(); err != nil {
 		return importedCount, fmt.Errorf("トランザクションコミットエラー: %v", err)
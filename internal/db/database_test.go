@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"os"
 	"path/filepath"
+	"strconv"
 	"sync"
 	"testing"
 	"time"
@@ -208,6 +209,47 @@ func TestSaveEntryFailure(t *testing.T) {
 	assert.Error(t, err, "Primary key constraint violation should cause an error")
 }
 
+func TestSaveEntries(t *testing.T) {
+	db := setupTestDB(t)
+
+	first := createTestEntry()
+	second := createTestEntry()
+	second.ID = "20220101130000"
+	second.Tags = []string{"go"}
+	assert.NoError(t, db.SaveEntries([]*models.Entry{first, second}))
+
+	count, err := db.GetEntryCount()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	saved, err := db.GetEntryByID(second.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"go"}, saved.Tags)
+
+	// Both entries are recorded as one operation
+	ops, err := db.GetOperations(10)
+	assert.NoError(t, err)
+	if assert.Len(t, ops, 1) {
+		assert.Equal(t, []string{first.ID, second.ID}, ops[0].EntryIDs)
+	}
+}
+
+func TestSaveEntriesRollsBack(t *testing.T) {
+	db := setupTestDB(t)
+
+	first := createTestEntry()
+	first.ID = "20220101110000"
+	duplicate := createTestEntry()
+	assert.NoError(t, db.SaveEntry(duplicate))
+
+	// The second entry clashes with a stored one, so the first is not saved either
+	assert.Error(t, db.SaveEntries([]*models.Entry{first, createTestEntry()}))
+	_, err := db.GetEntryByID(first.ID)
+	assert.Equal(t, sql.ErrNoRows, err)
+	count, err := db.GetEntryCount()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
 func TestUpdateEntry(t *testing.T) {
 	db := setupTestDB(t)
 
@@ -229,6 +271,78 @@ func TestUpdateEntry(t *testing.T) {
 	assert.Equal(t, 4, updatedEntry.Satisfaction)
 }
 
+func TestUpdateEntryCategoryAndDate(t *testing.T) {
+	db := setupTestDB(t)
+
+	entry := createTestEntry()
+	assert.NoError(t, db.SaveEntry(entry))
+
+	createdAt := time.Date(2025, 3, 31, 22, 15, 0, 0, time.Local)
+	entry.Category = models.Programming
+	entry.ProgramTitle = "wamon"
+	entry.CreatedAt = createdAt
+	assert.NoError(t, db.UpdateEntry(entry))
+
+	updatedEntry, err := db.GetEntryByID(entry.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.Programming, updatedEntry.Category)
+	assert.True(t, createdAt.Equal(updatedEntry.CreatedAt))
+
+	// The date appears in date range queries at its new position
+	entries, err := db.GetEntriesBetween(createdAt.Add(-time.Minute), createdAt.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestUpdateEntries(t *testing.T) {
+	db := setupTestDB(t)
+
+	first := createTestEntry()
+	second := createTestEntry()
+	second.ID = "20220101130000"
+	assert.NoError(t, db.SaveEntry(first))
+	assert.NoError(t, db.SaveEntry(second))
+
+	first.Category = models.Programming
+	second.Tags = []string{"go"}
+	assert.NoError(t, db.UpdateEntries([]*models.Entry{first, second}))
+	assert.NotNil(t, first.UpdatedAt)
+
+	updated, err := db.GetEntryByID(first.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.Programming, updated.Category)
+	updated, err = db.GetEntryByID(second.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"go"}, updated.Tags)
+
+	for _, id := range []string{first.ID, second.ID} {
+		revisions, err := db.GetEntryRevisions(id)
+		assert.NoError(t, err)
+		assert.Len(t, revisions, 1)
+	}
+}
+
+func TestUpdateEntriesRollsBack(t *testing.T) {
+	db := setupTestDB(t)
+
+	entry := createTestEntry()
+	assert.NoError(t, db.SaveEntry(entry))
+
+	entry.ResearchTopic = "変更"
+	missing := createTestEntry()
+	missing.ID = "missing"
+	err := db.UpdateEntries([]*models.Entry{entry, missing})
+	assert.Equal(t, sql.ErrNoRows, err)
+
+	// The first entry is left untouched and has no revision
+	stored, err := db.GetEntryByID(entry.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "テストトピック", stored.ResearchTopic)
+	revisions, err := db.GetEntryRevisions(entry.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, revisions)
+}
+
 func TestUpdateEntryFailure(t *testing.T) {
 	db := setupTestDB(t)
 
@@ -522,3 +636,256 @@ func TestGetEntriesSince(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, 3, len(allEntries)) // 全てのエントリが取得されるはず
 }
+
+func TestUpdateEntryRecordsRevision(t *testing.T) {
+	db := setupTestDB(t)
+
+	entry := createTestEntry()
+	err := db.SaveEntry(entry)
+	assert.NoError(t, err)
+
+	// 未編集のエントリには更新日時も改訂もない
+	saved, err := db.GetEntryByID(entry.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, saved.UpdatedAt)
+	revisions, err := db.GetEntryRevisions(entry.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, revisions)
+
+	// 2回更新する
+	entry.ResearchTopic = "1回目の更新"
+	assert.NoError(t, db.UpdateEntry(entry))
+	entry.ResearchTopic = "2回目の更新"
+	assert.NoError(t, db.UpdateEntry(entry))
+
+	updated, err := db.GetEntryByID(entry.ID)
+	assert.NoError(t, err)
+	assert.NotNil(t, updated.UpdatedAt)
+
+	// 改訂には更新前の状態が古い順に保存される
+	revisions, err = db.GetEntryRevisions(entry.ID)
+	assert.NoError(t, err)
+	assert.Len(t, revisions, 2)
+	assert.Equal(t, 1, revisions[0].Number)
+	assert.Equal(t, "テストトピック", revisions[0].Entry.ResearchTopic)
+	assert.Equal(t, "1回目の更新", revisions[1].Entry.ResearchTopic)
+}
+
+func TestAddUpdatedAtColumnToLegacyTable(t *testing.T) {
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "legacy.db")
+
+	// updated_at列のない古いスキーマを作成
+	legacy, err := sql.Open("sqlite3", path)
+	assert.NoError(t, err)
+	_, err = legacy.Exec(`
+		CREATE TABLE entries (
+			id TEXT PRIMARY KEY,
+			category TEXT NOT NULL,
+			research_topic TEXT,
+			program_title TEXT,
+			satisfaction INTEGER NOT NULL,
+			created_at TIMESTAMP NOT NULL
+		)
+	`)
+	assert.NoError(t, err)
+	_, err = legacy.Exec(`INSERT INTO entries VALUES ('legacy', '調べ物', 'old', '', 3, ?)`, time.Now())
+	assert.NoError(t, err)
+	legacy.Close()
+
+	// NewDBで開くと列が追加され、既存データも読める
+	database, err := NewDB(path)
+	assert.NoError(t, err)
+	defer database.Close()
+
+	entry, err := database.GetEntryByID("legacy")
+	assert.NoError(t, err)
+	assert.Equal(t, "old", entry.ResearchTopic)
+	assert.Nil(t, entry.UpdatedAt)
+	assert.Equal(t, models.Research, entry.Category)
+}
+
+func TestMigrateLegacyCategories(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "legacy.db")
+
+	database, err := NewDB(path)
+	assert.NoError(t, err)
+	raw := database.(*SQLiteDB).db
+	// 以前のバージョンは表示名をそのまま保存していた
+	for id, category := range map[string]string{"1": "調べ物", "2": "プログラマ", "3": "調べてプログラマ"} {
+		_, err = raw.Exec(`INSERT INTO entries (id, category, research_topic, program_title, satisfaction, created_at) VALUES (?, ?, 'topic', '', 3, ?)`, id, category, time.Now())
+		assert.NoError(t, err)
+	}
+	_, err = raw.Exec(`INSERT INTO entry_revisions (entry_id, category, research_topic, program_title, satisfaction, created_at, revised_at) VALUES ('1', 'プログラマ', 'old', '', 3, ?, ?)`, time.Now(), time.Now())
+	assert.NoError(t, err)
+	// 以前のバージョンのデータベースにはマイグレーションの記録がない
+	_, err = raw.Exec(`PRAGMA user_version = 0`)
+	assert.NoError(t, err)
+	database.Close()
+
+	// 開き直すとコードに変換される
+	database, err = NewDB(path)
+	assert.NoError(t, err)
+	defer database.Close()
+
+	expected := map[string]models.Category{"1": models.Research, "2": models.Programming, "3": models.ResearchAndProgram}
+	for id, category := range expected {
+		entry, err := database.GetEntryByID(id)
+		assert.NoError(t, err)
+		assert.Equal(t, category, entry.Category)
+	}
+
+	entries, err := database.GetEntriesByCategory(models.ResearchAndProgram)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	revisions, err := database.GetEntryRevisions("1")
+	assert.NoError(t, err)
+	assert.Len(t, revisions, 1)
+	assert.Equal(t, models.Programming, revisions[0].Entry.Category)
+}
+
+func TestMigrateLegacyCategoriesOnce(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wamon.db")
+
+	database, err := NewDB(path)
+	assert.NoError(t, err)
+	raw := database.(*SQLiteDB).db
+	var version int
+	assert.NoError(t, raw.QueryRow(`PRAGMA user_version`).Scan(&version))
+	assert.Equal(t, categoryCodesVersion, version)
+
+	// 記録済みのデータベースでは開くたびに書き換えない
+	_, err = raw.Exec(`INSERT INTO entries (id, category, research_topic, program_title, satisfaction, created_at) VALUES ('1', '調べ物', 'topic', '', 3, ?)`, time.Now())
+	assert.NoError(t, err)
+	database.Close()
+
+	database, err = NewDB(path)
+	assert.NoError(t, err)
+	defer database.Close()
+	var category string
+	assert.NoError(t, database.(*SQLiteDB).db.QueryRow(`SELECT category FROM entries WHERE id = '1'`).Scan(&category))
+	assert.Equal(t, "調べ物", category)
+}
+
+func TestGetEntriesBetween(t *testing.T) {
+	db := setupTestDB(t)
+
+	day := time.Date(2025, 4, 1, 0, 0, 0, 0, time.Local)
+	for _, e := range []*models.Entry{
+		{ID: "before", Category: models.Research, ResearchTopic: "a", Satisfaction: 3, CreatedAt: day.Add(-time.Minute)},
+		{ID: "morning", Category: models.Research, ResearchTopic: "b", Satisfaction: 3, CreatedAt: day.Add(9 * time.Hour)},
+		{ID: "evening", Category: models.Programming, ProgramTitle: "c", Satisfaction: 3, CreatedAt: day.Add(21 * time.Hour)},
+		{ID: "after", Category: models.Research, ResearchTopic: "d", Satisfaction: 3, CreatedAt: day.AddDate(0, 0, 1)},
+	} {
+		assert.NoError(t, db.SaveEntry(e))
+	}
+
+	entries, err := db.GetEntriesBetween(day, day.AddDate(0, 0, 1))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	// 古い順に並ぶ
+	assert.Equal(t, "morning", entries[0].ID)
+	assert.Equal(t, "evening", entries[1].ID)
+}
+
+func TestDeleteEntry(t *testing.T) {
+	db := setupTestDB(t)
+
+	entry := createTestEntry()
+	assert.NoError(t, db.SaveEntry(entry))
+	entry.ResearchTopic = "更新"
+	assert.NoError(t, db.UpdateEntry(entry))
+
+	// エントリと改訂の両方が削除される
+	assert.NoError(t, db.DeleteEntry(entry.ID))
+	_, err := db.GetEntryByID(entry.ID)
+	assert.Equal(t, sql.ErrNoRows, err)
+	revisions, err := db.GetEntryRevisions(entry.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, revisions)
+
+	// 存在しないIDの削除はエラー
+	assert.Equal(t, sql.ErrNoRows, db.DeleteEntry(entry.ID))
+}
+
+func TestEntryTags(t *testing.T) {
+	db := setupTestDB(t)
+
+	entry := &models.Entry{
+		ID:            "tagged",
+		Category:      models.Research,
+		ResearchTopic: "テストトピック",
+		Satisfaction:  3,
+		CreatedAt:     time.Now(),
+		Tags:          []string{"Go", "#sqlite", "go"},
+	}
+	assert.NoError(t, db.SaveEntry(entry))
+	assert.NoError(t, db.SaveEntry(&models.Entry{ID: "plain", Category: models.Programming, ProgramTitle: "p", Satisfaction: 3, CreatedAt: time.Now()}))
+
+	// 保存時に正規化される
+	saved, err := db.GetEntryByID("tagged")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"go", "sqlite"}, saved.Tags)
+
+	plain, err := db.GetEntryByID("plain")
+	assert.NoError(t, err)
+	assert.Nil(t, plain.Tags)
+
+	// 更新前のタグはリビジョンに残る
+	saved.Tags = []string{"cli"}
+	assert.NoError(t, db.UpdateEntry(saved))
+	revisions, err := db.GetEntryRevisions("tagged")
+	assert.NoError(t, err)
+	assert.Len(t, revisions, 1)
+	assert.Equal(t, []string{"go", "sqlite"}, revisions[0].Entry.Tags)
+
+	tags, err := db.GetAllTags()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cli"}, tags)
+}
+
+func TestGetAllTags(t *testing.T) {
+	db := setupTestDB(t)
+
+	for i, tags := range [][]string{{"sqlite", "go"}, {"go", "cli"}, nil} {
+		assert.NoError(t, db.SaveEntry(&models.Entry{
+			ID:            strconv.Itoa(i),
+			Category:      models.Research,
+			ResearchTopic: "topic",
+			Satisfaction:  3,
+			CreatedAt:     time.Now(),
+			Tags:          tags,
+		}))
+	}
+
+	tags, err := db.GetAllTags()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cli", "go", "sqlite"}, tags)
+}
+
+func TestOpenReadOnly(t *testing.T) {
+	dir := t.TempDir()
+
+	// A missing database is not created
+	_, err := OpenReadOnly(filepath.Join(dir, "missing", "wamon.db"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	_, err = os.Stat(filepath.Join(dir, "missing"))
+	assert.True(t, os.IsNotExist(err))
+
+	path := filepath.Join(dir, "my wamon.db")
+	database, err := NewDB(path)
+	assert.NoError(t, err)
+	assert.NoError(t, database.SaveEntry(&models.Entry{ID: "1", Category: models.Research, ResearchTopic: "a", Satisfaction: 3, CreatedAt: time.Now(), Tags: []string{"go"}}))
+	database.Close()
+
+	readOnly, err := OpenReadOnly(path)
+	assert.NoError(t, err)
+	defer readOnly.Close()
+	tags, err := readOnly.GetAllTags()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"go"}, tags)
+
+	// Nothing can be written
+	assert.Error(t, readOnly.SaveEntry(&models.Entry{ID: "2", Category: models.Research, ResearchTopic: "b", Satisfaction: 3, CreatedAt: time.Now()}))
+}
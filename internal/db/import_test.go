@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/econron/wamon/internal/models"
 	"github.com/stretchr/testify/assert"
@@ -172,3 +173,56 @@ func TestImportWithMixedValidAndInvalidEntries(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, 0, count, "エラー時にもエントリがインポートされています")
 }
+
+func TestImportLegacyCategoryNames(t *testing.T) {
+	db, err := NewDB(filepath.Join(t.TempDir(), "test.db"))
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// 以前のバージョンのエクスポートはカテゴリの表示名を含む
+	filePath := filepath.Join(t.TempDir(), "legacy.json")
+	err = os.WriteFile(filePath, []byte(
+		`{"id":"1","ts":"2022-01-01T12:00:00+09:00","cat":"調べ物","body":"a"}`+"\n"+
+			`{"id":"2","ts":"2022-01-02T12:00:00+09:00","cat":"調べてプログラマ","body":"b - c"}`+"\n"+
+			`{"id":"3","ts":"2022-01-03T12:00:00+09:00","cat":"both","body":"d - e"}`+"\n"), 0644)
+	assert.NoError(t, err)
+
+	count, err := db.ImportEntries(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	entry, err := db.GetEntryByID("1")
+	assert.NoError(t, err)
+	assert.Equal(t, models.Research, entry.Category)
+	entry, err = db.GetEntryByID("2")
+	assert.NoError(t, err)
+	assert.Equal(t, models.ResearchAndProgram, entry.Category)
+
+	// 再エクスポートするとコードで出力される
+	exportPath := filepath.Join(t.TempDir(), "export.json")
+	assert.NoError(t, db.ExportEntries(exportPath))
+	data, err := os.ReadFile(exportPath)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"cat":"research_and_programming"`)
+	assert.NotContains(t, string(data), "調べ物")
+}
+
+func TestExportImportTags(t *testing.T) {
+	source, err := NewDB(filepath.Join(t.TempDir(), "source.db"))
+	assert.NoError(t, err)
+	defer source.Close()
+	assert.NoError(t, source.SaveEntry(&models.Entry{ID: "1", Category: models.Research, ResearchTopic: "a", Satisfaction: 3, CreatedAt: time.Now(), Tags: []string{"go", "cli"}}))
+
+	exportPath := filepath.Join(t.TempDir(), "export.json")
+	assert.NoError(t, source.ExportEntries(exportPath))
+
+	target, err := NewDB(filepath.Join(t.TempDir(), "target.db"))
+	assert.NoError(t, err)
+	defer target.Close()
+	_, err = target.ImportEntries(exportPath)
+	assert.NoError(t, err)
+
+	entry, err := target.GetEntryByID("1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"go", "cli"}, entry.Tags)
+}
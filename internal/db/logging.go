@@ -0,0 +1,73 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/econron/wamon/internal/logging"
+	"github.com/mattn/go-sqlite3"
+)
+
+// driverName is the SQLite driver that logs the time each statement takes
+const driverName = "sqlite3_logged"
+
+func init() {
+	sql.Register(driverName, &loggingDriver{})
+}
+
+// loggingDriver opens SQLite connections that log their statements
+type loggingDriver struct {
+	sqlite3.SQLiteDriver
+}
+
+// Open opens a connection to the database at name
+func (d *loggingDriver) Open(name string) (driver.Conn, error) {
+	conn, err := d.SQLiteDriver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &loggingConn{SQLiteConn: conn.(*sqlite3.SQLiteConn)}, nil
+}
+
+// loggingConn logs the statements run on a connection at the debug level.
+// Only the number of arguments is logged, never their values, which are the user's entries.
+type loggingConn struct {
+	*sqlite3.SQLiteConn
+}
+
+// ExecContext runs a statement that returns no rows
+func (c *loggingConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	start := time.Now()
+	result, err := c.SQLiteConn.ExecContext(ctx, query, args)
+	logStatement(ctx, query, len(args), start, err)
+	return result, err
+}
+
+// QueryContext runs a statement that returns rows; the time is until the first row is ready
+func (c *loggingConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	start := time.Now()
+	rows, err := c.SQLiteConn.QueryContext(ctx, query, args)
+	logStatement(ctx, query, len(args), start, err)
+	return rows, err
+}
+
+// logStatement logs a statement with its duration
+func logStatement(ctx context.Context, query string, args int, start time.Time, err error) {
+	if !logging.Enabled(slog.LevelDebug) {
+		return
+	}
+	attrs := []any{"query", compactQuery(query), "args", args, "duration", time.Since(start)}
+	if err != nil {
+		attrs = append(attrs, "error", err)
+	}
+	slog.DebugContext(ctx, "sql", attrs...)
+}
+
+// compactQuery writes a statement on one line
+func compactQuery(query string) string {
+	return strings.Join(strings.Fields(query), " ")
+}
@@ -0,0 +1,35 @@
+package db
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatementsAreLogged(t *testing.T) {
+	var buf bytes.Buffer
+	original := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	defer slog.SetDefault(original)
+
+	database, err := NewDB(":memory:")
+	assert.NoError(t, err)
+	defer database.Close()
+
+	_, err = database.GetEntryByID("20250401120000")
+	assert.Error(t, err)
+
+	output := buf.String()
+	assert.Contains(t, output, `msg="database opened" path=:memory:`)
+	assert.Contains(t, output, "FROM entries WHERE id = ?")
+	assert.Contains(t, output, "args=1")
+	assert.Contains(t, output, "duration=")
+	// The values of the arguments are never logged
+	assert.NotContains(t, output, "20250401120000")
+}
+
+func TestCompactQuery(t *testing.T) {
+	assert.Equal(t, "SELECT id FROM entries WHERE id = ?", compactQuery("\n\tSELECT id\n\tFROM entries\n\tWHERE id = ?\n"))
+}
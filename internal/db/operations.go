@@ -0,0 +1,329 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/econron/wamon/internal/models"
+)
+
+// ErrNothingToUndo is returned by UndoOperation when every operation has been undone
+var ErrNothingToUndo = errors.New("nothing to undo")
+
+// ErrAlreadyUndone is returned by UndoOperation for an operation that was already undone
+var ErrAlreadyUndone = errors.New("operation already undone")
+
+// UndoConflictError is returned when a later operation changed an entry of the
+// operation being undone; the later one has to be undone first
+type UndoConflictError struct {
+	OperationID int64
+	LaterID     int64
+	EntryID     string
+}
+
+// Error describes which later operation blocks the undo
+func (e *UndoConflictError) Error() string {
+	return fmt.Sprintf("operation %d changed entry %s after operation %d", e.LaterID, e.EntryID, e.OperationID)
+}
+
+// entryIDSeparator joins entry IDs in GROUP_CONCAT; it cannot appear in an ID
+const entryIDSeparator = "\x1f"
+
+// operationChange is the journal record of one entry touched by an operation
+type operationChange struct {
+	entryID    string
+	previous   *models.Entry      // state before the operation; nil when the entry did not exist
+	revisionID int64              // revision the operation created, 0 if none
+	revisions  []*models.Revision // revisions the operation deleted
+}
+
+// querier is implemented by both *sql.DB and *sql.Tx
+type querier interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// createOperationTables creates the operation journal used by undo
+func createOperationTables(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS operations (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			kind TEXT NOT NULL,
+			created_at TIMESTAMP NOT NULL,
+			undone_at TIMESTAMP
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS operation_entries (
+			operation_id INTEGER NOT NULL,
+			entry_id TEXT NOT NULL,
+			previous TEXT,
+			revision_id INTEGER,
+			revisions TEXT
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec(`CREATE INDEX IF NOT EXISTS idx_operation_entries_operation_id ON operation_entries(operation_id)`)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(`CREATE INDEX IF NOT EXISTS idx_operation_entries_entry_id ON operation_entries(entry_id)`)
+	return err
+}
+
+// recordOperation adds an operation and the entries it touched to the journal within tx
+func recordOperation(tx *sql.Tx, kind models.OperationKind, now time.Time, changes []operationChange) error {
+	result, err := tx.Exec(`INSERT INTO operations (kind, created_at) VALUES (?, ?)`, kind, now)
+	if err != nil {
+		return err
+	}
+	operationID, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	for _, change := range changes {
+		var previous, revisions sql.NullString
+		if change.previous != nil {
+			data, err := json.Marshal(change.previous)
+			if err != nil {
+				return err
+			}
+			previous = sql.NullString{String: string(data), Valid: true}
+		}
+		if len(change.revisions) > 0 {
+			data, err := json.Marshal(change.revisions)
+			if err != nil {
+				return err
+			}
+			revisions = sql.NullString{String: string(data), Valid: true}
+		}
+		var revisionID sql.NullInt64
+		if change.revisionID != 0 {
+			revisionID = sql.NullInt64{Int64: change.revisionID, Valid: true}
+		}
+
+		_, err := tx.Exec(
+			`INSERT INTO operation_entries (operation_id, entry_id, previous, revision_id, revisions) VALUES (?, ?, ?, ?, ?)`,
+			operationID, change.entryID, previous, revisionID, revisions,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetOperations returns the most recent operations in the journal, newest first
+func (s *SQLiteDB) GetOperations(limit int) ([]*models.Operation, error) {
+	rows, err := s.db.Query(`
+		SELECT o.id, o.kind, o.created_at, o.undone_at, COALESCE(GROUP_CONCAT(e.entry_id, ?), '')
+		FROM operations o
+		LEFT JOIN operation_entries e ON e.operation_id = o.id
+		GROUP BY o.id
+		ORDER BY o.id DESC
+		LIMIT ?
+	`, entryIDSeparator, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var operations []*models.Operation
+	for rows.Next() {
+		op := &models.Operation{}
+		var kind, entryIDs string
+		var undoneAt sql.NullTime
+		if err := rows.Scan(&op.ID, &kind, &op.CreatedAt, &undoneAt, &entryIDs); err != nil {
+			return nil, err
+		}
+		op.Kind = models.OperationKind(kind)
+		if undoneAt.Valid {
+			t := undoneAt.Time
+			op.UndoneAt = &t
+		}
+		if entryIDs != "" {
+			op.EntryIDs = strings.Split(entryIDs, entryIDSeparator)
+		}
+		operations = append(operations, op)
+	}
+	return operations, rows.Err()
+}
+
+// UndoOperation reverts an operation in a single transaction and marks it as undone.
+// An id of 0 selects the most recent operation that has not been undone.
+func (s *SQLiteDB) UndoOperation(id int64) (*models.Operation, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if id == 0 {
+		err := tx.QueryRow(`SELECT id FROM operations WHERE undone_at IS NULL ORDER BY id DESC LIMIT 1`).Scan(&id)
+		if err == sql.ErrNoRows {
+			return nil, ErrNothingToUndo
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	op := &models.Operation{ID: id}
+	var kind string
+	var undoneAt sql.NullTime
+	err = tx.QueryRow(`SELECT kind, created_at, undone_at FROM operations WHERE id = ?`, id).Scan(&kind, &op.CreatedAt, &undoneAt)
+	if err != nil {
+		return nil, err
+	}
+	if undoneAt.Valid {
+		return nil, ErrAlreadyUndone
+	}
+	op.Kind = models.OperationKind(kind)
+
+	// Later operations on the same entries would be lost, so they must be undone first
+	var laterID int64
+	var entryID string
+	err = tx.QueryRow(`
+		SELECT e.operation_id, e.entry_id
+		FROM operation_entries e
+		JOIN operations o ON o.id = e.operation_id
+		WHERE o.id > ? AND o.undone_at IS NULL
+		  AND e.entry_id IN (SELECT entry_id FROM operation_entries WHERE operation_id = ?)
+		ORDER BY e.operation_id DESC
+		LIMIT 1
+	`, id, id).Scan(&laterID, &entryID)
+	if err == nil {
+		return nil, &UndoConflictError{OperationID: id, LaterID: laterID, EntryID: entryID}
+	}
+	if err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	changes, err := loadOperationChanges(tx, id)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(changes) - 1; i >= 0; i-- {
+		// Keep the entry as it was, so callers can tell what the undo did to it
+		before, err := scanEntry(tx.QueryRow(`SELECT `+entryColumns+` FROM entries WHERE id = ?`, changes[i].entryID))
+		if err != nil && err != sql.ErrNoRows {
+			return nil, err
+		}
+		if err := revertChange(tx, changes[i]); err != nil {
+			return nil, err
+		}
+		op.EntryIDs = append([]string{changes[i].entryID}, op.EntryIDs...)
+		if before != nil || changes[i].previous != nil {
+			op.Reverted = append([]models.RevertedEntry{{Before: before, After: changes[i].previous}}, op.Reverted...)
+		}
+	}
+
+	now := time.Now()
+	if _, err := tx.Exec(`UPDATE operations SET undone_at = ? WHERE id = ?`, now, id); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	op.UndoneAt = &now
+	return op, nil
+}
+
+// loadOperationChanges reads the journal records of an operation in the order they were written
+func loadOperationChanges(tx *sql.Tx, operationID int64) ([]operationChange, error) {
+	rows, err := tx.Query(`
+		SELECT entry_id, previous, revision_id, revisions
+		FROM operation_entries
+		WHERE operation_id = ?
+		ORDER BY rowid ASC
+	`, operationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var changes []operationChange
+	for rows.Next() {
+		var change operationChange
+		var previous, revisions sql.NullString
+		var revisionID sql.NullInt64
+		if err := rows.Scan(&change.entryID, &previous, &revisionID, &revisions); err != nil {
+			return nil, err
+		}
+		if previous.Valid {
+			change.previous = &models.Entry{}
+			if err := json.Unmarshal([]byte(previous.String), change.previous); err != nil {
+				return nil, err
+			}
+		}
+		if revisions.Valid {
+			if err := json.Unmarshal([]byte(revisions.String), &change.revisions); err != nil {
+				return nil, err
+			}
+		}
+		change.revisionID = revisionID.Int64
+		changes = append(changes, change)
+	}
+	return changes, rows.Err()
+}
+
+// revertChange puts one entry back into the state it had before the operation
+func revertChange(tx *sql.Tx, change operationChange) error {
+	if change.previous == nil {
+		_, err := tx.Exec(`DELETE FROM entries WHERE id = ?`, change.entryID)
+		return err
+	}
+
+	entry := change.previous
+	_, err := tx.Exec(
+		`INSERT OR REPLACE INTO entries (id, category, research_topic, program_title, satisfaction, created_at, updated_at, tags)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		entry.ID,
+		entry.Category,
+		entry.ResearchTopic,
+		entry.ProgramTitle,
+		entry.Satisfaction,
+		entry.CreatedAt,
+		entry.UpdatedAt,
+		joinTags(entry.Tags),
+	)
+	if err != nil {
+		return err
+	}
+
+	if change.revisionID != 0 {
+		if _, err := tx.Exec(`DELETE FROM entry_revisions WHERE id = ?`, change.revisionID); err != nil {
+			return err
+		}
+	}
+	for _, rev := range change.revisions {
+		_, err := tx.Exec(
+			`INSERT INTO entry_revisions (entry_id, category, research_topic, program_title, satisfaction, created_at, revised_at, tags)
+			 VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			rev.EntryID,
+			rev.Entry.Category,
+			rev.Entry.ResearchTopic,
+			rev.Entry.ProgramTitle,
+			rev.Entry.Satisfaction,
+			rev.Entry.CreatedAt,
+			rev.RevisedAt,
+			joinTags(rev.Entry.Tags),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
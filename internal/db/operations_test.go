@@ -0,0 +1,206 @@
+package db
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/econron/wamon/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUndoSave(t *testing.T) {
+	db := setupTestDB(t)
+
+	entry := createTestEntry()
+	assert.NoError(t, db.SaveEntry(entry))
+
+	op, err := db.UndoOperation(0)
+	assert.NoError(t, err)
+	assert.Equal(t, models.OperationSave, op.Kind)
+	assert.Equal(t, []string{entry.ID}, op.EntryIDs)
+
+	_, err = db.GetEntryByID(entry.ID)
+	assert.Equal(t, sql.ErrNoRows, err)
+
+	// The removed entry is reported as it was
+	if assert.Len(t, op.Reverted, 1) {
+		assert.Nil(t, op.Reverted[0].After)
+		if assert.NotNil(t, op.Reverted[0].Before) {
+			assert.Equal(t, entry.ResearchTopic, op.Reverted[0].Before.ResearchTopic)
+		}
+	}
+
+	_, err = db.UndoOperation(0)
+	assert.Equal(t, ErrNothingToUndo, err)
+}
+
+func TestUndoSaveEntries(t *testing.T) {
+	db := setupTestDB(t)
+
+	first := createTestEntry()
+	second := createTestEntry()
+	second.ID = "20220101130000"
+	assert.NoError(t, db.SaveEntries([]*models.Entry{first, second}))
+
+	op, err := db.UndoOperation(0)
+	assert.NoError(t, err)
+	assert.Equal(t, models.OperationSave, op.Kind)
+
+	count, err := db.GetEntryCount()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestUndoUpdate(t *testing.T) {
+	db := setupTestDB(t)
+
+	entry := createTestEntry()
+	entry.Tags = []string{"go"}
+	assert.NoError(t, db.SaveEntry(entry))
+
+	entry.Category = models.Programming
+	entry.ProgramTitle = "上書き"
+	entry.Tags = nil
+	assert.NoError(t, db.UpdateEntry(entry))
+
+	op, err := db.UndoOperation(0)
+	assert.NoError(t, err)
+	assert.Equal(t, models.OperationUpdate, op.Kind)
+
+	restored, err := db.GetEntryByID(entry.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.Research, restored.Category)
+	assert.Equal(t, "", restored.ProgramTitle)
+	assert.Equal(t, []string{"go"}, restored.Tags)
+	assert.Nil(t, restored.UpdatedAt)
+
+	// The revision written by the update is removed as well
+	revisions, err := db.GetEntryRevisions(entry.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, revisions)
+}
+
+func TestUndoBulkUpdate(t *testing.T) {
+	db := setupTestDB(t)
+
+	first := createTestEntry()
+	second := createTestEntry()
+	second.ID = "20220101130000"
+	assert.NoError(t, db.SaveEntry(first))
+	assert.NoError(t, db.SaveEntry(second))
+
+	first.Satisfaction = 1
+	second.Satisfaction = 1
+	assert.NoError(t, db.UpdateEntries([]*models.Entry{first, second}))
+
+	op, err := db.UndoOperation(0)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{first.ID, second.ID}, op.EntryIDs)
+	for _, id := range op.EntryIDs {
+		restored, err := db.GetEntryByID(id)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, restored.Satisfaction)
+	}
+}
+
+func TestUndoDelete(t *testing.T) {
+	db := setupTestDB(t)
+
+	entry := createTestEntry()
+	assert.NoError(t, db.SaveEntry(entry))
+	entry.ResearchTopic = "改訂"
+	assert.NoError(t, db.UpdateEntry(entry))
+	assert.NoError(t, db.DeleteEntry(entry.ID))
+
+	op, err := db.UndoOperation(0)
+	assert.NoError(t, err)
+	assert.Equal(t, models.OperationDelete, op.Kind)
+
+	restored, err := db.GetEntryByID(entry.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "改訂", restored.ResearchTopic)
+	assert.NotNil(t, restored.UpdatedAt)
+
+	// The entry brought back is reported with no earlier state
+	if assert.Len(t, op.Reverted, 1) {
+		assert.Nil(t, op.Reverted[0].Before)
+		assert.Equal(t, "改訂", op.Reverted[0].After.ResearchTopic)
+	}
+
+	revisions, err := db.GetEntryRevisions(entry.ID)
+	assert.NoError(t, err)
+	assert.Len(t, revisions, 1)
+	assert.Equal(t, "テストトピック", revisions[0].Entry.ResearchTopic)
+}
+
+func TestUndoImportBatch(t *testing.T) {
+	db := setupTestDB(t)
+
+	existing := createTestEntry()
+	assert.NoError(t, db.SaveEntry(existing))
+
+	importFile := filepath.Join(t.TempDir(), "import.json")
+	content := `{"id":"a","ts":"2025-04-01T09:00:00Z","cat":"research","body":"a"}
+{"id":"b","ts":"2025-04-02T09:00:00Z","cat":"programming","body":"b"}
+{"id":"` + existing.ID + `","ts":"2025-04-03T09:00:00Z","cat":"research","body":"skipped"}
+`
+	assert.NoError(t, os.WriteFile(importFile, []byte(content), 0644))
+	count, err := db.ImportEntries(importFile)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	op, err := db.UndoOperation(0)
+	assert.NoError(t, err)
+	assert.Equal(t, models.OperationImport, op.Kind)
+	assert.Equal(t, []string{"a", "b"}, op.EntryIDs)
+
+	entries, err := db.GetAllEntries()
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, existing.ID, entries[0].ID)
+}
+
+func TestUndoChosenOperation(t *testing.T) {
+	db := setupTestDB(t)
+
+	first := createTestEntry()
+	second := createTestEntry()
+	second.ID = "20220101130000"
+	assert.NoError(t, db.SaveEntry(first))
+	assert.NoError(t, db.SaveEntry(second))
+	first.Satisfaction = 2
+	assert.NoError(t, db.UpdateEntry(first))
+
+	operations, err := db.GetOperations(10)
+	assert.NoError(t, err)
+	assert.Len(t, operations, 3)
+	saveFirst, saveSecond, update := operations[2], operations[1], operations[0]
+	assert.Equal(t, models.OperationUpdate, update.Kind)
+
+	// Undoing the first save would lose the later update
+	_, err = db.UndoOperation(saveFirst.ID)
+	conflict, ok := err.(*UndoConflictError)
+	assert.True(t, ok)
+	assert.Equal(t, update.ID, conflict.LaterID)
+	assert.Equal(t, first.ID, conflict.EntryID)
+
+	// An operation on another entry can be undone out of order
+	_, err = db.UndoOperation(saveSecond.ID)
+	assert.NoError(t, err)
+	_, err = db.GetEntryByID(second.ID)
+	assert.Equal(t, sql.ErrNoRows, err)
+
+	_, err = db.UndoOperation(saveSecond.ID)
+	assert.Equal(t, ErrAlreadyUndone, err)
+	_, err = db.UndoOperation(999)
+	assert.Equal(t, sql.ErrNoRows, err)
+
+	operations, err = db.GetOperations(10)
+	assert.NoError(t, err)
+	assert.NotNil(t, operations[1].UndoneAt)
+	assert.Nil(t, operations[0].UndoneAt)
+	assert.WithinDuration(t, time.Now(), operations[0].CreatedAt, time.Minute)
+}
@@ -0,0 +1,90 @@
+package db
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/econron/wamon/internal/models"
+)
+
+// minSimilarity is the lowest score for an entry to count as similar
+const minSimilarity = 0.15
+
+// FindSimilarEntries returns up to limit entries whose text resembles the given entry,
+// most similar first. The entry itself is never included.
+func (s *SQLiteDB) FindSimilarEntries(entry *models.Entry, limit int) ([]*models.Entry, error) {
+	target := bigrams(entry.Body())
+	if len(target) == 0 || limit <= 0 {
+		return nil, nil
+	}
+
+	candidates, err := s.GetAllEntries()
+	if err != nil {
+		return nil, err
+	}
+
+	type scored struct {
+		entry *models.Entry
+		score float64
+	}
+	var matches []scored
+	for _, candidate := range candidates {
+		if candidate.ID == entry.ID {
+			continue
+		}
+		score := jaccard(target, bigrams(candidate.Body()))
+		if score >= minSimilarity {
+			matches = append(matches, scored{candidate, score})
+		}
+	}
+
+	// Highest score first, newest first on ties
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	similar := make([]*models.Entry, len(matches))
+	for i, m := range matches {
+		similar[i] = m.entry
+	}
+	return similar, nil
+}
+
+// bigrams splits text into a set of character bigrams.
+// Character bigrams work for Japanese text, which has no word separators.
+func bigrams(text string) map[string]struct{} {
+	var runes []rune
+	for _, r := range strings.ToLower(text) {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			runes = append(runes, r)
+		}
+	}
+
+	set := make(map[string]struct{})
+	if len(runes) == 1 {
+		set[string(runes)] = struct{}{}
+	}
+	for i := 0; i+1 < len(runes); i++ {
+		set[string(runes[i:i+2])] = struct{}{}
+	}
+	return set
+}
+
+// jaccard returns the Jaccard index of two sets
+func jaccard(a, b map[string]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	intersection := 0
+	for k := range a {
+		if _, ok := b[k]; ok {
+			intersection++
+		}
+	}
+	union := len(a) + len(b) - intersection
+	return float64(intersection) / float64(union)
+}
@@ -0,0 +1,52 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/econron/wamon/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindSimilarEntries(t *testing.T) {
+	db := setupTestDB(t)
+
+	now := time.Now()
+	target := &models.Entry{ID: "target", Category: models.Research, ResearchTopic: "Goのコンカレンシーパターン", Satisfaction: 4, CreatedAt: now}
+	entries := []*models.Entry{
+		target,
+		{ID: "close", Category: models.Research, ResearchTopic: "Goのコンカレンシーパターンの復習", Satisfaction: 3, CreatedAt: now.Add(-time.Hour)},
+		{ID: "partial", Category: models.Programming, ProgramTitle: "Goのチャネル", Satisfaction: 3, CreatedAt: now.Add(-2 * time.Hour)},
+		{ID: "unrelated", Category: models.Research, ResearchTopic: "量子コンピューティング", Satisfaction: 3, CreatedAt: now.Add(-3 * time.Hour)},
+	}
+	for _, e := range entries {
+		assert.NoError(t, db.SaveEntry(e))
+	}
+
+	similar, err := db.FindSimilarEntries(target, 5)
+	assert.NoError(t, err)
+	if assert.NotEmpty(t, similar) {
+		assert.Equal(t, "close", similar[0].ID)
+	}
+	for _, e := range similar {
+		assert.NotEqual(t, "target", e.ID, "entry itself must not be returned")
+		assert.NotEqual(t, "unrelated", e.ID)
+	}
+
+	// limit is respected
+	similar, err = db.FindSimilarEntries(target, 1)
+	assert.NoError(t, err)
+	assert.Len(t, similar, 1)
+
+	// empty body has nothing to compare
+	similar, err = db.FindSimilarEntries(&models.Entry{ID: "empty"}, 5)
+	assert.NoError(t, err)
+	assert.Empty(t, similar)
+}
+
+func TestJaccard(t *testing.T) {
+	a := bigrams("abc")
+	assert.Equal(t, 1.0, jaccard(a, bigrams("ABC")))
+	assert.Equal(t, 0.0, jaccard(a, bigrams("xyz")))
+	assert.Equal(t, 0.0, jaccard(a, bigrams("")))
+}
@@ -0,0 +1,243 @@
+package feedback
+
+import (
+	"bytes"
+	"math/rand"
+	"sort"
+	"text/template"
+	"time"
+
+	"github.com/econron/wamon/internal/i18n"
+	"github.com/econron/wamon/internal/models"
+	"github.com/econron/wamon/internal/stats"
+)
+
+// trendWindow is the number of entries averaged on each side of a satisfaction trend
+const trendWindow = 3
+
+// trendThreshold is how much the average satisfaction has to change to count as a trend
+const trendThreshold = 1.0
+
+// Facts describe the new entry in the light of the history; rules are matched
+// against them and message templates are executed with them
+type Facts struct {
+	Entry           *models.Entry
+	Category        string // display name of the entry's category
+	Satisfaction    int
+	Count           int  // number of entries including the new one
+	CategoryCount   int  // number of entries in the category including the new one
+	FirstInCategory bool // no earlier entry has the same category
+	Streak          int  // current streak in days
+	LongestStreak   int
+	GapDays         int    // days since the previous entry; -1 for the very first entry
+	Trend           string // "up", "down" or "" for the recent satisfaction
+	Batch           int    // number of entries saved together with this one, including it
+}
+
+// Options configure an Engine
+type Options struct {
+	// Now returns the current time; defaults to time.Now
+	Now func() time.Time
+	// Rand chooses between messages; defaults to a randomly seeded source
+	Rand *rand.Rand
+	// Calendar marks the days off that don't break a streak; nil means every day counts
+	Calendar *stats.Calendar
+}
+
+// Feedback is what the seal says about an entry and the expression it makes
+type Feedback struct {
+	Message string
+	Mood    string // art mood such as "happy"; empty for the default
+}
+
+// Engine selects the seal's message for a new entry from the rules of message packs
+type Engine struct {
+	rules []Rule
+	opts  Options
+}
+
+// NewEngine creates an engine from packs. A rule replaces any rule with the same ID
+// in an earlier pack, so user packs can override the built-in messages.
+func NewEngine(packs []*Pack, opts Options) *Engine {
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	if opts.Rand == nil {
+		opts.Rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	var rules []Rule
+	index := make(map[string]int)
+	for _, pack := range packs {
+		for _, rule := range pack.Rules {
+			if i, ok := index[rule.ID]; ok {
+				rules[i] = rule
+				continue
+			}
+			index[rule.ID] = len(rules)
+			rules = append(rules, rule)
+		}
+	}
+	return &Engine{rules: rules, opts: opts}
+}
+
+// Choose returns the feedback for entry, which has just been saved.
+// entries is the whole history and may include entry itself.
+// The message is empty when no rule matches.
+func (e *Engine) Choose(entry *models.Entry, entries []*models.Entry) Feedback {
+	return e.ChooseBatch([]*models.Entry{entry}, entries)
+}
+
+// ChooseBatch returns one feedback for several entries saved together. The facts
+// of each entry are computed as if the entries had been saved one after another,
+// and the message comes from the highest priority rule matched by any of them.
+func (e *Engine) ChooseBatch(saved []*models.Entry, entries []*models.Entry) Feedback {
+	saved = append([]*models.Entry(nil), saved...)
+	sort.SliceStable(saved, func(i, j int) bool {
+		return saved[i].CreatedAt.Before(saved[j].CreatedAt)
+	})
+
+	// Only the matching rules with the highest priority take part
+	type candidate struct {
+		message string
+		mood    string
+		facts   Facts
+	}
+	var candidates []candidate
+	best := 0
+	now := e.opts.Now()
+	for i, entry := range saved {
+		// The entries saved after this one are not part of its history yet
+		facts := NewFacts(entry, without(entries, saved[i+1:]), now, e.opts.Calendar)
+		facts.Batch = len(saved)
+
+		for _, rule := range e.rules {
+			if !rule.When.Match(facts) {
+				continue
+			}
+			if len(candidates) > 0 && rule.Priority < best {
+				continue
+			}
+			if len(candidates) == 0 || rule.Priority > best {
+				best = rule.Priority
+				candidates = nil
+			}
+			for _, message := range rule.Messages {
+				candidates = append(candidates, candidate{message: message, mood: rule.Mood, facts: facts})
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		return Feedback{}
+	}
+
+	chosen := candidates[e.opts.Rand.Intn(len(candidates))]
+	return Feedback{Message: render(chosen.message, chosen.facts), Mood: chosen.mood}
+}
+
+// without returns the entries except the excluded ones
+func without(entries, excluded []*models.Entry) []*models.Entry {
+	if len(excluded) == 0 {
+		return entries
+	}
+	ids := make(map[string]bool, len(excluded))
+	for _, e := range excluded {
+		ids[e.ID] = true
+	}
+	var kept []*models.Entry
+	for _, e := range entries {
+		if !ids[e.ID] {
+			kept = append(kept, e)
+		}
+	}
+	return kept
+}
+
+// NewFacts computes the facts about entry from the history at the given time
+func NewFacts(entry *models.Entry, entries []*models.Entry, now time.Time, cal *stats.Calendar) Facts {
+	var previous []*models.Entry
+	for _, e := range entries {
+		if e.ID != entry.ID {
+			previous = append(previous, e)
+		}
+	}
+	sort.SliceStable(previous, func(i, j int) bool {
+		return previous[i].CreatedAt.Before(previous[j].CreatedAt)
+	})
+
+	facts := Facts{
+		Entry:           entry,
+		Category:        i18n.CategoryLabel(entry.Category),
+		Satisfaction:    entry.Satisfaction,
+		Count:           len(previous) + 1,
+		CategoryCount:   1,
+		FirstInCategory: true,
+		GapDays:         -1,
+		Batch:           1,
+	}
+	for _, e := range previous {
+		if e.Category == entry.Category {
+			facts.CategoryCount++
+			facts.FirstInCategory = false
+		}
+	}
+
+	if len(previous) > 0 {
+		last := previous[len(previous)-1]
+		facts.GapDays = daysBetween(last.CreatedAt, entry.CreatedAt)
+	}
+
+	streak := stats.ComputeStreak(append(previous, entry), now, cal)
+	facts.Streak = streak.Current
+	facts.LongestStreak = streak.Longest
+
+	facts.Trend = trend(append(previous, entry))
+	return facts
+}
+
+// daysBetween counts the calendar days from a to b in local time
+func daysBetween(a, b time.Time) int {
+	a, b = a.Local(), b.Local()
+	dayA := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.Local)
+	dayB := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.Local)
+	return int(dayB.Sub(dayA).Hours()/24 + 0.5)
+}
+
+// trend compares the average satisfaction of the latest entries with the ones before them
+func trend(entries []*models.Entry) string {
+	if len(entries) < trendWindow*2 {
+		return ""
+	}
+	recent := average(entries[len(entries)-trendWindow:])
+	before := average(entries[len(entries)-trendWindow*2 : len(entries)-trendWindow])
+	switch {
+	case recent-before >= trendThreshold:
+		return "up"
+	case before-recent >= trendThreshold:
+		return "down"
+	default:
+		return ""
+	}
+}
+
+// average returns the mean satisfaction of entries
+func average(entries []*models.Entry) float64 {
+	total := 0
+	for _, e := range entries {
+		total += e.Satisfaction
+	}
+	return float64(total) / float64(len(entries))
+}
+
+// render executes a message template, falling back to the raw text if it fails
+func render(message string, facts Facts) string {
+	tmpl, err := template.New("message").Parse(message)
+	if err != nil {
+		return message
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, facts); err != nil {
+		return message
+	}
+	return buf.String()
+}
@@ -0,0 +1,190 @@
+package feedback
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/econron/wamon/internal/i18n"
+	"github.com/econron/wamon/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// now is the fixed clock used by the tests
+var now = time.Date(2025, 4, 10, 21, 0, 0, 0, time.Local)
+
+// entryAt creates an entry on the day daysAgo before now
+func entryAt(id string, daysAgo int, category models.Category, satisfaction int) *models.Entry {
+	return &models.Entry{
+		ID:           id,
+		Category:     category,
+		Satisfaction: satisfaction,
+		CreatedAt:    now.AddDate(0, 0, -daysAgo),
+	}
+}
+
+// newTestEngine creates an engine over the built-in Japanese pack with a fixed clock and seed
+func newTestEngine(t *testing.T, extra ...*Pack) *Engine {
+	builtin, err := LoadBuiltin(i18n.Japanese)
+	assert.NoError(t, err)
+	return NewEngine(append([]*Pack{builtin}, extra...), Options{
+		Now:  func() time.Time { return now },
+		Rand: rand.New(rand.NewSource(1)),
+	})
+}
+
+func TestNewFacts(t *testing.T) {
+	history := []*models.Entry{
+		entryAt("1", 12, models.Research, 2),
+		entryAt("2", 11, models.Research, 2),
+		entryAt("3", 10, models.Programming, 1),
+		entryAt("4", 2, models.Research, 4),
+		entryAt("5", 1, models.Research, 4),
+	}
+	entry := entryAt("6", 0, models.Research, 5)
+
+	facts := NewFacts(entry, append(history, entry), now, nil)
+	assert.Equal(t, 6, facts.Count)
+	assert.Equal(t, 5, facts.CategoryCount)
+	assert.False(t, facts.FirstInCategory)
+	assert.Equal(t, 3, facts.Streak)
+	assert.Equal(t, 3, facts.LongestStreak)
+	assert.Equal(t, 1, facts.GapDays)
+	assert.Equal(t, "up", facts.Trend)
+	assert.Equal(t, i18n.CategoryLabel(models.Research), facts.Category)
+}
+
+func TestNewFactsFirstEntry(t *testing.T) {
+	entry := entryAt("1", 0, models.Programming, 3)
+
+	facts := NewFacts(entry, nil, now, nil)
+	assert.Equal(t, 1, facts.Count)
+	assert.True(t, facts.FirstInCategory)
+	assert.Equal(t, -1, facts.GapDays)
+	assert.Equal(t, "", facts.Trend)
+}
+
+func TestChooseRules(t *testing.T) {
+	testCases := []struct {
+		name     string
+		history  []*models.Entry
+		entry    *models.Entry
+		contains string
+	}{
+		{
+			name:     "First entry is a milestone",
+			entry:    entryAt("1", 0, models.Research, 3),
+			contains: "1件目",
+		},
+		{
+			name:     "Comeback after a gap",
+			history:  []*models.Entry{entryAt("1", 20, models.Research, 3), entryAt("2", 10, models.Research, 3)},
+			entry:    entryAt("3", 0, models.Research, 3),
+			contains: "10日",
+		},
+		{
+			name:     "First entry in a category",
+			history:  []*models.Entry{entryAt("1", 3, models.Research, 3), entryAt("2", 1, models.Research, 3)},
+			entry:    entryAt("3", 0, models.Programming, 3),
+			contains: "初めての「" + i18n.CategoryLabel(models.Programming) + "」",
+		},
+		{
+			name: "Streak",
+			history: []*models.Entry{
+				entryAt("1", 4, models.Research, 3),
+				entryAt("2", 3, models.Research, 3),
+				entryAt("3", 2, models.Research, 3),
+				entryAt("4", 1, models.Research, 3),
+			},
+			entry:    entryAt("5", 0, models.Research, 3),
+			contains: "5日連続",
+		},
+		{
+			name: "Satisfaction going down",
+			history: []*models.Entry{
+				entryAt("1", 9, models.Research, 5),
+				entryAt("2", 8, models.Research, 5),
+				entryAt("3", 7, models.Research, 5),
+				entryAt("4", 5, models.Research, 2),
+				entryAt("5", 3, models.Research, 2),
+			},
+			entry:    entryAt("6", 1, models.Research, 2),
+			contains: "大変そう",
+		},
+		{
+			name:     "Falls back to the satisfaction",
+			history:  []*models.Entry{entryAt("1", 3, models.Research, 3), entryAt("2", 1, models.Research, 3)},
+			entry:    entryAt("3", 0, models.Research, 4),
+			contains: "拍手",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			engine := newTestEngine(t)
+			message := engine.Choose(tc.entry, append(tc.history, tc.entry)).Message
+			assert.Contains(t, message, tc.contains)
+		})
+	}
+}
+
+func TestChooseDeterministic(t *testing.T) {
+	entry := entryAt("1", 0, models.Research, 3)
+
+	// The same seed picks the same message
+	first := newTestEngine(t).Choose(entry, nil)
+	second := newTestEngine(t).Choose(entry, nil)
+	assert.Equal(t, first, second)
+}
+
+func TestUserPackOverridesRule(t *testing.T) {
+	pack := &Pack{Name: "mine", Rules: []Rule{
+		{ID: "satisfaction_4", Priority: 10, When: When{Satisfaction: []int{4}}, Messages: []string{"満足度{{.Satisfaction}}、いいね！"}},
+	}}
+	history := []*models.Entry{entryAt("1", 3, models.Research, 3), entryAt("2", 1, models.Research, 3)}
+	entry := entryAt("3", 0, models.Research, 4)
+
+	message := newTestEngine(t, pack).Choose(entry, append(history, entry)).Message
+	assert.Equal(t, "満足度4、いいね！", message)
+}
+
+func TestChooseNoRules(t *testing.T) {
+	engine := NewEngine(nil, Options{})
+	assert.Equal(t, Feedback{}, engine.Choose(entryAt("1", 0, models.Research, 3), nil))
+}
+
+func TestChooseMood(t *testing.T) {
+	history := []*models.Entry{entryAt("1", 3, models.Research, 3), entryAt("2", 1, models.Research, 3)}
+
+	// The mood comes from the rule that was chosen
+	entry := entryAt("3", 0, models.Research, 1)
+	assert.Equal(t, "caring", newTestEngine(t).Choose(entry, append(history, entry)).Mood)
+
+	entry = entryAt("3", 0, models.Research, 5)
+	assert.Equal(t, "excited", newTestEngine(t).Choose(entry, append(history, entry)).Mood)
+}
+
+func TestChooseBatch(t *testing.T) {
+	history := []*models.Entry{entryAt("1", 3, models.Research, 3), entryAt("2", 1, models.Research, 3)}
+	saved := []*models.Entry{entryAt("3", 0, models.Research, 4), entryAt("4", 0, models.Research, 3)}
+
+	fb := newTestEngine(t).ChooseBatch(saved, append(history, saved...))
+	assert.Contains(t, fb.Message, "2件")
+	assert.Equal(t, "excited", fb.Mood)
+
+	// Each entry counts as saved after the ones before it, so a milestone
+	// passed in the middle of the batch is still noticed
+	history = nil
+	for i := 0; i < 8; i++ {
+		history = append(history, entryAt(string(rune('a'+i)), 20-i, models.Research, 3))
+	}
+	saved = []*models.Entry{
+		entryAt("9", 0, models.Research, 3),
+		entryAt("10", 0, models.Research, 3),
+		entryAt("11", 0, models.Research, 3),
+	}
+	saved[1].CreatedAt = saved[0].CreatedAt.Add(time.Minute)
+	saved[2].CreatedAt = saved[0].CreatedAt.Add(2 * time.Minute)
+	fb = newTestEngine(t).ChooseBatch(saved, append(history, saved...))
+	assert.Contains(t, fb.Message, "10件目")
+}
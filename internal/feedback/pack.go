@@ -0,0 +1,165 @@
+package feedback
+
+import (
+	"embed"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/econron/wamon/internal/i18n"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed packs/*.yaml
+var builtinPacks embed.FS
+
+// Pack is a set of rules loaded from a YAML file
+type Pack struct {
+	Name     string `yaml:"name"`
+	Language string `yaml:"language"` // language the messages are written in; empty means any
+	Rules    []Rule `yaml:"rules"`
+}
+
+// Rule maps a condition on the facts to the messages the seal can say.
+// When several rules match, only the ones with the highest priority are used.
+type Rule struct {
+	ID       string   `yaml:"id"`
+	Priority int      `yaml:"priority"`
+	When     When     `yaml:"when"`
+	Messages []string `yaml:"messages"`
+	Mood     string   `yaml:"mood"` // expression of the seal art, e.g. "proud"
+}
+
+// When is the condition of a rule. Every field that is set must hold;
+// an empty condition matches every entry.
+type When struct {
+	Satisfaction    []int  `yaml:"satisfaction"`      // satisfaction is one of these
+	MinStreak       int    `yaml:"min_streak"`        // current streak is at least this many days
+	Milestones      []int  `yaml:"milestones"`        // total entry count is one of these
+	FirstInCategory bool   `yaml:"first_in_category"` // first entry in its category
+	MinGapDays      int    `yaml:"min_gap_days"`      // days since the previous entry are at least this
+	Trend           string `yaml:"trend"`             // "up" or "down"
+	MinBatch        int    `yaml:"min_batch"`         // at least this many entries were saved together
+}
+
+// Match reports whether the facts satisfy the condition
+func (w When) Match(f Facts) bool {
+	if len(w.Satisfaction) > 0 && !containsInt(w.Satisfaction, f.Satisfaction) {
+		return false
+	}
+	if w.MinStreak > 0 && f.Streak < w.MinStreak {
+		return false
+	}
+	if len(w.Milestones) > 0 && !containsInt(w.Milestones, f.Count) {
+		return false
+	}
+	if w.FirstInCategory && (!f.FirstInCategory || f.Count == 1) {
+		return false
+	}
+	if w.MinGapDays > 0 && f.GapDays < w.MinGapDays {
+		return false
+	}
+	if w.Trend != "" && w.Trend != f.Trend {
+		return false
+	}
+	if w.MinBatch > 0 && f.Batch < w.MinBatch {
+		return false
+	}
+	return true
+}
+
+// ParsePack parses and validates a message pack
+func ParsePack(name string, data []byte) (*Pack, error) {
+	pack := &Pack{}
+	if err := yaml.Unmarshal(data, pack); err != nil {
+		return nil, errors.New(i18n.T("feedback.parse_error", name, err))
+	}
+	if pack.Name == "" {
+		pack.Name = name
+	}
+	for i, rule := range pack.Rules {
+		if rule.ID == "" {
+			return nil, errors.New(i18n.T("feedback.missing_id", name, i+1))
+		}
+		if len(rule.Messages) == 0 {
+			return nil, errors.New(i18n.T("feedback.no_messages", name, rule.ID))
+		}
+		if rule.When.Trend != "" && rule.When.Trend != "up" && rule.When.Trend != "down" {
+			return nil, errors.New(i18n.T("feedback.invalid_trend", name, rule.ID, rule.When.Trend))
+		}
+	}
+	return pack, nil
+}
+
+// LoadBuiltin returns the built-in pack for lang, falling back to the default language
+func LoadBuiltin(lang i18n.Lang) (*Pack, error) {
+	data, err := builtinPacks.ReadFile("packs/" + string(lang) + ".yaml")
+	if err != nil {
+		lang = i18n.Default
+		data, err = builtinPacks.ReadFile("packs/" + string(lang) + ".yaml")
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ParsePack(string(lang), data)
+}
+
+// LoadDir loads the user packs in dir in file name order.
+// Packs written for another language than lang are left out.
+// A missing directory is not an error.
+func LoadDir(dir string, lang i18n.Lang) ([]*Pack, error) {
+	files, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, file := range files {
+		ext := filepath.Ext(file.Name())
+		if !file.IsDir() && (ext == ".yaml" || ext == ".yml") {
+			names = append(names, file.Name())
+		}
+	}
+	sort.Strings(names)
+
+	var packs []*Pack
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, errors.New(i18n.T("feedback.read_error", name, err))
+		}
+		pack, err := ParsePack(strings.TrimSuffix(name, filepath.Ext(name)), data)
+		if err != nil {
+			return nil, err
+		}
+		if pack.Language != "" && pack.Language != string(lang) {
+			continue
+		}
+		packs = append(packs, pack)
+	}
+	return packs, nil
+}
+
+// Dir returns the directory user message packs are read from
+func Dir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "messages"
+	}
+	return filepath.Join(home, ".wamon", "messages")
+}
+
+// containsInt reports whether values contains v
+func containsInt(values []int, v int) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package feedback
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/econron/wamon/internal/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadBuiltin(t *testing.T) {
+	for _, lang := range i18n.Languages() {
+		pack, err := LoadBuiltin(lang)
+		assert.NoError(t, err)
+		assert.Equal(t, string(lang), pack.Language)
+		assert.NotEmpty(t, pack.Rules)
+	}
+
+	// Unknown languages fall back to the default pack
+	pack, err := LoadBuiltin("xx")
+	assert.NoError(t, err)
+	assert.Equal(t, string(i18n.Default), pack.Language)
+}
+
+func TestBuiltinPacksHaveSameRules(t *testing.T) {
+	ja, err := LoadBuiltin(i18n.Japanese)
+	assert.NoError(t, err)
+	en, err := LoadBuiltin(i18n.English)
+	assert.NoError(t, err)
+
+	assert.Equal(t, len(ja.Rules), len(en.Rules))
+	for i := range ja.Rules {
+		assert.Equal(t, ja.Rules[i].ID, en.Rules[i].ID)
+		assert.Equal(t, ja.Rules[i].Priority, en.Rules[i].Priority)
+		assert.Equal(t, ja.Rules[i].When, en.Rules[i].When)
+		assert.Equal(t, ja.Rules[i].Mood, en.Rules[i].Mood)
+	}
+}
+
+func TestParsePack(t *testing.T) {
+	testCases := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{
+			name: "Valid pack",
+			data: "rules:\n  - id: late\n    when:\n      satisfaction: [5]\n    messages: [\"yay\"]\n",
+		},
+		{name: "Invalid YAML", data: "rules: [", wantErr: true},
+		{name: "Missing id", data: "rules:\n  - messages: [\"yay\"]\n", wantErr: true},
+		{name: "No messages", data: "rules:\n  - id: late\n", wantErr: true},
+		{name: "Invalid trend", data: "rules:\n  - id: late\n    when:\n      trend: sideways\n    messages: [\"yay\"]\n", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pack, err := ParsePack("test", []byte(tc.data))
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "test", pack.Name)
+			assert.Equal(t, []int{5}, pack.Rules[0].When.Satisfaction)
+		})
+	}
+}
+
+func TestLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+	write("b.yaml", "language: ja\nrules:\n  - id: b\n    messages: [\"b\"]\n")
+	write("a.yml", "rules:\n  - id: a\n    messages: [\"a\"]\n")
+	write("english.yaml", "language: en\nrules:\n  - id: e\n    messages: [\"e\"]\n")
+	write("notes.txt", "not a pack")
+
+	packs, err := LoadDir(dir, i18n.Japanese)
+	assert.NoError(t, err)
+	if assert.Len(t, packs, 2) {
+		assert.Equal(t, "a", packs[0].Name)
+		assert.Equal(t, "b", packs[1].Name)
+	}
+
+	// A missing directory has no packs
+	packs, err = LoadDir(filepath.Join(dir, "missing"), i18n.Japanese)
+	assert.NoError(t, err)
+	assert.Empty(t, packs)
+
+	// An invalid pack is reported
+	write("c.yaml", "rules: [")
+	_, err = LoadDir(dir, i18n.Japanese)
+	assert.Error(t, err)
+}
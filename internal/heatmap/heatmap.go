@@ -0,0 +1,130 @@
+package heatmap
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/econron/wamon/internal/i18n"
+	"github.com/econron/wamon/internal/models"
+)
+
+// Metric selects what value each day of the heatmap represents
+type Metric string
+
+const (
+	// Count is the number of entries recorded on the day
+	Count Metric = "count"
+	// Satisfaction is the average satisfaction of the day's entries
+	Satisfaction Metric = "satisfaction"
+)
+
+// Levels is the number of intensity levels, including the empty level 0
+const Levels = 5
+
+// Cell is a single day of the heatmap
+type Cell struct {
+	Date   time.Time
+	Value  float64
+	Count  int
+	InYear bool // false for the padding days before January 1st and after December 31st
+}
+
+// Grid is a calendar laid out as GitHub does: one column per week, Sunday on top
+type Grid struct {
+	Year   int
+	Metric Metric
+	Weeks  [][7]Cell
+	Max    float64
+}
+
+// ParseMetric validates a metric name
+func ParseMetric(s string) (Metric, error) {
+	switch Metric(s) {
+	case Count, Satisfaction:
+		return Metric(s), nil
+	default:
+		return "", errors.New(i18n.T("heatmap.invalid_metric", s))
+	}
+}
+
+// Build lays out the entries of the given year on a calendar grid
+func Build(entries []*models.Entry, year int, metric Metric) *Grid {
+	counts := make(map[string]int)
+	sums := make(map[string]int)
+	for _, entry := range entries {
+		local := entry.CreatedAt.Local()
+		if local.Year() != year {
+			continue
+		}
+		key := local.Format("2006-01-02")
+		counts[key]++
+		sums[key] += entry.Satisfaction
+	}
+
+	jan1 := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+	dec31 := time.Date(year, time.December, 31, 0, 0, 0, 0, time.Local)
+	// Start on the Sunday on or before January 1st
+	start := jan1.AddDate(0, 0, -int(jan1.Weekday()))
+
+	grid := &Grid{Year: year, Metric: metric}
+	for day := start; !day.After(dec31); day = day.AddDate(0, 0, 7) {
+		var week [7]Cell
+		for i := 0; i < 7; i++ {
+			date := day.AddDate(0, 0, i)
+			key := date.Format("2006-01-02")
+			cell := Cell{Date: date, Count: counts[key], InYear: date.Year() == year}
+			switch metric {
+			case Satisfaction:
+				if cell.Count > 0 {
+					cell.Value = float64(sums[key]) / float64(cell.Count)
+				}
+			default:
+				cell.Value = float64(cell.Count)
+			}
+			if cell.InYear && cell.Value > grid.Max {
+				grid.Max = cell.Value
+			}
+			week[i] = cell
+		}
+		grid.Weeks = append(grid.Weeks, week)
+	}
+
+	return grid
+}
+
+// Level maps a cell value to an intensity between 0 (nothing) and Levels-1
+func (g *Grid) Level(cell Cell) int {
+	if !cell.InYear || cell.Value <= 0 {
+		return 0
+	}
+	var ratio float64
+	switch g.Metric {
+	case Satisfaction:
+		// Satisfaction has a fixed 1-5 scale, independent of the other days
+		ratio = cell.Value / 5
+	default:
+		ratio = cell.Value / g.Max
+	}
+	level := int(math.Ceil(ratio * float64(Levels-1)))
+	if level < 1 {
+		level = 1
+	}
+	if level > Levels-1 {
+		level = Levels - 1
+	}
+	return level
+}
+
+// monthStarts returns, for each week index, the month that starts in that week (0 if none)
+func (g *Grid) monthStarts() []time.Month {
+	starts := make([]time.Month, len(g.Weeks))
+	for i, week := range g.Weeks {
+		for _, cell := range week {
+			if cell.InYear && cell.Date.Day() == 1 {
+				starts[i] = cell.Date.Month()
+			}
+		}
+	}
+	return starts
+}
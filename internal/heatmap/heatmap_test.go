@@ -0,0 +1,93 @@
+package heatmap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/econron/wamon/internal/i18n"
+	"github.com/econron/wamon/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func date(month time.Month, day, hour int) time.Time {
+	return time.Date(2025, month, day, hour, 0, 0, 0, time.Local)
+}
+
+func testEntries() []*models.Entry {
+	return []*models.Entry{
+		{Satisfaction: 5, CreatedAt: date(time.January, 1, 9)},
+		{Satisfaction: 3, CreatedAt: date(time.January, 1, 18)},
+		{Satisfaction: 1, CreatedAt: date(time.March, 15, 9)},
+		{Satisfaction: 4, CreatedAt: date(time.December, 31, 9)},
+		{Satisfaction: 5, CreatedAt: time.Date(2024, time.December, 31, 9, 0, 0, 0, time.Local)},
+	}
+}
+
+// findCell returns the grid cell for a date
+func findCell(t *testing.T, g *Grid, d time.Time) Cell {
+	for _, week := range g.Weeks {
+		for _, cell := range week {
+			if cell.Date.Equal(d) {
+				return cell
+			}
+		}
+	}
+	t.Fatalf("no cell for %s", d)
+	return Cell{}
+}
+
+func TestBuildCount(t *testing.T) {
+	g := Build(testEntries(), 2025, Count)
+
+	// 2025-01-01 is a Wednesday, so the first column starts on Sunday 2024-12-29
+	assert.Equal(t, time.Date(2024, time.December, 29, 0, 0, 0, 0, time.Local), g.Weeks[0][0].Date)
+	assert.False(t, g.Weeks[0][0].InYear)
+	assert.Equal(t, 53, len(g.Weeks))
+
+	jan1 := findCell(t, g, date(time.January, 1, 0))
+	assert.Equal(t, 2, jan1.Count)
+	assert.Equal(t, 2.0, jan1.Value)
+	assert.Equal(t, 2.0, g.Max)
+
+	// Entries from other years are ignored, even when they fall on a padding cell
+	dec31Prev := findCell(t, g, time.Date(2024, time.December, 31, 0, 0, 0, 0, time.Local))
+	assert.Equal(t, 0, dec31Prev.Count)
+
+	assert.Equal(t, Levels-1, g.Level(jan1))
+	assert.Equal(t, 2, g.Level(findCell(t, g, date(time.March, 15, 0))))
+	assert.Equal(t, 0, g.Level(findCell(t, g, date(time.March, 16, 0))))
+}
+
+func TestBuildSatisfaction(t *testing.T) {
+	g := Build(testEntries(), 2025, Satisfaction)
+
+	jan1 := findCell(t, g, date(time.January, 1, 0))
+	assert.Equal(t, 4.0, jan1.Value)
+	assert.Equal(t, 4, g.Level(jan1))
+
+	mar15 := findCell(t, g, date(time.March, 15, 0))
+	assert.Equal(t, 1, g.Level(mar15))
+}
+
+func TestParseMetric(t *testing.T) {
+	m, err := ParseMetric("count")
+	assert.NoError(t, err)
+	assert.Equal(t, Count, m)
+
+	m, err = ParseMetric("satisfaction")
+	assert.NoError(t, err)
+	assert.Equal(t, Satisfaction, m)
+
+	_, err = ParseMetric("stars")
+	assert.Error(t, err)
+}
+
+func TestParseMetricEnglish(t *testing.T) {
+	assert.NoError(t, i18n.SetLanguage("en"))
+	defer i18n.SetLanguage(string(i18n.Default))
+
+	_, err := ParseMetric("stars")
+	if assert.Error(t, err) {
+		assert.Equal(t, "Unknown metric: stars (count, satisfaction)", err.Error())
+	}
+}
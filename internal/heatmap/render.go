@@ -0,0 +1,135 @@
+package heatmap
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/econron/wamon/internal/i18n"
+)
+
+// plainBlocks draw the levels without color, from empty to full
+var plainBlocks = [Levels]string{"·", "░", "▒", "▓", "█"}
+
+// ansiColors are 256-color palette greens, from empty to full
+var ansiColors = [Levels]int{238, 22, 28, 34, 46}
+
+// svgColors are the GitHub contribution graph colors, from empty to full
+var svgColors = [Levels]string{"#ebedf0", "#9be9a8", "#40c463", "#30a14e", "#216e39"}
+
+// RenderText writes the heatmap as a terminal calendar grid.
+// With color disabled, levels are drawn with Unicode shade blocks instead.
+func RenderText(w io.Writer, g *Grid, color bool) {
+	var b strings.Builder
+
+	// Month labels, placed above the week in which each month starts
+	header := []rune(strings.Repeat(" ", len(g.Weeks)+1))
+	for i, month := range g.monthStarts() {
+		if month == 0 {
+			continue
+		}
+		label := month.String()[:3]
+		for j, r := range label {
+			if i+j < len(header) {
+				header[i+j] = r
+			}
+		}
+	}
+	b.WriteString("   " + strings.TrimRight(string(header), " ") + "\n")
+
+	for day := 0; day < 7; day++ {
+		b.WriteString(weekdayLabel(day) + " ")
+		for _, week := range g.Weeks {
+			cell := week[day]
+			if !cell.InYear {
+				b.WriteString(" ")
+				continue
+			}
+			b.WriteString(block(g.Level(cell), color))
+		}
+		b.WriteString("\n")
+	}
+
+	// Legend
+	b.WriteString("   " + i18n.T("heatmap.legend_less") + " ")
+	for level := 0; level < Levels; level++ {
+		b.WriteString(block(level, color))
+	}
+	b.WriteString(" " + i18n.T("heatmap.legend_more") + "\n")
+
+	io.WriteString(w, b.String())
+}
+
+// weekdayLabel labels every other row (Mon, Wed, Fri), like GitHub does.
+// Rows start on Sunday.
+func weekdayLabel(day int) string {
+	if day%2 == 0 {
+		return "  "
+	}
+	return i18n.T(fmt.Sprintf("weekday.short.%d", day))
+}
+
+// block returns the glyph for a level
+func block(level int, color bool) string {
+	if !color {
+		return plainBlocks[level]
+	}
+	return fmt.Sprintf("\x1b[38;5;%dm■\x1b[0m", ansiColors[level])
+}
+
+// SVG layout constants, in pixels
+const (
+	svgCell   = 11
+	svgGap    = 2
+	svgLeft   = 28
+	svgTop    = 20
+	svgBottom = 8
+)
+
+// RenderSVG writes the heatmap as a standalone SVG image
+func RenderSVG(w io.Writer, g *Grid) error {
+	step := svgCell + svgGap
+	width := svgLeft + len(g.Weeks)*step
+	height := svgTop + 7*step + svgBottom
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="sans-serif" font-size="9">`+"\n", width, height)
+	fmt.Fprintf(&b, `<title>wamon %d (%s)</title>`+"\n", g.Year, g.Metric)
+
+	for i, month := range g.monthStarts() {
+		if month != 0 {
+			fmt.Fprintf(&b, `<text x="%d" y="%d" fill="#767676">%s</text>`+"\n", svgLeft+i*step, svgTop-6, month.String()[:3])
+		}
+	}
+	for day, label := range []string{"", "Mon", "", "Wed", "", "Fri", ""} {
+		if label != "" {
+			fmt.Fprintf(&b, `<text x="0" y="%d" fill="#767676">%s</text>`+"\n", svgTop+day*step+svgCell-2, label)
+		}
+	}
+
+	for i, week := range g.Weeks {
+		for day, cell := range week {
+			if !cell.InYear {
+				continue
+			}
+			fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" rx="2" fill="%s"><title>%s: %s</title></rect>`+"\n",
+				svgLeft+i*step, svgTop+day*step, svgCell, svgCell,
+				svgColors[g.Level(cell)], cell.Date.Format("2006-01-02"), describe(g.Metric, cell))
+		}
+	}
+
+	b.WriteString("</svg>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// describe returns the tooltip text for a cell
+func describe(metric Metric, cell Cell) string {
+	if metric == Satisfaction {
+		if cell.Count == 0 {
+			return "no entries"
+		}
+		return fmt.Sprintf("satisfaction %.1f (%d entries)", cell.Value, cell.Count)
+	}
+	return fmt.Sprintf("%d entries", cell.Count)
+}
@@ -0,0 +1,73 @@
+package heatmap
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderTextPlain(t *testing.T) {
+	g := Build(testEntries(), 2025, Count)
+
+	var buf bytes.Buffer
+	RenderText(&buf, g, false)
+	output := buf.String()
+
+	assert.NotContains(t, output, "\x1b[", "plain output must not contain ANSI escapes")
+	assert.Contains(t, output, "Jan")
+	assert.Contains(t, output, "Dec")
+	assert.Contains(t, output, "█")
+	assert.Contains(t, output, "少 ·░▒▓█ 多")
+
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	// header, 7 weekday rows and the legend
+	assert.Len(t, lines, 9)
+}
+
+func TestRenderTextColor(t *testing.T) {
+	g := Build(testEntries(), 2025, Count)
+
+	var buf bytes.Buffer
+	RenderText(&buf, g, true)
+	assert.Contains(t, buf.String(), "\x1b[38;5;46m■")
+}
+
+func TestRenderSVG(t *testing.T) {
+	g := Build(testEntries(), 2025, Count)
+
+	var buf bytes.Buffer
+	assert.NoError(t, RenderSVG(&buf, g))
+	output := buf.String()
+
+	// Must be well-formed XML
+	decoder := xml.NewDecoder(strings.NewReader(output))
+	for {
+		_, err := decoder.Token()
+		if err != nil {
+			assert.Equal(t, "EOF", err.Error())
+			break
+		}
+	}
+
+	assert.Equal(t, 365, strings.Count(output, "<rect"))
+	assert.Contains(t, output, "2025-01-01: 2 entries")
+	assert.Contains(t, output, svgColors[Levels-1])
+}
+
+func TestMonthStarts(t *testing.T) {
+	g := Build(nil, 2025, Count)
+	starts := g.monthStarts()
+	assert.Equal(t, time.January, starts[0])
+
+	found := 0
+	for _, m := range starts {
+		if m != 0 {
+			found++
+		}
+	}
+	assert.Equal(t, 12, found)
+}
@@ -0,0 +1,157 @@
+package hooks
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/econron/wamon/internal/db"
+	"github.com/econron/wamon/internal/i18n"
+	"github.com/econron/wamon/internal/models"
+)
+
+// hookedDB runs the entry lifecycle hooks around the writes of a database
+type hookedDB struct {
+	db.DB
+	runner *Runner
+	warn   func(error)
+}
+
+// WrapDB returns a database that runs the hooks of runner when entries are saved,
+// updated or deleted. Failed post hooks are passed to warn, since the change has
+// already been made. Without any hooks the database is returned as it is.
+func WrapDB(database db.DB, runner *Runner, warn func(error)) db.DB {
+	if runner == nil || len(runner.config.Hooks) == 0 {
+		return database
+	}
+	return &hookedDB{DB: database, runner: runner, warn: warn}
+}
+
+// SaveEntry lets the pre-save hooks veto or change the entry before it is saved
+func (h *hookedDB) SaveEntry(entry *models.Entry) error {
+	return h.SaveEntries([]*models.Entry{entry})
+}
+
+// SaveEntries runs the pre-save hooks for every entry before saving any of them,
+// so a veto of one entry saves nothing. The post-save hooks run for each saved entry.
+func (h *hookedDB) SaveEntries(entries []*models.Entry) error {
+	if h.runner.Has(PreSave) {
+		changed := make([]models.Entry, len(entries))
+		for i, entry := range entries {
+			changed[i] = *entry
+			if err := h.runner.RunPre(PreSave, &changed[i], EntryEnv(entry)); err != nil {
+				return err
+			}
+			if err := validateChange(entry, &changed[i]); err != nil {
+				return &Error{Event: PreSave, Command: h.lastHook(PreSave), Message: err.Error()}
+			}
+		}
+		for i, entry := range entries {
+			*entry = changed[i]
+		}
+	}
+
+	if err := h.DB.SaveEntries(entries); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		h.post(PostSave, entry)
+	}
+	return nil
+}
+
+// UpdateEntry updates the entry and runs the post-update hooks
+func (h *hookedDB) UpdateEntry(entry *models.Entry) error {
+	return h.UpdateEntries([]*models.Entry{entry})
+}
+
+// UpdateEntries updates the entries and runs the post-update hooks for each of them
+func (h *hookedDB) UpdateEntries(entries []*models.Entry) error {
+	if err := h.DB.UpdateEntries(entries); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		h.post(PostUpdate, entry)
+	}
+	return nil
+}
+
+// DeleteEntry deletes the entry and runs the post-delete hooks with what was deleted
+func (h *hookedDB) DeleteEntry(id string) error {
+	var deleted *models.Entry
+	if h.runner.Has(PostDelete) {
+		// A missing entry is reported by the delete itself
+		deleted, _ = h.DB.GetEntryByID(id)
+	}
+
+	if err := h.DB.DeleteEntry(id); err != nil {
+		return err
+	}
+	if deleted != nil {
+		h.post(PostDelete, deleted)
+	}
+	return nil
+}
+
+// UndoOperation undoes the operation and runs the post hooks of what the undo did
+// to each entry: post-save for an entry brought back, post-update for an entry
+// reverted to its earlier state and post-delete for an entry removed. There is
+// nothing to veto, so no pre hooks run.
+func (h *hookedDB) UndoOperation(id int64) (*models.Operation, error) {
+	op, err := h.DB.UndoOperation(id)
+	if err != nil {
+		return nil, err
+	}
+	for _, reverted := range op.Reverted {
+		switch {
+		case reverted.Before == nil:
+			h.post(PostSave, reverted.After)
+		case reverted.After == nil:
+			h.post(PostDelete, reverted.Before)
+		default:
+			h.post(PostUpdate, reverted.After)
+		}
+	}
+	return op, nil
+}
+
+// post runs the hooks of a post event and reports their failures
+func (h *hookedDB) post(event Event, entry *models.Entry) {
+	for _, err := range h.runner.RunPost(event, entry, EntryEnv(entry)) {
+		if h.warn != nil {
+			h.warn(err)
+		}
+	}
+}
+
+// lastHook returns the command of the last hook of the event, which produced the final entry
+func (h *hookedDB) lastHook(event Event) string {
+	hooks := h.runner.config.Hooks[event]
+	return hooks[len(hooks)-1].Command
+}
+
+// EntryEnv returns the environment variables that describe entry to a hook
+func EntryEnv(entry *models.Entry) map[string]string {
+	return map[string]string{
+		"WAMON_ENTRY_ID":           entry.ID,
+		"WAMON_ENTRY_CATEGORY":     string(entry.Category),
+		"WAMON_ENTRY_SATISFACTION": strconv.Itoa(entry.Satisfaction),
+	}
+}
+
+// validateChange checks the fields pre-save hooks changed
+func validateChange(original, changed *models.Entry) error {
+	if changed.ID != original.ID {
+		return errors.New(i18n.T("hook.id_changed", original.ID, changed.ID))
+	}
+	if changed.Category != original.Category && !changed.Category.Valid() {
+		return errors.New(i18n.T("hook.invalid_category", changed.Category))
+	}
+	if changed.Satisfaction != original.Satisfaction && (changed.Satisfaction < 1 || changed.Satisfaction > 5) {
+		return errors.New(i18n.T("hook.invalid_satisfaction", changed.Satisfaction))
+	}
+	if changed.CreatedAt.IsZero() {
+		changed.CreatedAt = original.CreatedAt
+	}
+	changed.Tags = models.NormalizeTags(changed.Tags)
+	return nil
+}
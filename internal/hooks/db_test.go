@@ -0,0 +1,194 @@
+package hooks
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/econron/wamon/internal/db"
+	"github.com/econron/wamon/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupHookedDB opens a database in a temporary directory wrapped with the given hooks
+func setupHookedDB(t *testing.T, config Config) (db.DB, *[]error) {
+	database, err := db.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	assert.NoError(t, err)
+	t.Cleanup(func() { database.Close() })
+
+	var warnings []error
+	return WrapDB(database, NewRunner(config), func(err error) {
+		warnings = append(warnings, err)
+	}), &warnings
+}
+
+// newEntry creates an entry that is valid to save
+func newEntry(id string) *models.Entry {
+	return &models.Entry{ID: id, Category: models.Research, ResearchTopic: "Go", Satisfaction: 3, CreatedAt: time.Now()}
+}
+
+func TestWrapDBWithoutHooks(t *testing.T) {
+	database, err := db.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	assert.NoError(t, err)
+	defer database.Close()
+
+	assert.Equal(t, database, WrapDB(database, NewRunner(Config{}), nil))
+}
+
+func TestHookedSaveEntry(t *testing.T) {
+	dir := t.TempDir()
+	tag := writeScript(t, dir, "tag", `echo '{"tags":["Hooked"],"satisfaction":4}'`)
+	log := filepath.Join(dir, "log")
+	post := writeScript(t, dir, "post", `echo "$WAMON_HOOK $WAMON_ENTRY_ID $WAMON_ENTRY_SATISFACTION" >> `+log)
+	database, warnings := setupHookedDB(t, Config{Hooks: map[Event][]Hook{
+		PreSave:  {{Command: tag}},
+		PostSave: {{Command: post}},
+	}})
+
+	entry := newEntry("20250401120000")
+	assert.NoError(t, database.SaveEntry(entry))
+
+	// The change of the pre-save hook is saved, with the tags normalized
+	saved, err := database.GetEntryByID(entry.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"hooked"}, saved.Tags)
+	assert.Equal(t, 4, saved.Satisfaction)
+	assert.Equal(t, "Go", saved.ResearchTopic)
+
+	content, err := os.ReadFile(log)
+	assert.NoError(t, err)
+	assert.Equal(t, "post-save 20250401120000 4\n", string(content))
+	assert.Empty(t, *warnings)
+}
+
+func TestHookedSaveEntryVeto(t *testing.T) {
+	dir := t.TempDir()
+	reject := writeScript(t, dir, "reject", `echo "no weekends" >&2; exit 1`)
+	post := writeScript(t, dir, "post", `touch `+filepath.Join(dir, "ran"))
+	database, _ := setupHookedDB(t, Config{Hooks: map[Event][]Hook{
+		PreSave:  {{Command: reject}},
+		PostSave: {{Command: post}},
+	}})
+
+	err := database.SaveEntry(newEntry("20250401120000"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no weekends")
+
+	count, err := database.GetEntryCount()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+	assert.NoFileExists(t, filepath.Join(dir, "ran"))
+}
+
+func TestHookedSaveEntries(t *testing.T) {
+	dir := t.TempDir()
+	// Veto only the second entry
+	reject := writeScript(t, dir, "reject", `[ "$WAMON_ENTRY_ID" != "20250401130000" ] || exit 1`)
+	log := filepath.Join(dir, "log")
+	post := writeScript(t, dir, "post", `echo "$WAMON_ENTRY_ID" >> `+log)
+	database, _ := setupHookedDB(t, Config{Hooks: map[Event][]Hook{
+		PreSave:  {{Command: reject}},
+		PostSave: {{Command: post}},
+	}})
+
+	// A veto of one entry saves none of them
+	err := database.SaveEntries([]*models.Entry{newEntry("20250401120000"), newEntry("20250401130000")})
+	assert.Error(t, err)
+	count, err := database.GetEntryCount()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+	assert.NoFileExists(t, log)
+
+	// The post-save hooks run once for each saved entry
+	assert.NoError(t, database.SaveEntries([]*models.Entry{newEntry("20250401120000"), newEntry("20250401140000")}))
+	content, err := os.ReadFile(log)
+	assert.NoError(t, err)
+	assert.Equal(t, "20250401120000\n20250401140000\n", string(content))
+}
+
+func TestHookedSaveEntryInvalidChange(t *testing.T) {
+	testCases := []struct {
+		name   string
+		output string
+	}{
+		{name: "ID changed", output: `{"id":"other"}`},
+		{name: "Invalid category", output: `{"category":"sleeping"}`},
+		{name: "Invalid satisfaction", output: `{"satisfaction":9}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hook := writeScript(t, t.TempDir(), "change", `echo '`+tc.output+`'`)
+			database, _ := setupHookedDB(t, Config{Hooks: map[Event][]Hook{PreSave: {{Command: hook}}}})
+
+			entry := newEntry("20250401120000")
+			assert.Error(t, database.SaveEntry(entry))
+			// The entry is left as it was
+			assert.Equal(t, "20250401120000", entry.ID)
+			assert.Equal(t, 3, entry.Satisfaction)
+		})
+	}
+}
+
+func TestHookedUpdateAndDelete(t *testing.T) {
+	dir := t.TempDir()
+	log := filepath.Join(dir, "log")
+	record := writeScript(t, dir, "record", `echo "$WAMON_HOOK $WAMON_ENTRY_ID" >> `+log)
+	fail := writeScript(t, dir, "fail", `echo "server down" >&2; exit 1`)
+	database, warnings := setupHookedDB(t, Config{Hooks: map[Event][]Hook{
+		PostUpdate: {{Command: record}},
+		PostDelete: {{Command: record}, {Command: fail}},
+	}})
+
+	entries := []*models.Entry{newEntry("20250401120000"), newEntry("20250402120000")}
+	for _, entry := range entries {
+		assert.NoError(t, database.SaveEntry(entry))
+	}
+
+	entries[0].Satisfaction = 5
+	entries[1].Satisfaction = 5
+	assert.NoError(t, database.UpdateEntries(entries))
+	assert.NoError(t, database.UpdateEntry(entries[0]))
+
+	// A failed post hook is only a warning; the entry is still deleted
+	assert.NoError(t, database.DeleteEntry(entries[1].ID))
+	_, err := database.GetEntryByID(entries[1].ID)
+	assert.Error(t, err)
+	if assert.Len(t, *warnings, 1) {
+		assert.Contains(t, (*warnings)[0].Error(), "server down")
+	}
+
+	content, err := os.ReadFile(log)
+	assert.NoError(t, err)
+	assert.Equal(t, "post-update 20250401120000\npost-update 20250402120000\npost-update 20250401120000\npost-delete 20250402120000\n", string(content))
+}
+
+func TestHookedUndo(t *testing.T) {
+	dir := t.TempDir()
+	log := filepath.Join(dir, "log")
+	record := writeScript(t, dir, "record", `echo "$WAMON_HOOK $WAMON_ENTRY_ID $WAMON_ENTRY_SATISFACTION" >> `+log)
+	database, warnings := setupHookedDB(t, Config{Hooks: map[Event][]Hook{
+		PostSave:   {{Command: record}},
+		PostUpdate: {{Command: record}},
+		PostDelete: {{Command: record}},
+	}})
+
+	entry := newEntry("20250401120000")
+	assert.NoError(t, database.SaveEntry(entry))
+	entry.Satisfaction = 5
+	assert.NoError(t, database.UpdateEntry(entry))
+	assert.NoError(t, database.DeleteEntry(entry.ID))
+	assert.NoError(t, os.Remove(log))
+
+	// Undoing the delete, the update and the save runs the matching post hooks
+	for i := 0; i < 3; i++ {
+		_, err := database.UndoOperation(0)
+		assert.NoError(t, err)
+	}
+
+	content, err := os.ReadFile(log)
+	assert.NoError(t, err)
+	assert.Equal(t, "post-save 20250401120000 5\npost-update 20250401120000 3\npost-delete 20250401120000 3\n", string(content))
+	assert.Empty(t, *warnings)
+}
@@ -0,0 +1,208 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/econron/wamon/internal/i18n"
+	"github.com/econron/wamon/internal/models"
+)
+
+// Event is a point in the entry lifecycle hooks can run at
+type Event string
+
+const (
+	PreSave    Event = "pre-save"
+	PostSave   Event = "post-save"
+	PostUpdate Event = "post-update"
+	PostDelete Event = "post-delete"
+	PreReport  Event = "pre-report"
+)
+
+// Events lists every event in the order they are documented
+var Events = []Event{PreSave, PostSave, PostUpdate, PostDelete, PreReport}
+
+// DefaultTimeout is how long a hook may run when no timeout is configured
+const DefaultTimeout = 10 * time.Second
+
+// waitDelay is how long to wait for the output of a hook to close once it was stopped
+const waitDelay = 500 * time.Millisecond
+
+// Hook is an executable run on an event
+type Hook struct {
+	Command string
+	Args    []string
+	Timeout time.Duration // 0 means the timeout of the Config
+}
+
+// Config holds the hooks of each event
+type Config struct {
+	Timeout time.Duration // default timeout of every hook
+	Hooks   map[Event][]Hook
+}
+
+// Pre reports whether hooks of the event run before the action and can stop or change it
+func (e Event) Pre() bool {
+	return strings.HasPrefix(string(e), "pre-")
+}
+
+// ParseEvent returns the event called name
+func ParseEvent(name string) (Event, bool) {
+	for _, event := range Events {
+		if string(event) == name {
+			return event, true
+		}
+	}
+	return "", false
+}
+
+// Error is returned when a hook fails: it exited with a non-zero status,
+// timed out, could not be started or printed something that is not JSON.
+// For a pre event it means the action was vetoed.
+type Error struct {
+	Event   Event
+	Command string
+	Message string
+}
+
+// Error describes which hook failed and why
+func (e *Error) Error() string {
+	if e.Event.Pre() {
+		return i18n.T("hook.vetoed", e.Event, e.Command, e.Message)
+	}
+	return i18n.T("hook.failed", e.Event, e.Command, e.Message)
+}
+
+// Runner runs the configured hooks
+type Runner struct {
+	config Config
+	// Env is added to the environment of every hook, e.g. WAMON_DB_PATH
+	Env map[string]string
+}
+
+// NewRunner creates a runner for the configured hooks
+func NewRunner(config Config) *Runner {
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultTimeout
+	}
+	return &Runner{config: config, Env: make(map[string]string)}
+}
+
+// Has reports whether any hook is configured for the event
+func (r *Runner) Has(event Event) bool {
+	return r != nil && len(r.config.Hooks[event]) > 0
+}
+
+// RunPre runs the hooks of a pre event one after another. Each hook gets v as JSON
+// on stdin. A hook that prints JSON replaces v with it, so the next hook and the
+// action see the change; a hook that prints nothing leaves v as it is.
+// The first hook that fails stops the rest and vetoes the action.
+func (r *Runner) RunPre(event Event, v interface{}, env map[string]string) error {
+	if !r.Has(event) {
+		return nil
+	}
+	for _, hook := range r.config.Hooks[event] {
+		input, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		output, err := r.run(event, hook, input, env)
+		if err != nil {
+			return err
+		}
+		if len(bytes.TrimSpace(output)) == 0 {
+			continue
+		}
+		if err := json.Unmarshal(output, v); err != nil {
+			return &Error{Event: event, Command: hook.Command, Message: i18n.T("hook.invalid_output", err)}
+		}
+	}
+	return nil
+}
+
+// RunPost runs every hook of a post event with v as JSON on stdin.
+// The action has already happened, so failures are returned but don't stop the other hooks.
+func (r *Runner) RunPost(event Event, v interface{}, env map[string]string) []error {
+	if !r.Has(event) {
+		return nil
+	}
+	input, err := json.Marshal(v)
+	if err != nil {
+		return []error{err}
+	}
+	var errs []error
+	for _, hook := range r.config.Hooks[event] {
+		if _, err := r.run(event, hook, input, env); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// run executes one hook and returns what it printed on stdout
+func (r *Runner) run(event Event, hook Hook, input []byte, env map[string]string) ([]byte, error) {
+	timeout := hook.Timeout
+	if timeout <= 0 {
+		timeout = r.config.Timeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, expandHome(hook.Command), hook.Args...)
+	cmd.Stdin = bytes.NewReader(input)
+	// Don't wait for children of the hook that keep its output open after a timeout
+	cmd.WaitDelay = waitDelay
+	cmd.Env = append(os.Environ(), "WAMON_HOOK="+string(event))
+	for key, value := range r.Env {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+	for key, value := range env {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return nil, &Error{Event: event, Command: hook.Command, Message: i18n.T("hook.timeout", timeout)}
+	}
+	if err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return nil, &Error{Event: event, Command: hook.Command, Message: err.Error()}
+		}
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			message = i18n.T("hook.exit_status", exitErr.ExitCode())
+		}
+		return nil, &Error{Event: event, Command: hook.Command, Message: message}
+	}
+	return stdout.Bytes(), nil
+}
+
+// expandHome replaces a leading ~ with the home directory
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
+
+// Report is what the pre-report hooks receive; they can veto the report or
+// print a Report with the entries changed or filtered
+type Report struct {
+	Channel string          `json:"channel"`
+	Entries []*models.Entry `json:"entries"`
+}
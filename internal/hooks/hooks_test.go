@@ -0,0 +1,130 @@
+package hooks
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/econron/wamon/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeScript creates an executable shell script in dir and returns its path
+func writeScript(t *testing.T, dir, name, body string) string {
+	path := filepath.Join(dir, name)
+	assert.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+body+"\n"), 0755))
+	return path
+}
+
+func TestParseEvent(t *testing.T) {
+	for _, event := range Events {
+		parsed, ok := ParseEvent(string(event))
+		assert.True(t, ok)
+		assert.Equal(t, event, parsed)
+	}
+	_, ok := ParseEvent("post-report")
+	assert.False(t, ok)
+
+	assert.True(t, PreSave.Pre())
+	assert.True(t, PreReport.Pre())
+	assert.False(t, PostSave.Pre())
+}
+
+func TestRunPreMutates(t *testing.T) {
+	dir := t.TempDir()
+	// The first hook adds a tag, the second one sees it and raises the satisfaction
+	tag := writeScript(t, dir, "tag", `sed 's/"satisfaction"/"tags":["hooked"],"satisfaction"/'`)
+	raise := writeScript(t, dir, "raise", `grep -q hooked && echo '{"satisfaction":5}'`)
+	runner := NewRunner(Config{Hooks: map[Event][]Hook{PreSave: {{Command: tag}, {Command: raise}}}})
+
+	entry := &models.Entry{ID: "1", Category: models.Research, Satisfaction: 3, ResearchTopic: "Go"}
+	assert.NoError(t, runner.RunPre(PreSave, entry, nil))
+	assert.Equal(t, []string{"hooked"}, entry.Tags)
+	assert.Equal(t, 5, entry.Satisfaction)
+	// Fields the hook did not print are kept
+	assert.Equal(t, "Go", entry.ResearchTopic)
+}
+
+func TestRunPreVeto(t *testing.T) {
+	dir := t.TempDir()
+	reject := writeScript(t, dir, "reject", `echo "topic is required" >&2; exit 3`)
+	never := writeScript(t, dir, "never", `touch `+filepath.Join(dir, "ran"))
+	runner := NewRunner(Config{Hooks: map[Event][]Hook{PreSave: {{Command: reject}, {Command: never}}}})
+
+	err := runner.RunPre(PreSave, &models.Entry{ID: "1"}, nil)
+	var hookErr *Error
+	if assert.ErrorAs(t, err, &hookErr) {
+		assert.Equal(t, PreSave, hookErr.Event)
+		assert.Equal(t, reject, hookErr.Command)
+		assert.Equal(t, "topic is required", hookErr.Message)
+	}
+	assert.Contains(t, err.Error(), "topic is required")
+
+	// The hooks after the veto are not run
+	assert.NoFileExists(t, filepath.Join(dir, "ran"))
+}
+
+func TestRunPreInvalidOutput(t *testing.T) {
+	hook := writeScript(t, t.TempDir(), "bad", `echo "not json"`)
+	runner := NewRunner(Config{Hooks: map[Event][]Hook{PreSave: {{Command: hook}}}})
+
+	err := runner.RunPre(PreSave, &models.Entry{ID: "1"}, nil)
+	assert.Error(t, err)
+}
+
+func TestRunTimeout(t *testing.T) {
+	hook := writeScript(t, t.TempDir(), "slow", `sleep 5`)
+	runner := NewRunner(Config{
+		Timeout: time.Minute,
+		Hooks:   map[Event][]Hook{PreSave: {{Command: hook, Timeout: 100 * time.Millisecond}}},
+	})
+
+	start := time.Now()
+	err := runner.RunPre(PreSave, &models.Entry{ID: "1"}, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "100ms")
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestRunPost(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out")
+	record := writeScript(t, dir, "record", `cat > `+out+`; echo "$WAMON_HOOK $WAMON_ENTRY_ID $WAMON_DB_PATH" >> `+out)
+	fail := writeScript(t, dir, "fail", `exit 1`)
+	runner := NewRunner(Config{Hooks: map[Event][]Hook{PostSave: {{Command: fail}, {Command: record}}}})
+	runner.Env["WAMON_DB_PATH"] = "/tmp/wamon.db"
+
+	entry := &models.Entry{ID: "20250401120000", Category: models.Research}
+	errs := runner.RunPost(PostSave, entry, EntryEnv(entry))
+
+	// The failure is reported and the other hooks still run
+	if assert.Len(t, errs, 1) {
+		assert.Contains(t, errs[0].Error(), "1")
+	}
+	content, err := os.ReadFile(out)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), `"id":"20250401120000"`)
+	assert.Contains(t, string(content), "post-save 20250401120000 /tmp/wamon.db")
+}
+
+func TestRunMissingCommand(t *testing.T) {
+	runner := NewRunner(Config{Hooks: map[Event][]Hook{PostDelete: {{Command: "/nonexistent/hook"}}}})
+
+	errs := runner.RunPost(PostDelete, &models.Entry{ID: "1"}, nil)
+	assert.Len(t, errs, 1)
+}
+
+func TestNoHooks(t *testing.T) {
+	runner := NewRunner(Config{})
+	assert.False(t, runner.Has(PreSave))
+	assert.NoError(t, runner.RunPre(PreSave, &models.Entry{}, nil))
+	assert.Empty(t, runner.RunPost(PostSave, &models.Entry{}, nil))
+}
+
+func TestExpandHome(t *testing.T) {
+	t.Setenv("HOME", "/home/seal")
+	assert.Equal(t, "/home/seal/bin/hook", expandHome("~/bin/hook"))
+	assert.Equal(t, "/usr/bin/hook", expandHome("/usr/bin/hook"))
+	assert.Equal(t, "hook", expandHome("hook"))
+}
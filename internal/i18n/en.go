@@ -0,0 +1,607 @@
+package i18n
+
+// en is the English catalog
+var en = map[string]string{
+	"add.template_flag": "name of the template for the initial editor content (~/.wamon/templates/<name>.md)",
+
+	"app.title": "🦭 Ringed Seal Journal 🦭",
+
+	"art.load_warning": "⚠️ Could not load the art pack: %v",
+	"art.missing_mood": "Art pack %s has no frames for the %s mood",
+	"art.not_found":    "Art pack %s was not found (in %s or the built-in packs)",
+	"art.parse_error":  "Could not parse art pack %s: %v",
+	"art.read_error":   "Could not read art pack %s: %v",
+
+	"bulk.add_tag_flag":          "tag to add",
+	"bulk.change.add_tag":        "add tag: %s",
+	"bulk.change.category":       "category: %s → %s",
+	"bulk.change.program_title":  "program: %q → %q",
+	"bulk.change.remove_tag":     "remove tag: %s",
+	"bulk.change.research_topic": "researched: %q → %q",
+	"bulk.change.satisfaction":   "satisfaction: %d → %d",
+	"bulk.column.changes":        "Changes",
+	"bulk.column.date":           "Date",
+	"bulk.dry_run":               "(dry run) %d entries would be changed. Run without --dry-run to save.",
+	"bulk.dry_run_flag":          "show the changes without saving them",
+	"bulk.filter_flag":           "filter selecting the entries to change",
+	"bulk.filter_required":       "Specify the entries to change with --filter",
+	"bulk.invalid_satisfaction":  "satisfaction must be a number from 1 to 5: %s",
+	"bulk.invalid_set":           "--set must be written as field=value: %s",
+	"bulk.no_changes":            "specify at least one of --set, --add-tag or --remove-tag",
+	"bulk.no_match":              "No entries match: %s",
+	"bulk.nothing_to_change":     "%d entries match, but none of them need changes",
+	"bulk.remove_tag_flag":       "tag to remove",
+	"bulk.set_flag":              "field to change (field=value; category, satisfaction, research_topic, program_title)",
+	"bulk.unknown_field":         "cannot change field: %s (%s)",
+	"bulk.updated":               "Updated %d entries!",
+
+	"category.programming":              "Programming",
+	"category.research":                 "Research",
+	"category.research_and_programming": "Research & Programming",
+
+	"cmd.add.long": `Records a new entry, the same as running wamon without arguments.
+The initial editor content comes from ~/.wamon/templates/<category>.md when it exists.
+With --template, ~/.wamon/templates/<name>.md is used instead.
+
+Examples:
+  wamon add
+  wamon add --template standup`,
+	"cmd.add.short": "Record a new entry",
+	"cmd.bulk.long": `Applies the --set, --add-tag and --remove-tag changes to every entry matching --filter.
+The changes are saved in a single transaction and the previous state of each entry is kept as a revision.
+With --dry-run only the list of changes is shown.
+
+Filter syntax (entries must match every term):
+  category:research     category (or cat:)
+  tag:go                tag
+  since:2025-04-01      on or after the date
+  until:2025-04-30      up to and including the date
+  satisfaction:<3       satisfaction (=, <, <=, >, >=; or sat:)
+  id:202504             ID prefix
+  sqlite                text in the ID, content or tags (use "..." to include spaces)
+
+Examples:
+  wamon bulk --filter 'cat:research since:2025-04-01' --set category=programming --dry-run
+  wamon bulk --filter 'tag:golang' --add-tag go --remove-tag golang`,
+	"cmd.bulk.short":            "Change every entry that matches a filter",
+	"cmd.completion.long":       "Prints completion scripts for bash, zsh, fish and PowerShell.\n`wamon completion install` installs the script to the standard location of your shell.\n\nEntry IDs, categories and tags are completed from the database.\n\nExamples:\n  $ wamon completion install\n  $ wamon completion bash > /etc/bash_completion.d/wamon\n  $ source <(wamon completion zsh)",
+	"cmd.completion.short":      "Generate shell completion scripts",
+	"cmd.completion_bash.long":  "Prints the completion script for bash to standard output.",
+	"cmd.completion_bash.short": "Print the completion script for bash",
+	"cmd.completion_fish.long":  "Prints the completion script for fish to standard output.",
+	"cmd.completion_fish.short": "Print the completion script for fish",
+	"cmd.completion_install.long": `Writes the completion script where your shell loads it automatically.
+Without a shell argument, the shell is taken from the SHELL environment variable.
+
+  bash: ~/.local/share/bash-completion/completions/wamon
+  zsh:  ~/.zfunc/_wamon
+  fish: ~/.config/fish/completions/wamon.fish`,
+	"cmd.completion_install.short":    "Install the completion script",
+	"cmd.completion_powershell.long":  "Prints the completion script for PowerShell to standard output.",
+	"cmd.completion_powershell.short": "Print the completion script for PowerShell",
+	"cmd.completion_zsh.long":         "Prints the completion script for zsh to standard output.",
+	"cmd.completion_zsh.short":        "Print the completion script for zsh",
+	"cmd.config.long": `Shows and changes the values of the config file.
+Both YAML (.wamon.yaml) and TOML (.wamon.toml) config files are supported.
+Values are checked before they are saved; unknown keys and invalid values are errors.
+
+Examples:
+  $ wamon config list
+  $ wamon config set slack.channel times-wamon
+  $ wamon config set streak.non_working_days sat,sun
+  $ wamon config unset art.pack
+  $ wamon config edit`,
+	"cmd.config.short":      "Show and change the configuration",
+	"cmd.config_edit.long":  "Opens the config file in an editor. The content is checked when it is saved, and the editor is reopened while there are problems.",
+	"cmd.config_edit.short": "Edit the config file in an editor",
+	"cmd.config_get.long":   "Prints the value of a key, or its default when it is not set.",
+	"cmd.config_get.short":  "Show a config value",
+	"cmd.config_list.long":  "Prints every key that can be set with its current value.",
+	"cmd.config_list.short": "Show every config value",
+	"cmd.config_migrate_secrets.long": `Moves the slack.token written in the config file to a file only its owner can read
+(~/.wamon/slack-token, mode 600) and points slack.token_file to it.`,
+	"cmd.config_migrate_secrets.short": "Move tokens out of the config file",
+	"cmd.config_path.long": `Prints the path of the config file in use, or where it will be created.
+The config file is taken from --config, the WAMON_CONFIG environment variable,
+$XDG_CONFIG_HOME/wamon/config.yaml, ~/.wamon/.wamon.yaml, ~/.wamon.yaml, then $XDG_CONFIG_DIRS/wamon/config.yaml.
+With --explain, it lists the paths searched and where each value comes from (flag, environment variable, config file or default).`,
+	"cmd.config_path.short":  "Show the path of the config file",
+	"cmd.config_set.long":    "Checks the value of a key and saves it to the config file. Lists are separated by commas.",
+	"cmd.config_set.short":   "Change a config value",
+	"cmd.config_unset.long":  "Removes a key from the config file, going back to its default.",
+	"cmd.config_unset.short": "Remove a config value",
+	"cmd.edit.long": `Edits the entry with the given ID.
+An editor opens; edit the content and save it.`,
+	"cmd.edit.short": "Edit an existing entry",
+	"cmd.export.long": `Exports every recorded entry as JSON.
+The file contains one JSON object per line.
+Without a file name, the entries are saved to wamon_export.json in the current directory.
+
+Examples:
+  $ wamon export
+  $ wamon export my_records.json
+  $ wamon export --since 24h
+  $ wamon export my_records.json --since 168h`,
+	"cmd.export.short": "Export all entries as JSON",
+	"cmd.heatmap.long": `Shows a year of entries as a calendar, like the GitHub contribution graph.
+When NO_COLOR is set or the output is not a terminal, shade blocks are used instead of colors.
+
+Examples:
+  $ wamon heatmap
+  $ wamon heatmap --year 2025 --metric satisfaction
+  $ wamon heatmap -c research
+  $ wamon heatmap --svg heatmap.svg`,
+	"cmd.heatmap.short": "Show a heatmap of your entries",
+	"cmd.import.long": `Imports entries from an exported JSON file.
+Entries whose ID already exists are skipped.
+
+Example:
+  $ wamon import wamon_backup.json`,
+	"cmd.import.short": "Import entries from a JSON file",
+	"cmd.list.long":    "Lists the entries recorded so far. The list can be filtered by category.",
+	"cmd.list.short":   "Show past entries",
+	"cmd.report.long": `Sends the entries of last week to Slack, or those of last month, last quarter
+or any range of days with --period. By default this is the last full calendar week
+(Monday to Sunday), not the last 7 days; use --current for this week or --since for the last 7 days.
+Weeks start on Monday unless report.week_start says otherwise.
+Slack is configured in ~/.wamon.yaml.`,
+	"cmd.report.short": "Send the entries of last week or another period to Slack",
+	"cmd.root.long": `A CLI tool for recording your daily activities with a ringed seal.
+Record what you researched and the programs you wrote, and let the ringed seal cheer you on!`,
+	"cmd.root.short": "A CLI tool for recording your daily activities with a ringed seal",
+	"cmd.set_db.long": `Saves the current database path to the configuration file.
+You no longer need to pass the --db option every time.`,
+	"cmd.set_db.short": "Save the current database path to the configuration",
+	"cmd.show.long": `Shows the entry with the given ID together with its created and updated times and revision count.
+Other entries of the same day and similar entries are shown as well.
+
+Examples:
+  $ wamon show 20250401093000
+  $ wamon show 20250401093000 --output json`,
+	"cmd.show.short": "Show the details of an entry",
+	"cmd.stats.long": `Shows, for the given period, the number of entries per category, the mean and median satisfaction,
+the most satisfying entries, trends by hour and weekday, and your streak.
+
+Examples:
+  $ wamon stats
+  $ wamon stats --since 7d
+  $ wamon stats --since 2025-04-01 --output json`,
+	"cmd.stats.short": "Show statistics of your entries",
+	"cmd.tui.long": `Shows the entry list, details and statistics full-screen.
+You can search, filter by category, and add, edit or delete entries.
+
+Keys:
+  /        incremental search
+  c        cycle category
+  a        add an entry
+  e, Enter edit the selected entry
+  d        delete the selected entry
+  r        reload
+  q, ESC   quit`,
+	"cmd.tui.short": "Browse and edit entries full-screen",
+	"cmd.undo.long": `Undoes adding, editing, bulk changes, imports and deletions.
+Without an argument the most recent operation that has not been undone is reverted.
+With an operation ID that operation is reverted (see wamon undo --list).
+An import is undone as a whole batch.
+
+Examples:
+  wamon undo
+  wamon undo --list
+  wamon undo 12`,
+	"cmd.undo.short": "Undo the last operation",
+
+	"completion.hint.bash": "Open a new shell to enable it (requires bash-completion).",
+	"completion.hint.fish": "Open a new shell to enable it.",
+	"completion.hint.zsh": `Add these lines to ~/.zshrc if they are not there yet:
+  fpath=(~/.zfunc $fpath)
+  autoload -U compinit && compinit`,
+	"completion.home_error": "Failed to find the home directory: %v",
+	"completion.installed":  "Installed the %s completion script to %s",
+	"completion.unsupported_shell": `Unsupported shell: %s
+Supported shells: bash, zsh, fish`,
+	"completion.write_error": "Failed to write the completion script: %v",
+
+	"config.default_mark":                  "(default)",
+	"config.edit_cancelled":                "The content is empty, so the config file was not changed.",
+	"config.edit_retry":                    "The config has %d problem(s). Reopening the editor...",
+	"config.edited":                        "Saved the config file: %s",
+	"config.empty_value":                   "The value is empty",
+	"config.explain_file":                  "Config file: %s (%s)",
+	"config.explain_searched":              "Searched, in order:",
+	"config.explain_values":                "Values:",
+	"config.field.art.animate":             "Animate the art in a terminal",
+	"config.field.art.pack":                "Seal art pack (seal, tiny, a name in ~/.wamon/art, or the path of a YAML file)",
+	"config.field.art.plain":               "Print only the message, without art",
+	"config.field.database.path":           "Path of the database file",
+	"config.field.hooks":                   "Commands run before and after entries are saved, updated or deleted and reports are sent (a list per event)",
+	"config.field.lang":                    "Display language (ja or en). When omitted, it is taken from LANG and related variables",
+	"config.field.report.week_start":       "First day of the week in reports (mon, sun, ...)",
+	"config.field.slack.channel":           "Slack channel the weekly report is sent to",
+	"config.field.slack.enabled":           "Enable sending to Slack",
+	"config.field.slack.token":             "Slack bot token used to send the weekly report (starts with xoxb-)",
+	"config.field.slack.token_command":     "Command printing the Slack token, such as 'pass show slack/wamon'. It only runs when a report is sent",
+	"config.field.slack.token_file":        "File holding only the Slack token (must be readable by its owner only, mode 600)",
+	"config.field.streak.holidays":         "Built-in holiday list (jp)",
+	"config.field.streak.holidays_file":    "Holiday file with one 'YYYY-MM-DD name' per line",
+	"config.field.streak.non_working_days": "Weekdays off that don't break a streak (sun, mon, ...)",
+	"config.invalid_bool":                  "%s must be true or false: %v",
+	"config.invalid_language":              "Unsupported language: %v (supported: %s)",
+	"config.invalid_list":                  "%s must be a list: %v",
+	"config.invalid_section":               "%s must be a section of keys and values",
+	"config.invalid_slack_channel":         "Invalid channel name: '%s'",
+	"config.invalid_slack_token":           "Slack tokens start with one of %s",
+	"config.invalid_string":                "%s must be a string: %v",
+	"config.migrated":                      "Moved slack.token to %s (mode 600) and set slack.token_file in %s",
+	"config.not_set":                       "%s is not set.",
+	"config.nothing_to_migrate":            "%s has no token written in it",
+	"config.origin.env":                    "WAMON_CONFIG environment variable",
+	"config.origin.flag":                   "--config flag",
+	"config.origin.legacy":                 "legacy location",
+	"config.origin.not_found":              "not found; it will be created here",
+	"config.origin.xdg_dirs":               "XDG_CONFIG_DIRS",
+	"config.origin.xdg_home":               "XDG_CONFIG_HOME",
+	"config.parse_error":                   "Cannot read the config file %s: %v",
+	"config.path_is_directory":             "This is a directory, not a file: %s",
+	"config.path_not_found":                "File not found: %s",
+	"config.secret_file_exists":            "%s already holds another token. Check it and remove it first",
+	"config.secret_permissions":            "%s can be read by other users (mode %v). Run chmod 600 %s",
+	"config.section_not_settable":          "%s cannot be set from the command line. Use wamon config edit to change the file.",
+	"config.set":                           "Set %s to %s (%s)",
+	"config.source.default":                "default",
+	"config.source.file":                   "config file",
+	"config.source.unset":                  "not set",
+	"config.token_command_failed":          "slack.token_command failed: %v",
+	"config.token_empty":                   "The token read from %s is empty",
+	"config.unknown_key":                   "Unknown config key: %s (see wamon config list for the keys)",
+	"config.unset":                         "Removed %s (%s)",
+	"config.write_error":                   "Cannot save the config file: %v",
+
+	"edit.title": "🦭 Updated entry 🦭",
+	"edit.update_error": `Failed to update the data: %v
+The changes could not be saved. Please try again.`,
+	"edit.updated": "Entry updated!",
+
+	"editor.cancelled":            "the content was empty, so editing was cancelled",
+	"editor.category":             "Category: %s",
+	"editor.category_label":       "Category:",
+	"editor.date":                 "Date: %s",
+	"editor.date_label":           "Date:",
+	"editor.error":                "Error: %s",
+	"editor.error_header":         "There are errors in the content. Fix the line below each error and save. Delete everything and save to cancel.",
+	"editor.invalid_category":     "unknown category: %s (use one of %s)",
+	"editor.invalid_date":         "cannot read the date: %s (e.g. 2025-04-01 09:30)",
+	"editor.invalid_satisfaction": "satisfaction must be a number from 1 to 5: %s",
+	"editor.launch_failed":        "Failed to launch the editor. Please check that '%s' is available on this system.",
+	"editor.not_found":            "no editor available",
+	"editor.not_found_hint": `Warning: no standard editor was found on this system.
+Installing vim or vi is recommended.
+Alternatively, set the EDITOR environment variable.`,
+	"editor.program_title":         "Program written:",
+	"editor.read_back_error":       "failed to read the edited file: %v",
+	"editor.read_back_lost":        "Failed to read the edited file. Your changes may have been lost.",
+	"editor.research_topic":        "Researched:",
+	"editor.retry":                 "Found %d error(s) in the content. Reopening the editor...",
+	"editor.run_error":             "failed to run the editor: %v",
+	"editor.satisfaction":          "Satisfaction: %d/5",
+	"editor.satisfaction_label":    "Satisfaction:",
+	"editor.tags":                  "Tags:",
+	"editor.tempfile_create_error": "failed to create a temporary file: %v",
+	"editor.tempfile_write_error":  "failed to write the temporary file: %v",
+	"editor.using":                 "Editing with '%s'...",
+	"editor.vim_help": `How to use vim/vi:
+ESC: leave insert mode
+:q: quit without saving
+:wq: save and quit
+:x: save and quit
+:w: save`,
+	"editor.vim_missing_hint": `If vim/vi is not installed, try another editor.
+Example: export EDITOR=nano`,
+
+	"entry.category":       "Category: %s",
+	"entry.id_with_date":   "Entry ID: %s [%s]",
+	"entry.program_title":  "Program written: %s",
+	"entry.research_topic": "Researched: %s",
+	"entry.satisfaction":   "Satisfaction: %d/5",
+	"entry.tags":           "Tags: %s",
+
+	"error.calendar": `Failed to load the holiday settings: %v
+Every day is treated as a working day.`,
+	"error.config_load": `Failed to load the configuration: %v
+Please try again.`,
+	"error.config_save": `Failed to save the configuration: %v
+Please try again.`,
+	"error.count": "Failed to fetch data: %v",
+	"error.db_init": `Failed to initialize the database: %v
+Please check the database path: %s`,
+	"error.edit": `Edit error: %v
+Editing was cancelled.`,
+	"error.edit_short": "edit error: %v",
+	"error.entry_not_found": `No entry with ID %s was found.
+Please specify a valid ID.`,
+	"error.fetch": `Failed to fetch data: %v
+Please try again.`,
+	"error.input": `Input error: %v
+Please try again.`,
+	"error.invalid_category": "Invalid category. Valid categories: %s",
+	"error.invalid_filter":   "Invalid filter: %v",
+	"error.invalid_output": `Invalid output format: %s
+Valid formats: text, json`,
+	"error.invalid_since": "Invalid period: %v",
+	"error.json":          "JSON conversion error: %v",
+	"error.save": `Failed to save data: %v
+Please try again.`,
+	"error.streak":               "Failed to calculate the streak: %v",
+	"error.unsupported_language": "Unsupported language: %s (supported: %s)",
+
+	"export.count_error": "Failed to count the entries: %v",
+	"export.done":        "Exported %d entries to %s",
+	"export.error":       "Export error: %v",
+	"export.file":        "File: %s/%s",
+	"export.since_flag":  "only export the entries of the given period (e.g. 24h, 168h)",
+
+	"feedback.invalid_trend": "Invalid trend in message pack %s, rule %s: %s (use up or down)",
+	"feedback.load_warning":  "⚠️ Could not load message packs: %v",
+	"feedback.missing_id":    "Message pack %s: rule %d has no id",
+	"feedback.no_messages":   "Message pack %s: rule %s has no messages",
+	"feedback.parse_error":   "Could not parse message pack %s: %v",
+	"feedback.read_error":    "Could not read message pack %s: %v",
+
+	"flag.category":   "filter by category (research, programming, both)",
+	"flag.config":     "config file (searched in WAMON_CONFIG, ~/.config/wamon/config.yaml, then ~/.wamon/.wamon.yaml)",
+	"flag.db":         "database file path (defaults to WAMON_DB_PATH, database.path in the config file, then ~/.wamon/wamon.db)",
+	"flag.debug":      "same as --verbose",
+	"flag.explain":    "show how the config file was found and where each value comes from",
+	"flag.lang":       "display language (ja, en)",
+	"flag.log_file":   "file to write logs to (rotated every 5 MB, keeping 3 old files)",
+	"flag.log_format": "log format (text, json)",
+	"flag.log_level":  "log level (debug, info, warn, error)",
+	"flag.multi":      "Record several entries in one session",
+	"flag.no_art":     "Show only the seal's message without art (for scripts and screen readers)",
+	"flag.output":     "output format (text, json)",
+	"flag.script":     "Read the answers to the prompts from a file (for automated runs)",
+	"flag.tag":        "filter by tag",
+	"flag.verbose":    "print detailed logs (SQL timings, config sources, Slack API calls) to stderr",
+
+	"heatmap.create_error":   "Failed to create the file: %v",
+	"heatmap.invalid_metric": "Unknown metric: %s (count, satisfaction)",
+	"heatmap.legend_less":    "Less",
+	"heatmap.legend_more":    "More",
+	"heatmap.metric_flag":    "metric to show (count, satisfaction)",
+	"heatmap.saved":          "Saved the heatmap to %s",
+	"heatmap.svg_error":      "Failed to write the SVG: %v",
+	"heatmap.svg_flag":       "save the heatmap as an SVG file",
+	"heatmap.title":          "🦭 Entries in %d 🦭",
+	"heatmap.year_flag":      "year to show (default is this year)",
+
+	"hook.config_invalid":         "hooks.%s must be a list of hooks",
+	"hook.config_invalid_hook":    "hooks.%s: hook %d is invalid: %v",
+	"hook.config_invalid_timeout": "Invalid timeout for %s: %v (e.g. 5s)",
+	"hook.config_missing_command": "command is missing",
+	"hook.config_unknown_event":   "Unknown hook event: %s (use pre-save, post-save, post-update, post-delete or pre-report)",
+	"hook.exit_status":            "exited with status %d",
+	"hook.failed":                 "The %s hook %s failed: %s",
+	"hook.id_changed":             "the ID cannot be changed (%s → %s)",
+	"hook.invalid_category":       "invalid category: %s",
+	"hook.invalid_output":         "its output is not valid JSON: %v",
+	"hook.invalid_satisfaction":   "satisfaction must be 1-5: %d",
+	"hook.timeout":                "timed out after %v",
+	"hook.vetoed":                 "Stopped by the %s hook %s: %s",
+	"hook.warning":                "⚠️ %v",
+
+	"import.done":        "Imported %d entries",
+	"import.error":       "Import error: %v",
+	"import.total":       "The database now contains %d entries",
+	"import.total_error": "Failed to count the entries in the database: %v",
+
+	"journal.add_another":         "Add another entry? (y/N)",
+	"journal.back_to_category":    "The content was emptied, so let's choose the category again.",
+	"journal.cancelled":           "Recording cancelled. See you!",
+	"journal.confirm_save":        "Save these %d entries? (Y/n)",
+	"journal.count":               "Entries so far: %d",
+	"journal.draft_error":         "Could not handle the draft: %v",
+	"journal.draft_found":         "There is a draft saved at %s (%s). Continue from it? (Y/n)",
+	"journal.draft_found_entries": "There is a draft saved at %s with %d entries. Continue from it? (Y/n)",
+	"journal.draft_kept": `What you wrote is kept as a draft: %s
+You can continue from it next time.`,
+	"journal.draft_restored": "Restored %d entries from the draft",
+	"journal.first_step":     "This is the first step; there is nothing to go back to.",
+	"journal.intro": `Let's record today's activity!
+Type 'quit' to stop at any time, or 'back' to return to the previous question.`,
+	"journal.saved":      "Recorded!",
+	"journal.saved_many": "Recorded %d entries!",
+	"journal.streak":     "Streak: %d days (longest %d days)",
+	"journal.summary":    "Entries to save (%d):",
+
+	"list.empty":        "No entries.",
+	"list.entry_header": "Entry #%d [ID: %s] [%s]",
+	"list.total":        "Total: %d entries",
+
+	"log.invalid_format": "Unknown log format: %s (use text or json)",
+	"log.invalid_level":  "Unknown log level: %s (use debug, info, warn or error)",
+	"log.setup_error":    "Failed to set up logging: %v",
+
+	"operation.delete": "delete",
+	"operation.import": "import",
+	"operation.save":   "add",
+	"operation.update": "edit",
+
+	"prompt.ask_program_title":    "What program did you write?",
+	"prompt.ask_research_topic":   "What did you research?",
+	"prompt.ask_satisfaction":     "Rate your satisfaction from 1 to 5 (5 is best):",
+	"prompt.choose_category":      "Choose a category:",
+	"prompt.edit_start":           "Starting to edit the entry...",
+	"prompt.end_of_input":         "input ended (the script may be missing answers)",
+	"prompt.invalid_category":     "Invalid choice. Enter 1, 2 or 3.",
+	"prompt.invalid_satisfaction": "satisfaction must be a number from 1 to 5",
+	"prompt.invalid_yes_no":       "Please answer y or n.",
+	"prompt.not_a_terminal":       "Standard input is not a terminal, so the questions cannot be answered. Run wamon from a terminal or pass the answers with --script <file>.",
+	"prompt.read_error":           "Failed to read input: %v",
+	"prompt.script_unterminated":  "the editor content in the script is not ended by a '%s' line",
+
+	"query.invalid_value":  "invalid value for %s: %s",
+	"query.unclosed_quote": "unclosed quote: %s",
+	"query.unknown_field":  "unknown field: %s (%s)",
+
+	"report.ask_channel": "Enter the Slack channel to post to (e.g. general):",
+	"report.ask_token":   "Enter your Slack Bot User OAuth Token (starts with xoxb-):",
+	"report.config_save_error": `Failed to save the configuration: %v
+The configuration could not be saved, but the report will still be sent.`,
+	"report.current_flag":           "Send the week, month or quarter in progress instead of the last complete one",
+	"report.date_layout":            "Jan 2, 2006",
+	"report.dry_run_flag":           "Print the message instead of sending it to Slack (no token needed)",
+	"report.empty":                  "No entries for %s.",
+	"report.format_flag":            "Output format of --dry-run (blocks-json, markdown, text)",
+	"report.format_without_dry_run": "--format can only be used with --dry-run",
+	"report.invalid_date":           "Invalid date for %s: %s (use YYYY-MM-DD)",
+	"report.invalid_format":         "Unknown format: %s (use %s)",
+	"report.invalid_period":         "Unknown period: %s (use week, month, quarter or custom)",
+	"report.invalid_token":          "Invalid token. Enter a Bot User OAuth Token (starts with xoxb-).",
+	"report.month_layout":           "January 2006",
+	"report.period.quarter":         "%d Q%d",
+	"report.period.range":           "%s – %s",
+	"report.period_conflict":        "--since and --until can only be used with --period custom",
+	"report.period_flag":            "Period of the report (week, month, quarter, custom); defaults to the last calendar week",
+	"report.send_error": `Failed to send to Slack: %v
+Please try again.`,
+	"report.sent":           "Sent the entries for %s (%d) to Slack channel #%s!",
+	"report.since_flag":     "First day of the period (YYYY-MM-DD, --period custom)",
+	"report.since_required": "--period custom needs --since",
+	"report.streak_error": `Failed to calculate the streak: %v
+Sending the report without the streak.`,
+	"report.time_of_day.afternoon": "afternoon",
+	"report.time_of_day.evening":   "evening",
+	"report.time_of_day.morning":   "morning",
+	"report.time_of_day.night":     "night",
+	"report.token_error":           "Failed to read the Slack token: %v",
+	"report.token_saved":           "Saved the token to %s (mode 600)",
+	"report.until_before_since":    "--until (%s) is before --since (%s)",
+	"report.until_flag":            "Last day of the period (YYYY-MM-DD, --period custom, today by default)",
+	"report.week_start_error":      "Invalid report.week_start: %v",
+
+	"script.read_error": "Could not read script %s: %v",
+
+	"set_db.saved": `Saved the database path %s to the configuration.
+This path will be used from now on without the --db option.`,
+
+	"show.created_at":     "Created: %s",
+	"show.id":             "Entry ID: %s",
+	"show.none":           "  none",
+	"show.revision_count": "Revisions: %d",
+	"show.same_day":       "Entries on the same day:",
+	"show.similar":        "Similar entries:",
+	"show.similar_flag":   "maximum number of similar entries to show",
+	"show.title":          "🦭 Entry details 🦭",
+	"show.updated_at":     "Updated: %s",
+
+	"slack.best":                 "*🌟 Best-rated entries*",
+	"slack.busiest_day":          "📅 Busiest day: *%s* (%d entries)",
+	"slack.by_category":          "*By category*",
+	"slack.category":             "*Category:* %s",
+	"slack.category_count":       "%s: *%d*",
+	"slack.compare":              "📈 Compared with %s: entries %d → %d (%+d)",
+	"slack.compare_satisfaction": "　　average satisfaction %.1f → %.1f (%+.1f)",
+	"slack.days_omitted":         "(%d earlier days are not shown)",
+	"slack.header":               "🦭 Ringed seal journal %s 🦭",
+	"slack.overview":             "📝 *%d entries* on %d days · average satisfaction *%.1f*",
+	"slack.program_title":        "*Program written:* %s",
+	"slack.research_topic":       "*Researched:* %s",
+	"slack.satisfaction":         "*Satisfaction:* %s",
+	"slack.streak":               "🔥 Streak: current *%d days* / longest *%d days*",
+	"slack.summary":              "For %s you recorded *%d entries*. Keep it up!",
+	"slack.time_of_day_count":    "%s %d",
+	"slack.times_of_day":         "🕒 Time of day: %s",
+
+	"stats.by_category":          "By category:",
+	"stats.by_hour":              "By hour:",
+	"stats.by_weekday":           "By weekday:",
+	"stats.empty":                "No entries in this period.",
+	"stats.holidays_ended":       "Warning: the built-in holiday list (%s) ends in %d. Later holidays are not treated as days off for streaks; add them with streak.holidays_file",
+	"stats.hour":                 "%02d:00",
+	"stats.invalid_holiday_date": "Invalid date on line %d of the holiday list: %s",
+	"stats.invalid_weekday":      "Unknown weekday: %s",
+	"stats.period":               "Period: %s - %s",
+	"stats.satisfaction":         "Satisfaction: mean %.1f / median %.1f",
+	"stats.since_example":        "%q (e.g. 30d, 2w, 168h, 2025-04-01)",
+	"stats.since_flag":           "period to summarize (e.g. 30d, 2w, 168h, 2025-04-01)",
+	"stats.streak":               "Streak: current %d days / longest %d days",
+	"stats.title":                "🦭 Ringed Seal Statistics 🦭",
+	"stats.top_entries":          "Most satisfying entries:",
+	"stats.top_flag":             "number of most satisfying entries to show",
+	"stats.total":                "Entries: %d (active days: %d)",
+	"stats.unknown_holidays":     "No built-in holiday list named %s",
+
+	"template.not_found":    "template '%s' not found in %s",
+	"template.parse_error":  "failed to parse template: %v",
+	"template.read_error":   "failed to read template '%s': %v",
+	"template.render_error": "failed to render template: %v",
+
+	"tui.add_title": " Add entry ",
+	"tui.cancel":    "Cancel",
+	"tui.confirm_delete": `Delete entry %s?
+%s`,
+	"tui.delete":                "Delete",
+	"tui.delete_error":          "Delete error: %v",
+	"tui.deleted":               "Deleted entry %s",
+	"tui.detail":                " Details ",
+	"tui.detail.category":       "[green]Category:[-] %s",
+	"tui.detail.created_at":     "[green]Date:[-] %s",
+	"tui.detail.id":             "[green]Entry ID:[-] %s",
+	"tui.detail.program_title":  "[green]Program written:[-]",
+	"tui.detail.research_topic": "[green]Researched:[-]",
+	"tui.detail.satisfaction":   "[green]Satisfaction:[-] %d/5",
+	"tui.detail.tags":           "[green]Tags:[-] %s",
+	"tui.detail.updated_at":     "[green]Updated:[-] %s",
+	"tui.edit_title":            " Edit entry %s ",
+	"tui.empty_body":            "the entry is empty",
+	"tui.entries":               " Entries ",
+	"tui.entries_filtered":      " Entries [%s] ",
+	"tui.error":                 "Display error: %v",
+	"tui.form.category":         "Category",
+	"tui.form.program_title":    "Program written",
+	"tui.form.research_topic":   "Researched",
+	"tui.form.satisfaction":     "Satisfaction",
+	"tui.form.tags":             "Tags",
+	"tui.help":                  " /: search | c: category | a: add | e/Enter: edit | d: delete | r: reload | q: quit",
+	"tui.reload_error":          "Reload error: %v",
+	"tui.save":                  "Save",
+	"tui.save_error":            "Save error: %v",
+	"tui.saved":                 "Saved entry %s",
+	"tui.search":                "Search: ",
+	"tui.sidebar.longest":       "Longest: %d days",
+	"tui.sidebar.mean":          "Mean satisfaction: %.1f",
+	"tui.sidebar.streak":        "Streak: %d days",
+	"tui.sidebar.total":         "Entries: %d",
+	"tui.stats":                 " Stats ",
+
+	"undo.already_undone": "Operation #%d has already been undone.",
+	"undo.conflict":       "Operation #%d cannot be undone yet: operation #%d changed entry %s afterwards. Run wamon undo %d first.",
+	"undo.done":           "Undid operation #%d (%s, %d entries)!",
+	"undo.entries":        "Entries: %s",
+	"undo.error":          "Failed to undo: %v",
+	"undo.invalid_id":     "Invalid operation ID: %s",
+	"undo.limit_flag":     "number of operations to list",
+	"undo.list_empty":     "No operations have been recorded.",
+	"undo.list_flag":      "list the operations that can be undone",
+	"undo.list_hint":      "Run wamon undo [OPERATION_ID] to undo one.",
+	"undo.list_title":     "🦭 Operation History 🦭",
+	"undo.more_entries":   "%s and %d more",
+	"undo.not_found":      "Operation #%d was not found. Check wamon undo --list.",
+	"undo.nothing":        "There is nothing to undo.",
+	"undo.summary":        "%s, %d entries",
+	"undo.undone_mark":    "(undone)",
+
+	"weekday.long.0":  "Sunday",
+	"weekday.long.1":  "Monday",
+	"weekday.long.2":  "Tuesday",
+	"weekday.long.3":  "Wednesday",
+	"weekday.long.4":  "Thursday",
+	"weekday.long.5":  "Friday",
+	"weekday.long.6":  "Saturday",
+	"weekday.short.0": "Su",
+	"weekday.short.1": "Mo",
+	"weekday.short.2": "Tu",
+	"weekday.short.3": "We",
+	"weekday.short.4": "Th",
+	"weekday.short.5": "Fr",
+	"weekday.short.6": "Sa",
+}
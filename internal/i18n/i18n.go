@@ -0,0 +1,150 @@
+// Package i18n provides the message catalogs used for every user-facing string.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/econron/wamon/internal/models"
+)
+
+// Lang identifies a supported language
+type Lang string
+
+const (
+	Japanese Lang = "ja"
+	English  Lang = "en"
+
+	// Default is used when no language is configured
+	Default = Japanese
+)
+
+// catalogs holds the messages of every supported language, keyed by message key
+var catalogs = map[Lang]map[string]string{
+	Japanese: ja,
+	English:  en,
+}
+
+var (
+	mu      sync.RWMutex
+	current = Default
+)
+
+// Languages returns the supported languages in a stable order
+func Languages() []Lang {
+	langs := make([]Lang, 0, len(catalogs))
+	for lang := range catalogs {
+		langs = append(langs, lang)
+	}
+	sort.Slice(langs, func(i, j int) bool { return langs[i] < langs[j] })
+	return langs
+}
+
+// Current returns the active language
+func Current() Lang {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// SetLanguage switches the active language. Locale names such as "en_US.UTF-8" are accepted.
+func SetLanguage(name string) error {
+	lang, ok := normalize(name)
+	if !ok {
+		return fmt.Errorf("unsupported language %q (supported: %s)", name, supportedList())
+	}
+	mu.Lock()
+	current = lang
+	mu.Unlock()
+	return nil
+}
+
+// Detect picks the language from, in order of precedence, the --lang flag,
+// the config file and the LC_ALL, LC_MESSAGES and LANG environment variables.
+// Unsupported or empty values are skipped.
+func Detect(flagValue, configValue string) Lang {
+	candidates := []string{
+		flagValue,
+		configValue,
+		os.Getenv("LC_ALL"),
+		os.Getenv("LC_MESSAGES"),
+		os.Getenv("LANG"),
+	}
+	for _, candidate := range candidates {
+		if lang, ok := normalize(candidate); ok {
+			return lang
+		}
+	}
+	return Default
+}
+
+// Supported reports whether name is a supported language or locale name
+func Supported(name string) bool {
+	_, ok := normalize(name)
+	return ok
+}
+
+// normalize turns a language or locale name ("en", "ja_JP.UTF-8") into a supported Lang
+func normalize(name string) (Lang, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if i := strings.IndexAny(name, "_.-@"); i >= 0 {
+		name = name[:i]
+	}
+	lang := Lang(name)
+	_, ok := catalogs[lang]
+	return lang, ok
+}
+
+// supportedList returns the supported languages as a comma-separated list
+func supportedList() string {
+	var names []string
+	for _, lang := range Languages() {
+		names = append(names, string(lang))
+	}
+	return strings.Join(names, ", ")
+}
+
+// T returns the message for key in the active language, formatted with args.
+// Missing keys fall back to the default language, then to the key itself.
+func T(key string, args ...interface{}) string {
+	return TL(Current(), key, args...)
+}
+
+// TL is like T but for an explicit language
+func TL(lang Lang, key string, args ...interface{}) string {
+	msg, ok := catalogs[lang][key]
+	if !ok {
+		msg, ok = catalogs[Default][key]
+	}
+	if !ok {
+		return key
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// All returns the message for key in every language.
+// It is used to parse text that may have been written in any language.
+func All(key string) []string {
+	var messages []string
+	for _, lang := range Languages() {
+		if msg, ok := catalogs[lang][key]; ok {
+			messages = append(messages, msg)
+		}
+	}
+	return messages
+}
+
+// CategoryLabel returns the localized display name of a category.
+// The message key is "category." followed by the category code.
+func CategoryLabel(category models.Category) string {
+	if !category.Valid() {
+		return string(category)
+	}
+	return T("category." + string(category))
+}
@@ -0,0 +1,139 @@
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/econron/wamon/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalogsHaveSameKeys(t *testing.T) {
+	for _, lang := range Languages() {
+		for key := range catalogs[Default] {
+			_, ok := catalogs[lang][key]
+			assert.True(t, ok, "key %q is missing in %s", key, lang)
+		}
+		for key := range catalogs[lang] {
+			_, ok := catalogs[Default][key]
+			assert.True(t, ok, "key %q of %s is missing in %s", key, lang, Default)
+		}
+	}
+}
+
+var verbPattern = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z%]`)
+
+func TestCatalogsHaveSameFormatVerbs(t *testing.T) {
+	for _, lang := range Languages() {
+		for key, msg := range catalogs[lang] {
+			expected := verbPattern.FindAllString(catalogs[Default][key], -1)
+			assert.Equal(t, expected, verbPattern.FindAllString(msg, -1), "format verbs of %q differ in %s", key, lang)
+		}
+	}
+}
+
+// keyPatterns find the literal catalog keys used in the source
+var keyPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`i18n\.T\("([^"]+)"[,)]`),
+	regexp.MustCompile(`i18n\.All\("([^"]+)"\)`),
+	regexp.MustCompile(`HasLabel\([^,]+, "([^"]+)"\)`),
+	regexp.MustCompile(`localizeFlag\([^,]+, "[^"]+", "([^"]+)"\)`),
+}
+
+var commandPattern = regexp.MustCompile(`localizeCommand\([^,]+, "([^"]+)"\)`)
+
+func TestUsedKeysExist(t *testing.T) {
+	root := filepath.Join("..", "..")
+	found := 0
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() && strings.HasPrefix(info.Name(), ".") && path != root {
+			return filepath.SkipDir
+		}
+		if info.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var keys []string
+		for _, pattern := range keyPatterns {
+			for _, m := range pattern.FindAllStringSubmatch(string(data), -1) {
+				keys = append(keys, m[1])
+			}
+		}
+		for _, m := range commandPattern.FindAllStringSubmatch(string(data), -1) {
+			keys = append(keys, "cmd."+m[1]+".short", "cmd."+m[1]+".long")
+		}
+		for _, key := range keys {
+			found++
+			_, ok := catalogs[Default][key]
+			assert.True(t, ok, "%s uses unknown key %q", path, key)
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Greater(t, found, 0)
+}
+
+func TestSeriesKeysExist(t *testing.T) {
+	// These keys are built with fmt.Sprintf, so TestUsedKeysExist cannot find them
+	for i := 0; i < 7; i++ {
+		key := fmt.Sprintf("weekday.short.%d", i)
+		assert.NotEqual(t, key, T(key))
+	}
+}
+
+func TestSetLanguage(t *testing.T) {
+	defer SetLanguage(string(Default))
+
+	assert.NoError(t, SetLanguage("en"))
+	assert.Equal(t, English, Current())
+	assert.Equal(t, "Research", CategoryLabel(models.Research))
+
+	assert.NoError(t, SetLanguage("ja_JP.UTF-8"))
+	assert.Equal(t, Japanese, Current())
+	assert.Equal(t, "調べ物", CategoryLabel(models.Research))
+
+	assert.Error(t, SetLanguage("fr"))
+	assert.Equal(t, Japanese, Current())
+}
+
+func TestDetect(t *testing.T) {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		t.Setenv(name, "")
+	}
+
+	assert.Equal(t, Default, Detect("", ""))
+	assert.Equal(t, English, Detect("en", "ja"))
+	assert.Equal(t, English, Detect("", "en"))
+	assert.Equal(t, English, Detect("fr", "en"))
+
+	t.Setenv("LANG", "en_US.UTF-8")
+	assert.Equal(t, English, Detect("", ""))
+	assert.Equal(t, Japanese, Detect("", "ja"))
+
+	t.Setenv("LC_ALL", "ja_JP.UTF-8")
+	assert.Equal(t, Japanese, Detect("", ""))
+
+	t.Setenv("LC_ALL", "C")
+	assert.Equal(t, English, Detect("", ""))
+}
+
+func TestT(t *testing.T) {
+	assert.Equal(t, "合計: 3件の記録", TL(Japanese, "list.total", 3))
+	assert.Equal(t, "Total: 3 entries", TL(English, "list.total", 3))
+	assert.Equal(t, "no.such.key", T("no.such.key"))
+	assert.Equal(t, []string{"Researched:", "調べたこと:"}, All("editor.research_topic"))
+}
+
+func TestCategoryLabelUnknown(t *testing.T) {
+	assert.Equal(t, "other", CategoryLabel(models.Category("other")))
+}
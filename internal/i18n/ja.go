@@ -0,0 +1,607 @@
+package i18n
+
+// ja is the Japanese catalog. Japanese is the default language, so every key must be here.
+var ja = map[string]string{
+	"add.template_flag": "エディタの初期内容に使うテンプレート名 (~/.wamon/templates/<名前>.md)",
+
+	"app.title": "🦭 ワモンアザラシの記録 🦭",
+
+	"art.load_warning": "⚠️ アートパックを読み込めませんでした: %v",
+	"art.missing_mood": "アートパック %s に %s の表情がありません",
+	"art.not_found":    "アートパック %s が見つかりません（%s または組み込みのパック）",
+	"art.parse_error":  "アートパック %s を解析できませんでした: %v",
+	"art.read_error":   "アートパック %s を読み込めませんでした: %v",
+
+	"bulk.add_tag_flag":          "追加するタグ",
+	"bulk.change.add_tag":        "タグ追加: %s",
+	"bulk.change.category":       "カテゴリ: %s → %s",
+	"bulk.change.program_title":  "書いたプログラム: %q → %q",
+	"bulk.change.remove_tag":     "タグ削除: %s",
+	"bulk.change.research_topic": "調べたこと: %q → %q",
+	"bulk.change.satisfaction":   "満足度: %d → %d",
+	"bulk.column.changes":        "変更内容",
+	"bulk.column.date":           "日時",
+	"bulk.dry_run":               "（ドライラン）%d件の記録が変更されます。--dry-run を外すと保存されます。",
+	"bulk.dry_run_flag":          "変更せずに変更内容だけを表示する",
+	"bulk.filter_flag":           "変更する記録の条件",
+	"bulk.filter_required":       "--filter で変更する記録の条件を指定してください",
+	"bulk.invalid_satisfaction":  "満足度は1から5の数字で指定してください: %s",
+	"bulk.invalid_set":           "--set は field=value の形式で指定してください: %s",
+	"bulk.no_changes":            "--set, --add-tag, --remove-tag のいずれかを指定してください",
+	"bulk.no_match":              "条件に一致する記録がありません: %s",
+	"bulk.nothing_to_change":     "%d件の記録が一致しましたが、変更が必要な記録はありません",
+	"bulk.remove_tag_flag":       "削除するタグ",
+	"bulk.set_flag":              "変更する項目 (field=value; category, satisfaction, research_topic, program_title)",
+	"bulk.unknown_field":         "変更できない項目です: %s (%s)",
+	"bulk.updated":               "%d件の記録を更新しました！",
+
+	"category.programming":              "プログラマ",
+	"category.research":                 "調べ物",
+	"category.research_and_programming": "調べてプログラマ",
+
+	"cmd.add.long": `新しい記録を追加します。引数なしで wamon を実行した場合と同じです。
+エディタの初期内容は ~/.wamon/templates/<カテゴリ>.md があればそのテンプレートから作成されます。
+--template を指定すると ~/.wamon/templates/<名前>.md を使用します。
+
+例:
+  wamon add
+  wamon add --template standup`,
+	"cmd.add.short": "新しい記録を追加",
+	"cmd.bulk.long": `--filter に一致するすべての記録に --set, --add-tag, --remove-tag の変更を適用します。
+変更は1つのトランザクションで保存され、各記録の変更前の内容は改訂として残ります。
+--dry-run を指定すると変更内容の一覧だけを表示します。
+
+フィルタの書き方 (すべての条件に一致する記録が対象):
+  category:research     カテゴリ (cat: も可)
+  tag:go                タグ
+  since:2025-04-01      指定日以降
+  until:2025-04-30      指定日まで
+  satisfaction:<3       満足度 (=, <, <=, >, >=; sat: も可)
+  id:202504             IDの前方一致
+  sqlite                ID・内容・タグに含まれる文字列 ("..." で空白を含められます)
+
+例:
+  wamon bulk --filter 'cat:調べ物 since:2025-04-01' --set category=programming --dry-run
+  wamon bulk --filter 'tag:golang' --add-tag go --remove-tag golang`,
+	"cmd.bulk.short":            "条件に一致する記録をまとめて変更",
+	"cmd.completion.long":       "bash, zsh, fish, PowerShell用の補完スクリプトを出力します。\n`wamon completion install`で、使用中のシェルの標準の場所に補完スクリプトをインストールできます。\n\n記録ID、カテゴリ、タグは、データベースの内容から補完されます。\n\n例:\n  $ wamon completion install\n  $ wamon completion bash > /etc/bash_completion.d/wamon\n  $ source <(wamon completion zsh)",
+	"cmd.completion.short":      "シェル補完スクリプトを生成",
+	"cmd.completion_bash.long":  "bash用の補完スクリプトを標準出力に出力します。",
+	"cmd.completion_bash.short": "bash用の補完スクリプトを出力",
+	"cmd.completion_fish.long":  "fish用の補完スクリプトを標準出力に出力します。",
+	"cmd.completion_fish.short": "fish用の補完スクリプトを出力",
+	"cmd.completion_install.long": `補完スクリプトを、シェルが自動的に読み込む場所に書き込みます。
+シェルを省略した場合は、環境変数SHELLから判断します。
+
+  bash: ~/.local/share/bash-completion/completions/wamon
+  zsh:  ~/.zfunc/_wamon
+  fish: ~/.config/fish/completions/wamon.fish`,
+	"cmd.completion_install.short":    "補完スクリプトをインストール",
+	"cmd.completion_powershell.long":  "PowerShell用の補完スクリプトを標準出力に出力します。",
+	"cmd.completion_powershell.short": "PowerShell用の補完スクリプトを出力",
+	"cmd.completion_zsh.long":         "zsh用の補完スクリプトを標準出力に出力します。",
+	"cmd.completion_zsh.short":        "zsh用の補完スクリプトを出力",
+	"cmd.config.long": `設定ファイルの値を表示・変更します。
+設定ファイルはYAML（.wamon.yaml）とTOML（.wamon.toml）に対応しています。
+値は保存する前にチェックされ、不明なキーや正しくない値はエラーになります。
+
+例:
+  $ wamon config list
+  $ wamon config set slack.channel times-wamon
+  $ wamon config set streak.non_working_days sat,sun
+  $ wamon config unset art.pack
+  $ wamon config edit`,
+	"cmd.config.short":      "設定の表示と変更",
+	"cmd.config_edit.long":  "設定ファイルをエディタで開きます。保存時に内容をチェックし、問題があればエディタを開き直します。",
+	"cmd.config_edit.short": "設定ファイルをエディタで編集",
+	"cmd.config_get.long":   "キーの値を表示します。設定されていない場合は既定値を表示します。",
+	"cmd.config_get.short":  "設定の値を表示",
+	"cmd.config_list.long":  "設定できるすべてのキーと現在の値を表示します。",
+	"cmd.config_list.short": "すべての設定を表示",
+	"cmd.config_migrate_secrets.long": `設定ファイルに直接書かれた slack.token を、所有者だけが読めるファイル（~/.wamon/slack-token、権限 600）に移し、
+slack.token_file がそのファイルを指すように設定ファイルを書き換えます。`,
+	"cmd.config_migrate_secrets.short": "設定ファイルのトークンを別ファイルに移す",
+	"cmd.config_path.long": `使用中の設定ファイルのパスを表示します。まだない場合は作成される場所を表示します。
+設定ファイルは --config、環境変数 WAMON_CONFIG、$XDG_CONFIG_HOME/wamon/config.yaml、
+~/.wamon/.wamon.yaml、~/.wamon.yaml、$XDG_CONFIG_DIRS/wamon/config.yaml の順に探します。
+--explain を付けると、探した場所と各設定値がどこから来たか（フラグ、環境変数、設定ファイル、デフォルト）を表示します。`,
+	"cmd.config_path.short":  "設定ファイルのパスを表示",
+	"cmd.config_set.long":    "キーの値をチェックしてから設定ファイルに保存します。リストはカンマ区切りで指定します。",
+	"cmd.config_set.short":   "設定の値を変更",
+	"cmd.config_unset.long":  "キーを設定ファイルから削除し、既定値に戻します。",
+	"cmd.config_unset.short": "設定の値を削除",
+	"cmd.edit.long": `指定されたIDの記録を編集します。
+エディタが開くので、内容を編集して保存してください。`,
+	"cmd.edit.short": "既存の記録を編集",
+	"cmd.export.long": `記録した全てのエントリをJSON形式でエクスポートします。
+エクスポートされたファイルは、1行につき1つのJSONオブジェクトの形式で保存されます。
+ファイル名が指定されない場合は、カレントディレクトリにwamon_export.jsonという名前で保存されます。
+
+例:
+  $ wamon export
+  $ wamon export my_records.json
+  $ wamon export --since 24h
+  $ wamon export my_records.json --since 168h`,
+	"cmd.export.short": "全ての記録をJSON形式でエクスポート",
+	"cmd.heatmap.long": `1年分の記録をGitHubのコントリビューショングラフのようなカレンダー形式で表示します。
+環境変数 NO_COLOR が設定されている場合や出力が端末でない場合は、色の代わりに濃淡のブロック文字で表示します。
+
+例:
+  $ wamon heatmap
+  $ wamon heatmap --year 2025 --metric satisfaction
+  $ wamon heatmap -c 調べ物
+  $ wamon heatmap --svg heatmap.svg`,
+	"cmd.heatmap.short": "記録のヒートマップを表示",
+	"cmd.import.long": `エクスポートされたJSONファイルからデータをインポートします。
+同じIDの項目が既に存在する場合はスキップされます。
+
+例:
+  $ wamon import wamon_backup.json`,
+	"cmd.import.short": "JSONファイルからデータをインポート",
+	"cmd.list.long":    "過去に記録したエントリを一覧表示します。カテゴリでフィルタリングすることもできます。",
+	"cmd.list.short":   "過去の記録を表示",
+	"cmd.report.long": `先週（--period で先月・前四半期・任意の期間も指定できます）の記録をSlackに送信します。
+デフォルトは直近7日間ではなく、最後に終わったカレンダー上の週（月曜日〜日曜日）です。
+今週の記録は --current、直近7日間は --since で送信できます。
+週の始まりは設定ファイルの report.week_start で変えられます（デフォルトは月曜日）。
+Slackの設定は~/.wamon.yamlで行います。`,
+	"cmd.report.short": "Slackに先週などの期間の記録を送信",
+	"cmd.root.long": `ワモンアザラシと一緒に日々の活動を記録するCLIツールです。
+調べ物や書いたプログラムを記録して、ワモンアザラシから褒めてもらいましょう！`,
+	"cmd.root.short": "ワモンアザラシと一緒に日々の活動を記録するCLIツール",
+	"cmd.set_db.long": `現在のデータベースパスを設定ファイルに保存します。
+これにより、--dbオプションを毎回指定する必要がなくなります。`,
+	"cmd.set_db.short": "現在のデータベースパスを設定に保存",
+	"cmd.show.long": `指定されたIDの記録を、作成・更新日時や改訂回数と一緒に表示します。
+同じ日の他の記録や、内容が似ている記録もあわせて表示します。
+
+例:
+  $ wamon show 20250401093000
+  $ wamon show 20250401093000 --output json`,
+	"cmd.show.short": "記録の詳細を表示",
+	"cmd.stats.long": `指定した期間の記録から、カテゴリごとの件数、満足度の平均・中央値、
+満足度の高い記録、時間帯・曜日ごとの傾向、連続記録日数を表示します。
+
+例:
+  $ wamon stats
+  $ wamon stats --since 7d
+  $ wamon stats --since 2025-04-01 --output json`,
+	"cmd.stats.short": "記録の統計を表示",
+	"cmd.tui.long": `記録の一覧、詳細、統計を全画面で表示します。
+検索やカテゴリでの絞り込み、記録の追加・編集・削除ができます。
+
+キー操作:
+  /        インクリメンタル検索
+  c        カテゴリの切り替え
+  a        記録を追加
+  e, Enter 選択中の記録を編集
+  d        選択中の記録を削除
+  r        再読み込み
+  q, ESC   終了`,
+	"cmd.tui.short": "全画面で記録を閲覧・編集",
+	"cmd.undo.long": `記録の追加・編集・一括変更・インポート・削除を取り消します。
+引数を省略すると、まだ取り消していない最新の操作を取り消します。
+操作IDを指定するとその操作を取り消します (wamon undo --list で確認できます)。
+インポートは1回分のファイルがまとめて取り消されます。
+
+例:
+  wamon undo
+  wamon undo --list
+  wamon undo 12`,
+	"cmd.undo.short": "直前の操作を取り消す",
+
+	"completion.hint.bash": "新しいシェルを開くと有効になります（bash-completionが必要です）。",
+	"completion.hint.fish": "新しいシェルを開くと有効になります。",
+	"completion.hint.zsh": `~/.zshrc に次の行がなければ追加してください:
+  fpath=(~/.zfunc $fpath)
+  autoload -U compinit && compinit`,
+	"completion.home_error": "ホームディレクトリの取得エラー: %v",
+	"completion.installed":  "%sの補完スクリプトを %s にインストールしました",
+	"completion.unsupported_shell": `対応していないシェルです: %s
+対応しているシェル: bash, zsh, fish`,
+	"completion.write_error": "補完スクリプトの書き込みエラー: %v",
+
+	"config.default_mark":                  "(既定値)",
+	"config.edit_cancelled":                "内容が空のため、設定ファイルは変更しませんでした。",
+	"config.edit_retry":                    "設定に%d件の問題があります。エディタを開き直します...",
+	"config.edited":                        "設定ファイルを保存しました: %s",
+	"config.empty_value":                   "値が空です",
+	"config.explain_file":                  "設定ファイル: %s (%s)",
+	"config.explain_searched":              "探した場所 (上から順に):",
+	"config.explain_values":                "設定値:",
+	"config.field.art.animate":             "ターミナルでアートをアニメーションする",
+	"config.field.art.pack":                "アザラシのアート（seal, tiny, ~/.wamon/art の名前、またはYAMLファイルのパス）",
+	"config.field.art.plain":               "アートなしでメッセージだけを表示する",
+	"config.field.database.path":           "データベースファイルのパス",
+	"config.field.hooks":                   "記録の保存・更新・削除やレポートの前後に実行するコマンド（イベントごとのリスト）",
+	"config.field.lang":                    "表示言語（ja または en）。省略すると環境変数 LANG などから決まります",
+	"config.field.report.week_start":       "週の始まりの曜日（mon, sun, ... または 月, 日, ...）",
+	"config.field.slack.channel":           "週次レポートを送るSlackチャンネル",
+	"config.field.slack.enabled":           "Slackへの送信を有効にする",
+	"config.field.slack.token":             "週次レポートを送るSlack Bot Token（xoxb- で始まる）",
+	"config.field.slack.token_command":     "Slackのトークンを出力するコマンド（例: pass show slack/wamon）。レポートを送るときだけ実行されます",
+	"config.field.slack.token_file":        "Slackのトークンだけを書いたファイル（所有者だけが読める権限 600 が必要）",
+	"config.field.streak.holidays":         "組み込みの祝日データ（jp）",
+	"config.field.streak.holidays_file":    "独自の休日ファイル（1行に「YYYY-MM-DD 名称」）",
+	"config.field.streak.non_working_days": "連続記録が途切れない休みの曜日（sun, mon, ... または 日, 月, ...）",
+	"config.invalid_bool":                  "%s には true か false を指定してください: %v",
+	"config.invalid_language":              "対応していない言語です: %v (対応: %s)",
+	"config.invalid_list":                  "%s にはリストを指定してください: %v",
+	"config.invalid_section":               "%s にはキーと値のセクションを指定してください",
+	"config.invalid_slack_channel":         "チャンネル名が正しくありません: 「%s」",
+	"config.invalid_slack_token":           "Slackのトークンは %s のいずれかで始まります",
+	"config.invalid_string":                "%s には文字列を指定してください: %v",
+	"config.migrated":                      "slack.token を %s (権限 600) に移し、%s に slack.token_file を設定しました",
+	"config.not_set":                       "%s は設定されていません。",
+	"config.nothing_to_migrate":            "%s にはトークンが直接書かれていません",
+	"config.origin.env":                    "環境変数 WAMON_CONFIG",
+	"config.origin.flag":                   "--config フラグ",
+	"config.origin.legacy":                 "従来の場所",
+	"config.origin.not_found":              "まだないため、ここに作成されます",
+	"config.origin.xdg_dirs":               "XDG_CONFIG_DIRS",
+	"config.origin.xdg_home":               "XDG_CONFIG_HOME",
+	"config.parse_error":                   "設定ファイル %s を読み込めません: %v",
+	"config.path_is_directory":             "ファイルではなくディレクトリです: %s",
+	"config.path_not_found":                "ファイルが見つかりません: %s",
+	"config.secret_file_exists":            "%s には別のトークンがすでに保存されています。確認してから削除してください",
+	"config.secret_permissions":            "%s は他のユーザーから読める権限 (%v) になっています。chmod 600 %s で権限を変更してください",
+	"config.section_not_settable":          "%s はコマンドでは設定できません。wamon config edit でファイルを編集してください。",
+	"config.set":                           "%s を設定しました: %s (%s)",
+	"config.source.default":                "デフォルト",
+	"config.source.file":                   "設定ファイル",
+	"config.source.unset":                  "未設定",
+	"config.token_command_failed":          "slack.token_command の実行に失敗しました: %v",
+	"config.token_empty":                   "%s から読み込んだトークンが空です",
+	"config.unknown_key":                   "不明な設定キーです: %s (wamon config list で使えるキーを確認できます)",
+	"config.unset":                         "%s の設定を削除しました (%s)",
+	"config.write_error":                   "設定ファイルを保存できません: %v",
+
+	"edit.title": "🦭 更新された記録 🦭",
+	"edit.update_error": `データの更新エラー: %v
+編集内容を保存できませんでした。再度試してみてください。`,
+	"edit.updated": "記録を更新しました！",
+
+	"editor.cancelled":            "内容が空のため編集を中止しました",
+	"editor.category":             "カテゴリ: %s",
+	"editor.category_label":       "カテゴリ:",
+	"editor.date":                 "日時: %s",
+	"editor.date_label":           "日時:",
+	"editor.error":                "エラー: %s",
+	"editor.error_header":         "入力内容にエラーがあります。各エラーの下の行を修正して保存してください。すべて削除して保存すると中止します。",
+	"editor.invalid_category":     "不明なカテゴリです: %s (%s のいずれかを指定してください)",
+	"editor.invalid_date":         "日時を読み取れません: %s (例: 2025-04-01 09:30)",
+	"editor.invalid_satisfaction": "満足度は1から5の数字で指定してください: %s",
+	"editor.launch_failed":        "エディタの起動に失敗しました。システムで '%s' が利用可能かどうか確認してください。",
+	"editor.not_found":            "利用可能なエディタが見つかりません",
+	"editor.not_found_hint": `警告: システムで標準のエディタが見つかりませんでした。
+vimまたはviをインストールすることをお勧めします。
+または、EDITOR環境変数を設定してください。`,
+	"editor.program_title":         "書いたプログラム:",
+	"editor.read_back_error":       "編集後のファイルの読み込みに失敗しました: %v",
+	"editor.read_back_lost":        "編集後のファイルの読み込みに失敗しました。変更内容が失われた可能性があります。",
+	"editor.research_topic":        "調べたこと:",
+	"editor.retry":                 "入力内容に%d件のエラーがあります。エディタを開き直します...",
+	"editor.run_error":             "エディタの実行に失敗しました: %v",
+	"editor.satisfaction":          "満足度: %d/5",
+	"editor.satisfaction_label":    "満足度:",
+	"editor.tags":                  "タグ:",
+	"editor.tempfile_create_error": "一時ファイルの作成に失敗しました: %v",
+	"editor.tempfile_write_error":  "一時ファイルの書き込みに失敗しました: %v",
+	"editor.using":                 "エディタ '%s' を使用して編集します...",
+	"editor.vim_help": `vim/viの操作方法:
+ESC: 編集モードを終了
+:q: 保存せずに終了
+:wq: 保存して終了
+:x: 保存して終了
+:w: 保存`,
+	"editor.vim_missing_hint": `vim/viがインストールされていない場合は、別のエディタを試してみてください。
+例: export EDITOR=nano`,
+
+	"entry.category":       "カテゴリ: %s",
+	"entry.id_with_date":   "記録ID: %s [%s]",
+	"entry.program_title":  "書いたプログラム: %s",
+	"entry.research_topic": "調べたこと: %s",
+	"entry.satisfaction":   "満足度: %d/5",
+	"entry.tags":           "タグ: %s",
+
+	"error.calendar": `休日設定の読み込みエラー: %v
+すべての日を稼働日として計算します。`,
+	"error.config_load": `設定の読み込みエラー: %v
+再度試してみてください。`,
+	"error.config_save": `設定の保存エラー: %v
+再度試してみてください。`,
+	"error.count": "データ取得エラー: %v",
+	"error.db_init": `データベースの初期化エラー: %v
+データベースパス: %s を確認してください。`,
+	"error.edit": `編集エラー: %v
+編集をキャンセルしました。`,
+	"error.edit_short": "編集エラー: %v",
+	"error.entry_not_found": `ID %s の記録が見つかりません。
+正しいIDを指定してください。`,
+	"error.fetch": `データの取得エラー: %v
+再度試してみてください。`,
+	"error.input": `入力エラー: %v
+再度試してみてください。`,
+	"error.invalid_category": "無効なカテゴリです。有効なカテゴリ: %s",
+	"error.invalid_filter":   "フィルタが正しくありません: %v",
+	"error.invalid_output": `無効な出力形式です: %s
+有効な形式: text, json`,
+	"error.invalid_since": "指定された期間の形式が不正です: %v",
+	"error.json":          "JSON変換エラー: %v",
+	"error.save": `データの保存エラー: %v
+再度試してみてください。`,
+	"error.streak":               "連続記録の計算エラー: %v",
+	"error.unsupported_language": "対応していない言語です: %s (対応している言語: %s)",
+
+	"export.count_error": "エントリ数の取得エラー: %v",
+	"export.done":        "%d件のエントリを %s にエクスポートしました",
+	"export.error":       "エクスポートエラー: %v",
+	"export.file":        "ファイル: %s/%s",
+	"export.since_flag":  "指定した期間分のエントリのみエクスポート (例: 24h, 168h)",
+
+	"feedback.invalid_trend": "メッセージパック %s のルール %s の trend が不正です: %s (up か down を指定してください)",
+	"feedback.load_warning":  "⚠️ メッセージパックを読み込めませんでした: %v",
+	"feedback.missing_id":    "メッセージパック %s の %d 番目のルールに id がありません",
+	"feedback.no_messages":   "メッセージパック %s のルール %s にメッセージがありません",
+	"feedback.parse_error":   "メッセージパック %s を解析できませんでした: %v",
+	"feedback.read_error":    "メッセージパック %s を読み込めませんでした: %v",
+
+	"flag.category":   "カテゴリで絞り込み (research, programming, both または 調べ物, プログラマ, 調べてプログラマ)",
+	"flag.config":     "設定ファイル (WAMON_CONFIG、~/.config/wamon/config.yaml、~/.wamon/.wamon.yaml の順に探します)",
+	"flag.db":         "データベースファイルのパス (デフォルトは WAMON_DB_PATH、設定ファイルの database.path、~/.wamon/wamon.db の順)",
+	"flag.debug":      "--verbose と同じ",
+	"flag.explain":    "設定ファイルの探し方と、各設定値がどこから来たかを表示",
+	"flag.lang":       "表示言語 (ja, en)",
+	"flag.log_file":   "ログを書き込むファイル（5MBごとにローテーションし、3世代まで保存）",
+	"flag.log_format": "ログの形式 (text, json)",
+	"flag.log_level":  "ログのレベル (debug, info, warn, error)",
+	"flag.multi":      "1回のセッションで複数の記録を続けて入力",
+	"flag.no_art":     "アザラシのアートを表示せずメッセージだけを表示する（スクリプト・スクリーンリーダー向け）",
+	"flag.output":     "出力形式 (text, json)",
+	"flag.script":     "質問への回答をファイルから読み込む（自動実行用）",
+	"flag.tag":        "タグで絞り込み",
+	"flag.verbose":    "詳細なログ（SQLの実行時間、設定の読み込み元、Slack APIの呼び出し）を標準エラー出力に表示",
+
+	"heatmap.create_error":   "ファイルの作成エラー: %v",
+	"heatmap.invalid_metric": "不明な指標です: %s (count, satisfaction)",
+	"heatmap.legend_less":    "少",
+	"heatmap.legend_more":    "多",
+	"heatmap.metric_flag":    "表示する指標 (count, satisfaction)",
+	"heatmap.saved":          "ヒートマップを %s に保存しました",
+	"heatmap.svg_error":      "SVGの書き込みエラー: %v",
+	"heatmap.svg_flag":       "ヒートマップをSVGファイルとして保存",
+	"heatmap.title":          "🦭 %d年の記録 🦭",
+	"heatmap.year_flag":      "表示する年 (デフォルトは今年)",
+
+	"hook.config_invalid":         "hooks.%s にはフックの一覧を指定してください",
+	"hook.config_invalid_hook":    "hooks.%s の %d 番目のフックが不正です: %v",
+	"hook.config_invalid_timeout": "%s のタイムアウトが不正です: %v（例: 5s）",
+	"hook.config_missing_command": "command が指定されていません",
+	"hook.config_unknown_event":   "不明なフックイベントです: %s（pre-save, post-save, post-update, post-delete, pre-report のいずれか）",
+	"hook.exit_status":            "終了コード %d で終了しました",
+	"hook.failed":                 "%s フック %s が失敗しました: %s",
+	"hook.id_changed":             "IDは変更できません（%s → %s）",
+	"hook.invalid_category":       "不正なカテゴリです: %s",
+	"hook.invalid_output":         "出力をJSONとして読み込めませんでした: %v",
+	"hook.invalid_satisfaction":   "満足度は1〜5で指定してください: %d",
+	"hook.timeout":                "%v でタイムアウトしました",
+	"hook.vetoed":                 "%s フック %s により中止されました: %s",
+	"hook.warning":                "⚠️ %v",
+
+	"import.done":        "%d件のエントリを正常にインポートしました",
+	"import.error":       "インポートエラー: %v",
+	"import.total":       "現在のデータベースには合計%d件のエントリがあります",
+	"import.total_error": "データベース内の総エントリ数の取得に失敗しました: %v",
+
+	"journal.add_another":         "続けて別の記録を追加しますか？ (y/N)",
+	"journal.back_to_category":    "内容が空になったので、カテゴリの選択に戻ります。",
+	"journal.cancelled":           "記録をキャンセルしました。またね！",
+	"journal.confirm_save":        "この%d件を保存しますか？ (Y/n)",
+	"journal.count":               "現在の記録数: %d件",
+	"journal.draft_error":         "下書きを扱えませんでした: %v",
+	"journal.draft_found":         "%s に保存された下書き (%s) があります。続きから書きますか？ (Y/n)",
+	"journal.draft_found_entries": "%s に保存された下書き (%d件) があります。続きから書きますか？ (Y/n)",
+	"journal.draft_kept": `書いた内容は下書きとして保存されています: %s
+次に記録するときに続きから書けます。`,
+	"journal.draft_restored": "下書きから %d件の記録を復元しました",
+	"journal.first_step":     "これが最初のステップです。戻る場所はありません。",
+	"journal.intro": `今日の活動を記録しましょう！
+途中でやめたい場合は 'quit'、前の質問に戻りたい場合は 'back' と入力してください。`,
+	"journal.saved":      "記録しました！",
+	"journal.saved_many": "%d件を記録しました！",
+	"journal.streak":     "連続記録: %d日 (最長 %d日)",
+	"journal.summary":    "今回の記録 (%d件):",
+
+	"list.empty":        "記録がありません。",
+	"list.entry_header": "記録 #%d [ID: %s] [%s]",
+	"list.total":        "合計: %d件の記録",
+
+	"log.invalid_format": "不明なログの形式です: %s (text, json のいずれかを指定してください)",
+	"log.invalid_level":  "不明なログレベルです: %s (debug, info, warn, error のいずれかを指定してください)",
+	"log.setup_error":    "ログを設定できませんでした: %v",
+
+	"operation.delete": "削除",
+	"operation.import": "インポート",
+	"operation.save":   "追加",
+	"operation.update": "編集",
+
+	"prompt.ask_program_title":    "書いたプログラムを入力してください:",
+	"prompt.ask_research_topic":   "調べたことを入力してください:",
+	"prompt.ask_satisfaction":     "満足度を1-5で入力してください (5が最高):",
+	"prompt.choose_category":      "カテゴリを選択してください:",
+	"prompt.edit_start":           "エントリの編集を開始します...",
+	"prompt.end_of_input":         "入力が終了しました（スクリプトの回答が足りない可能性があります）",
+	"prompt.invalid_category":     "無効な選択です。1, 2, または 3 を入力してください。",
+	"prompt.invalid_satisfaction": "満足度は1から5の数字で入力してください",
+	"prompt.invalid_yes_no":       "y か n で答えてください。",
+	"prompt.not_a_terminal":       "標準入力が端末ではないため、質問に答えられません。端末から実行するか、--script <ファイル> で回答を指定してください。",
+	"prompt.read_error":           "入力の読み取りエラー: %v",
+	"prompt.script_unterminated":  "スクリプトのエディタ内容が「%s」の行で終わっていません",
+
+	"query.invalid_value":  "%s に指定できない値です: %s",
+	"query.unclosed_quote": "引用符が閉じられていません: %s",
+	"query.unknown_field":  "不明な項目です: %s (%s)",
+
+	"report.ask_channel": "投稿先のSlackチャンネル名を入力してください（例: general）:",
+	"report.ask_token":   "SlackのBot User OAuth Tokenを入力してください（xoxb-で始まるトークン）:",
+	"report.config_save_error": `設定の保存エラー: %v
+設定の保存に失敗しましたが、今回のレポート送信は続行します。`,
+	"report.current_flag":           "終わった期間ではなく、今の週・月・四半期を送信",
+	"report.date_layout":            "2006/01/02",
+	"report.dry_run_flag":           "Slackに送信せず、送信するメッセージを表示する（トークン不要）",
+	"report.empty":                  "%s の記録がありません。",
+	"report.format_flag":            "--dry-run の出力形式 (blocks-json, markdown, text)",
+	"report.format_without_dry_run": "--format は --dry-run と一緒に指定してください",
+	"report.invalid_date":           "%s の日付が不正です: %s（YYYY-MM-DD の形式で指定してください）",
+	"report.invalid_format":         "不明な形式です: %s（%s のいずれかを指定してください）",
+	"report.invalid_period":         "不明な期間です: %s（week, month, quarter, custom のいずれかを指定してください）",
+	"report.invalid_token":          "無効なトークンです。Bot User OAuth Token（xoxb-で始まるトークン）を入力してください。",
+	"report.month_layout":           "2006年1月",
+	"report.period.quarter":         "%d年 第%d四半期",
+	"report.period.range":           "%s〜%s",
+	"report.period_conflict":        "--since と --until は --period custom でのみ使えます",
+	"report.period_flag":            "レポートの期間 (week, month, quarter, custom)。デフォルトは先週（カレンダー上の週）",
+	"report.send_error": `Slackへの送信エラー: %v
+再度試してみてください。`,
+	"report.sent":           "%s の記録 (%d件) をSlackチャンネル #%s に送信しました！",
+	"report.since_flag":     "期間の初日 (YYYY-MM-DD、--period custom)",
+	"report.since_required": "--period custom には --since を指定してください",
+	"report.streak_error": `連続記録の計算エラー: %v
+連続記録なしでレポートを送信します。`,
+	"report.time_of_day.afternoon": "昼",
+	"report.time_of_day.evening":   "夕方",
+	"report.time_of_day.morning":   "朝",
+	"report.time_of_day.night":     "夜",
+	"report.token_error":           "Slackのトークンを読み込めませんでした: %v",
+	"report.token_saved":           "トークンを %s (権限 600) に保存しました",
+	"report.until_before_since":    "--until (%s) が --since (%s) より前です",
+	"report.until_flag":            "期間の最終日 (YYYY-MM-DD、--period custom、デフォルトは今日)",
+	"report.week_start_error":      "report.week_start の値が不正です: %v",
+
+	"script.read_error": "スクリプト %s を読み込めませんでした: %v",
+
+	"set_db.saved": `データベースパス %s を設定に保存しました。
+今後は--dbオプションを指定しなくても、このパスが使用されます。`,
+
+	"show.created_at":     "作成日時: %s",
+	"show.id":             "記録ID: %s",
+	"show.none":           "  なし",
+	"show.revision_count": "改訂回数: %d",
+	"show.same_day":       "同じ日の記録:",
+	"show.similar":        "似ている記録:",
+	"show.similar_flag":   "表示する似ている記録の最大数",
+	"show.title":          "🦭 記録の詳細 🦭",
+	"show.updated_at":     "更新日時: %s",
+
+	"slack.best":                 "*🌟 満足度の高かった記録*",
+	"slack.busiest_day":          "📅 最も記録が多かった日: *%s* (%d件)",
+	"slack.by_category":          "*カテゴリ別*",
+	"slack.category":             "*カテゴリ:* %s",
+	"slack.category_count":       "%s: *%d件*",
+	"slack.compare":              "📈 前の期間 (%s) との比較: 記録 %d件 → %d件 (%+d)",
+	"slack.compare_satisfaction": "　　平均満足度 %.1f → %.1f (%+.1f)",
+	"slack.days_omitted":         "（それより前の %d日分は省略しました）",
+	"slack.header":               "🦭 ワモンアザラシの記録 %s 🦭",
+	"slack.overview":             "📝 記録 *%d件*（活動 %d日）・平均満足度 *%.1f*",
+	"slack.program_title":        "*書いたプログラム:* %s",
+	"slack.research_topic":       "*調べたこと:* %s",
+	"slack.satisfaction":         "*満足度:* %s",
+	"slack.streak":               "🔥 連続記録: 現在 *%d日* / 最長 *%d日*",
+	"slack.summary":              "%s は合計 *%d件* の記録がありました。次も頑張りましょう！",
+	"slack.time_of_day_count":    "%s %d件",
+	"slack.times_of_day":         "🕒 時間帯: %s",
+
+	"stats.by_category":          "カテゴリ別:",
+	"stats.by_hour":              "時間帯別:",
+	"stats.by_weekday":           "曜日別:",
+	"stats.empty":                "この期間の記録がありません。",
+	"stats.holidays_ended":       "警告: 組み込みの祝日データ (%s) は%d年までです。それ以降の祝日は連続記録で休みとして扱われません。streak.holidays_file で追加できます",
+	"stats.hour":                 "%02d時",
+	"stats.invalid_holiday_date": "祝日データの%d行目の日付が不正です: %s",
+	"stats.invalid_weekday":      "不明な曜日です: %s",
+	"stats.period":               "期間: %s 〜 %s",
+	"stats.satisfaction":         "満足度: 平均 %.1f / 中央値 %.1f",
+	"stats.since_example":        "%q (例: 30d, 2w, 168h, 2025-04-01)",
+	"stats.since_flag":           "集計する期間 (例: 30d, 2w, 168h, 2025-04-01)",
+	"stats.streak":               "連続記録: 現在 %d日 / 最長 %d日",
+	"stats.title":                "🦭 ワモンアザラシの統計 🦭",
+	"stats.top_entries":          "満足度の高い記録:",
+	"stats.top_flag":             "表示する満足度の高い記録の件数",
+	"stats.total":                "記録数: %d件 (活動日数: %d日)",
+	"stats.unknown_holidays":     "組み込みの祝日データが見つかりません: %s",
+
+	"template.not_found":    "テンプレート '%s' が見つかりません (%s)",
+	"template.parse_error":  "テンプレートの解析に失敗しました: %v",
+	"template.read_error":   "テンプレート '%s' の読み込みに失敗しました: %v",
+	"template.render_error": "テンプレートの展開に失敗しました: %v",
+
+	"tui.add_title": " 記録を追加 ",
+	"tui.cancel":    "キャンセル",
+	"tui.confirm_delete": `記録 %s を削除しますか？
+%s`,
+	"tui.delete":                "削除",
+	"tui.delete_error":          "削除エラー: %v",
+	"tui.deleted":               "記録 %s を削除しました",
+	"tui.detail":                " 詳細 ",
+	"tui.detail.category":       "[green]カテゴリ:[-] %s",
+	"tui.detail.created_at":     "[green]日時:[-] %s",
+	"tui.detail.id":             "[green]記録ID:[-] %s",
+	"tui.detail.program_title":  "[green]書いたプログラム:[-]",
+	"tui.detail.research_topic": "[green]調べたこと:[-]",
+	"tui.detail.satisfaction":   "[green]満足度:[-] %d/5",
+	"tui.detail.tags":           "[green]タグ:[-] %s",
+	"tui.detail.updated_at":     "[green]更新:[-] %s",
+	"tui.edit_title":            " 記録 %s を編集 ",
+	"tui.empty_body":            "内容が空です",
+	"tui.entries":               " 記録 ",
+	"tui.entries_filtered":      " 記録 [%s] ",
+	"tui.error":                 "画面の表示エラー: %v",
+	"tui.form.category":         "カテゴリ",
+	"tui.form.program_title":    "書いたプログラム",
+	"tui.form.research_topic":   "調べたこと",
+	"tui.form.satisfaction":     "満足度",
+	"tui.form.tags":             "タグ",
+	"tui.help":                  " /: 検索 | c: カテゴリ | a: 追加 | e/Enter: 編集 | d: 削除 | r: 再読み込み | q: 終了",
+	"tui.reload_error":          "再読み込みエラー: %v",
+	"tui.save":                  "保存",
+	"tui.save_error":            "保存エラー: %v",
+	"tui.saved":                 "記録 %s を保存しました",
+	"tui.search":                "検索: ",
+	"tui.sidebar.longest":       "最長記録: %d日",
+	"tui.sidebar.mean":          "平均満足度: %.1f",
+	"tui.sidebar.streak":        "連続記録: %d日",
+	"tui.sidebar.total":         "記録数: %d件",
+	"tui.stats":                 " 統計 ",
+
+	"undo.already_undone": "操作 #%d はすでに取り消されています。",
+	"undo.conflict":       "操作 #%d の後に、操作 #%d が記録 %s を変更しています。先に wamon undo %d を実行してください。",
+	"undo.done":           "操作 #%d（%s, %d件）を取り消しました！",
+	"undo.entries":        "対象: %s",
+	"undo.error":          "取り消しに失敗しました: %v",
+	"undo.invalid_id":     "操作IDが正しくありません: %s",
+	"undo.limit_flag":     "一覧に表示する操作の数",
+	"undo.list_empty":     "記録された操作がありません。",
+	"undo.list_flag":      "取り消せる操作の一覧を表示する",
+	"undo.list_hint":      "wamon undo [操作ID] で取り消せます。",
+	"undo.list_title":     "🦭 操作の履歴 🦭",
+	"undo.more_entries":   "%s ほか%d件",
+	"undo.not_found":      "操作 #%d が見つかりません。wamon undo --list で確認してください。",
+	"undo.nothing":        "取り消せる操作がありません。",
+	"undo.summary":        "%s %d件",
+	"undo.undone_mark":    "(取り消し済み)",
+
+	"weekday.long.0":  "日曜日",
+	"weekday.long.1":  "月曜日",
+	"weekday.long.2":  "火曜日",
+	"weekday.long.3":  "水曜日",
+	"weekday.long.4":  "木曜日",
+	"weekday.long.5":  "金曜日",
+	"weekday.long.6":  "土曜日",
+	"weekday.short.0": "日",
+	"weekday.short.1": "月",
+	"weekday.short.2": "火",
+	"weekday.short.3": "水",
+	"weekday.short.4": "木",
+	"weekday.short.5": "金",
+	"weekday.short.6": "土",
+}
@@ -0,0 +1,84 @@
+package interactive
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/econron/wamon/internal/models"
+)
+
+// Draft is the editor text of an entry that has not been saved yet. The draft
+// file also holds the entries finished earlier in the session, so everything
+// written before a failed or cancelled save can be restored together.
+type Draft struct {
+	ID           string          `json:"id,omitempty"`
+	Category     models.Category `json:"category"`
+	Content      string          `json:"content"`
+	Satisfaction int             `json:"satisfaction,omitempty"` // set once the entry is finished
+	CreatedAt    time.Time       `json:"created_at"`
+	SavedAt      time.Time       `json:"saved_at"`
+	// Finished holds the entries of the session that were answered but not saved yet
+	Finished []Draft `json:"finished,omitempty"`
+}
+
+// Entry rebuilds a finished entry from its draft
+func (d *Draft) Entry() (*models.Entry, error) {
+	entry := &models.Entry{ID: d.ID, Category: d.Category, Satisfaction: d.Satisfaction, CreatedAt: d.CreatedAt}
+	if entry.ID == "" {
+		entry.ID = d.CreatedAt.Format(models.IDLayout)
+	}
+	if errs := ParseEditorContent(d.Content, entry); len(errs) > 0 {
+		return nil, errs[0]
+	}
+	return entry, nil
+}
+
+// DraftPath returns where the draft of the interactive journal is kept
+func DraftPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "draft.json"
+	}
+	return filepath.Join(home, ".wamon", "draft.json")
+}
+
+// LoadDraft reads the draft at path; it returns nil when there is none
+func LoadDraft(path string) (*Draft, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	draft := &Draft{}
+	if err := json.Unmarshal(data, draft); err != nil {
+		return nil, err
+	}
+	return draft, nil
+}
+
+// Save writes the draft to path, creating the directory if needed
+func (d *Draft) Save(path string) error {
+	d.SavedAt = time.Now()
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// RemoveDraft deletes the draft at path; a missing draft is not an error
+func RemoveDraft(path string) error {
+	err := os.Remove(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
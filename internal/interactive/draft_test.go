@@ -0,0 +1,68 @@
+package interactive
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/econron/wamon/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDraftSaveLoadRemove(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wamon", "draft.json")
+
+	// There is no draft at first
+	draft, err := LoadDraft(path)
+	assert.NoError(t, err)
+	assert.Nil(t, draft)
+
+	created := time.Date(2025, 4, 1, 9, 30, 0, 0, time.UTC)
+	assert.NoError(t, (&Draft{Category: models.Research, Content: "調べたこと:\nGo", CreatedAt: created}).Save(path))
+
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	draft, err = LoadDraft(path)
+	assert.NoError(t, err)
+	if assert.NotNil(t, draft) {
+		assert.Equal(t, models.Research, draft.Category)
+		assert.Equal(t, "調べたこと:\nGo", draft.Content)
+		assert.True(t, created.Equal(draft.CreatedAt))
+		assert.False(t, draft.SavedAt.IsZero())
+	}
+
+	assert.NoError(t, RemoveDraft(path))
+	assert.NoError(t, RemoveDraft(path))
+	draft, err = LoadDraft(path)
+	assert.NoError(t, err)
+	assert.Nil(t, draft)
+}
+
+func TestLoadDraftInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "draft.json")
+	assert.NoError(t, os.WriteFile(path, []byte("{"), 0600))
+
+	_, err := LoadDraft(path)
+	assert.Error(t, err)
+}
+
+func TestDraftEntry(t *testing.T) {
+	createdAt := time.Date(2025, 4, 1, 9, 30, 0, 0, time.Local)
+	draft := &Draft{ID: "20250401093005", Category: models.Programming, Content: "書いたプログラム:\nwamon", Satisfaction: 4, CreatedAt: createdAt}
+
+	entry, err := draft.Entry()
+	assert.NoError(t, err)
+	assert.Equal(t, "20250401093005", entry.ID)
+	assert.Equal(t, "wamon", entry.ProgramTitle)
+	assert.Equal(t, 4, entry.Satisfaction)
+	assert.Equal(t, createdAt, entry.CreatedAt)
+
+	// A draft without an ID gets one from its time
+	draft.ID = ""
+	entry, err = draft.Entry()
+	assert.NoError(t, err)
+	assert.Equal(t, "20250401093000", entry.ID)
+}
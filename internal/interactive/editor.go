@@ -2,6 +2,7 @@ package interactive
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
@@ -88,7 +89,7 @@ func EditText(initialContent, title string) (string, bool, error) {
 	// If the simple editor fails too, try external editor as last resort
 	if err != nil {
 		fmt.Println("シンプルエディタに失敗しました。外部エディタを使用します。")
-		externalContent, externalErr := EditWithExternalEditor(initialContent)
+		externalContent, externalErr := EditWithExternalEditor(os.Stdout, initialContent)
 		if externalErr != nil {
 			fmt.Printf("外部エディタも失敗しました: %v\n", externalErr)
 			fmt.Println("編集操作をキャンセルします。")
@@ -0,0 +1,187 @@
+package interactive
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/econron/wamon/internal/i18n"
+	"github.com/econron/wamon/internal/models"
+)
+
+// ErrorCommentPrefix marks the lines added to the editor content to point out
+// invalid fields. They are removed again before the content is parsed.
+const ErrorCommentPrefix = "#!"
+
+// DateLayout is the format of the date line in the editor
+const DateLayout = "2006-01-02 15:04"
+
+// dateLayouts are the formats accepted for the date line
+var dateLayouts = []string{
+	DateLayout,
+	"2006-01-02 15:04:05",
+	"2006/01/02 15:04",
+	"2006-01-02",
+	"2006/01/02",
+}
+
+// labelKeys are all the labels of the editor content. A label directly below
+// another one means the field above it was left empty.
+var labelKeys = []string{
+	"editor.research_topic",
+	"editor.program_title",
+	"editor.tags",
+	"editor.category_label",
+	"editor.date_label",
+	"editor.satisfaction_label",
+}
+
+// FieldError describes an invalid field in the editor content
+type FieldError struct {
+	Line    int // index of the offending line, after error comments are removed
+	Message string
+}
+
+// Error returns the message of the field error
+func (e FieldError) Error() string {
+	return e.Message
+}
+
+// ParseEditorContent reads the labeled fields of the editor content into the entry.
+// The entry is only changed when every field is valid; otherwise the problems are returned.
+func ParseEditorContent(content string, entry *models.Entry) []FieldError {
+	parsed := *entry
+	var errs []FieldError
+
+	lines := strings.Split(StripErrorComments(content), "\n")
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if value, ok := labelValue(line, "editor.category_label"); ok {
+			category, ok := parseCategoryValue(value)
+			if !ok {
+				errs = append(errs, FieldError{Line: i, Message: i18n.T("editor.invalid_category", value, categoryCodes())})
+				continue
+			}
+			parsed.Category = category
+		} else if value, ok := labelValue(line, "editor.date_label"); ok {
+			// Keep the seconds of the original time when the line was not changed
+			if value == entry.CreatedAt.Local().Format(DateLayout) {
+				continue
+			}
+			date, ok := parseDateValue(value)
+			if !ok {
+				errs = append(errs, FieldError{Line: i, Message: i18n.T("editor.invalid_date", value)})
+				continue
+			}
+			parsed.CreatedAt = date
+		} else if value, ok := labelValue(line, "editor.satisfaction_label"); ok {
+			// The value is written as "4/5"
+			number := strings.TrimSpace(strings.SplitN(value, "/", 2)[0])
+			satisfaction, err := strconv.Atoi(number)
+			if err != nil || satisfaction < 1 || satisfaction > 5 {
+				errs = append(errs, FieldError{Line: i, Message: i18n.T("editor.invalid_satisfaction", value)})
+				continue
+			}
+			parsed.Satisfaction = satisfaction
+		} else if HasLabel(line, "editor.research_topic") {
+			parsed.ResearchTopic = nextValue(lines, i)
+		} else if HasLabel(line, "editor.program_title") {
+			parsed.ProgramTitle = nextValue(lines, i)
+		} else if HasLabel(line, "editor.tags") {
+			parsed.Tags = models.ParseTags(nextValue(lines, i))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	*entry = parsed
+	return nil
+}
+
+// AnnotateErrors returns the content with an error comment above each invalid line
+func AnnotateErrors(content string, errs []FieldError) string {
+	messages := make(map[int][]string)
+	for _, err := range errs {
+		messages[err.Line] = append(messages[err.Line], err.Message)
+	}
+
+	annotated := []string{ErrorCommentPrefix + " " + i18n.T("editor.error_header")}
+	for i, line := range strings.Split(StripErrorComments(content), "\n") {
+		for _, message := range messages[i] {
+			annotated = append(annotated, ErrorCommentPrefix+" "+i18n.T("editor.error", message))
+		}
+		annotated = append(annotated, line)
+	}
+	return strings.Join(annotated, "\n")
+}
+
+// StripErrorComments removes the lines added by AnnotateErrors
+func StripErrorComments(content string) string {
+	lines := strings.Split(content, "\n")
+	var kept []string
+	for _, line := range lines {
+		if !strings.HasPrefix(strings.TrimSpace(line), ErrorCommentPrefix) {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
+
+// labelValue returns the text after the label for key when line starts with it
+func labelValue(line, key string) (string, bool) {
+	for _, label := range i18n.All(key) {
+		if strings.HasPrefix(line, label) {
+			return strings.TrimSpace(strings.TrimPrefix(line, label)), true
+		}
+	}
+	return "", false
+}
+
+// nextValue returns the line below the label at index i, or "" when the field was left empty
+func nextValue(lines []string, i int) string {
+	if i+1 >= len(lines) {
+		return ""
+	}
+	next := strings.TrimSpace(lines[i+1])
+	for _, key := range labelKeys {
+		if HasLabel(next, key) {
+			return ""
+		}
+	}
+	return next
+}
+
+// parseCategoryValue accepts a category code, legacy name or display name in any language
+func parseCategoryValue(value string) (models.Category, bool) {
+	if category, ok := models.ParseCategory(value); ok {
+		return category, true
+	}
+	for _, category := range models.Categories() {
+		for _, label := range i18n.All("category." + string(category)) {
+			if strings.EqualFold(value, label) {
+				return category, true
+			}
+		}
+	}
+	return "", false
+}
+
+// parseDateValue parses the date line in the local time zone
+func parseDateValue(value string) (time.Time, bool) {
+	for _, layout := range dateLayouts {
+		if date, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return date, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// categoryCodes lists the valid category codes for error messages
+func categoryCodes() string {
+	var codes []string
+	for _, category := range models.Categories() {
+		codes = append(codes, string(category))
+	}
+	return strings.Join(codes, ", ")
+}
@@ -0,0 +1,120 @@
+package interactive
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/econron/wamon/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseEditorContent(t *testing.T) {
+	entry := &models.Entry{Category: models.ResearchAndProgram}
+	content := "カテゴリ: 調べてプログラマ\n\n調べたこと:\n  Goのテンプレート  \n\nProgram written:\nwamon\n\nタグ:\nGo, #cli\n"
+
+	assert.Empty(t, ParseEditorContent(content, entry))
+	assert.Equal(t, "Goのテンプレート", entry.ResearchTopic)
+	assert.Equal(t, "wamon", entry.ProgramTitle)
+	assert.Equal(t, []string{"go", "cli"}, entry.Tags)
+}
+
+func TestParseEditorContentEditableFields(t *testing.T) {
+	created := time.Date(2025, 4, 1, 9, 30, 45, 0, time.Local)
+	entry := &models.Entry{Category: models.Research, Satisfaction: 2, CreatedAt: created}
+
+	// Unchanged date lines keep the seconds
+	assert.Empty(t, ParseEditorContent("日時: 2025-04-01 09:30\n満足度: 4/5", entry))
+	assert.Equal(t, created, entry.CreatedAt)
+	assert.Equal(t, 4, entry.Satisfaction)
+
+	content := "Category: Programming\nDate: 2025-03-31 22:15\nSatisfaction: 5\n"
+	assert.Empty(t, ParseEditorContent(content, entry))
+	assert.Equal(t, models.Programming, entry.Category)
+	assert.Equal(t, time.Date(2025, 3, 31, 22, 15, 0, 0, time.Local), entry.CreatedAt)
+	assert.Equal(t, 5, entry.Satisfaction)
+
+	assert.Empty(t, ParseEditorContent("カテゴリ: both\n日時: 2025/04/02", entry))
+	assert.Equal(t, models.ResearchAndProgram, entry.Category)
+	assert.Equal(t, time.Date(2025, 4, 2, 0, 0, 0, 0, time.Local), entry.CreatedAt)
+}
+
+func TestParseEditorContentEmptyField(t *testing.T) {
+	entry := &models.Entry{ResearchTopic: "old", ProgramTitle: "old"}
+
+	assert.Empty(t, ParseEditorContent("調べたこと:\n書いたプログラム:\nwamon", entry))
+	assert.Equal(t, "", entry.ResearchTopic)
+	assert.Equal(t, "wamon", entry.ProgramTitle)
+}
+
+func TestParseEditorContentInvalid(t *testing.T) {
+	entry := &models.Entry{Category: models.Research, Satisfaction: 3, ResearchTopic: "old"}
+	content := "カテゴリ: 料理\n日時: 昨日\n\n調べたこと:\nnew\n\n満足度: 6/5"
+
+	errs := ParseEditorContent(content, entry)
+	assert.Len(t, errs, 3)
+	assert.Equal(t, 0, errs[0].Line)
+	assert.Contains(t, errs[0].Message, "料理")
+	assert.Equal(t, 1, errs[1].Line)
+	assert.Equal(t, 6, errs[2].Line)
+
+	// Nothing is applied while some field is invalid
+	assert.Equal(t, models.Research, entry.Category)
+	assert.Equal(t, "old", entry.ResearchTopic)
+	assert.Equal(t, 3, entry.Satisfaction)
+}
+
+func TestAnnotateErrors(t *testing.T) {
+	content := "#! 古いエラー\nカテゴリ: 料理\n満足度: 3/5"
+	errs := []FieldError{{Line: 0, Message: "不明なカテゴリです"}}
+
+	annotated := AnnotateErrors(content, errs)
+	lines := strings.Split(annotated, "\n")
+	assert.Len(t, lines, 4)
+	assert.True(t, strings.HasPrefix(lines[0], ErrorCommentPrefix))
+	assert.Equal(t, "#! エラー: 不明なカテゴリです", lines[1])
+	assert.Equal(t, "カテゴリ: 料理", lines[2])
+	assert.Equal(t, "カテゴリ: 料理\n満足度: 3/5", StripErrorComments(annotated))
+}
+
+func TestComposeEntryReopensOnErrors(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	original := editContent
+	defer func() { editContent = original }()
+
+	var shown []string
+	responses := []string{"カテゴリ: 料理\n満足度: 9/5", "カテゴリ: programming\n満足度: 4/5"}
+	editContent = func(out io.Writer, content string) (string, error) {
+		shown = append(shown, content)
+		response := responses[0]
+		responses = responses[1:]
+		return response, nil
+	}
+
+	entry := &models.Entry{Category: models.Research, Satisfaction: 2, CreatedAt: time.Now()}
+	assert.NoError(t, NewPrompter().ComposeEntry(entry, "", nil))
+	assert.Equal(t, models.Programming, entry.Category)
+	assert.Equal(t, 4, entry.Satisfaction)
+
+	assert.Len(t, shown, 2)
+	assert.Contains(t, shown[1], "#! エラー: 不明なカテゴリです: 料理")
+	assert.Contains(t, shown[1], "#! エラー: 満足度は1から5の数字で指定してください: 9/5")
+}
+
+func TestComposeEntryCancel(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	original := editContent
+	defer func() { editContent = original }()
+
+	editContent = func(out io.Writer, content string) (string, error) {
+		return "#! エラー\n\n", nil
+	}
+	assert.Error(t, NewPrompter().ComposeEntry(&models.Entry{Category: models.Research, CreatedAt: time.Now()}, "", nil))
+
+	editContent = func(out io.Writer, content string) (string, error) {
+		return "", errors.New("editor failed")
+	}
+	assert.Error(t, NewPrompter().ComposeEntry(&models.Entry{Category: models.Research, CreatedAt: time.Now()}, "", nil))
+}
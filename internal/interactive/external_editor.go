@@ -1,9 +1,15 @@
 package interactive
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
 	"os/exec"
+	"time"
+
+	"github.com/econron/wamon/internal/i18n"
 )
 
 // EditorPriority defines the order in which editors should be tried
@@ -31,31 +37,30 @@ func findEditor() (string, error) {
 		}
 	}
 
-	return "", fmt.Errorf("利用可能なエディタが見つかりません")
+	return "", errors.New(i18n.T("editor.not_found"))
 }
 
-// EditWithExternalEditor opens the given text in an external editor
-func EditWithExternalEditor(initialContent string) (string, error) {
+// EditWithExternalEditor opens the given text in an external editor.
+// Messages about the editor are written to out.
+func EditWithExternalEditor(out io.Writer, initialContent string) (string, error) {
 	// Create a temporary file
 	tmpFile, err := os.CreateTemp("", "wamon-*.txt")
 	if err != nil {
-		return "", fmt.Errorf("一時ファイルの作成に失敗しました: %v", err)
+		return "", errors.New(i18n.T("editor.tempfile_create_error", err))
 	}
 	tmpPath := tmpFile.Name()
 	defer os.Remove(tmpPath) // Cleanup on exit
 
 	// Write initial content to the file
 	if _, err := tmpFile.WriteString(initialContent); err != nil {
-		return "", fmt.Errorf("一時ファイルの書き込みに失敗しました: %v", err)
+		return "", errors.New(i18n.T("editor.tempfile_write_error", err))
 	}
 	tmpFile.Close()
 
 	// Find an available editor
 	editor, err := findEditor()
 	if err != nil {
-		fmt.Println("警告: システムで標準のエディタが見つかりませんでした。")
-		fmt.Println("vimまたはviをインストールすることをお勧めします。")
-		fmt.Println("または、EDITOR環境変数を設定してください。")
+		fmt.Fprintln(out, i18n.T("editor.not_found_hint"))
 		return "", err
 	}
 
@@ -72,32 +77,29 @@ func EditWithExternalEditor(initialContent string) (string, error) {
 	cmd.Stderr = os.Stderr
 
 	// Run the editor
-	fmt.Printf("エディタ '%s' を使用して編集します...\n", editor)
+	fmt.Fprintln(out, i18n.T("editor.using", editor))
 	if editor == "vim" || editor == "vi" {
-		fmt.Println("vim/viの操作方法:")
-		fmt.Println("ESC: 編集モードを終了")
-		fmt.Println(":q: 保存せずに終了")
-		fmt.Println(":wq: 保存して終了")
-		fmt.Println(":x: 保存して終了")
-		fmt.Println(":w: 保存")
-		fmt.Println("------------------------")
+		fmt.Fprintln(out, i18n.T("editor.vim_help"))
+		fmt.Fprintln(out, "------------------------")
 	}
 
-	if err := cmd.Run(); err != nil {
-		fmt.Printf("エディタの起動に失敗しました。システムで '%s' が利用可能かどうか確認してください。\n", editor)
+	start := time.Now()
+	err = cmd.Run()
+	slog.Debug("editor closed", "editor", editor, "file", tmpPath, "duration", time.Since(start), "error", err)
+	if err != nil {
+		fmt.Fprintln(out, i18n.T("editor.launch_failed", editor))
 		// Try to provide helpful advice
 		if editor == "vim" || editor == "vi" {
-			fmt.Println("vim/viがインストールされていない場合は、別のエディタを試してみてください。")
-			fmt.Println("例: export EDITOR=nano")
+			fmt.Fprintln(out, i18n.T("editor.vim_missing_hint"))
 		}
-		return "", fmt.Errorf("エディタの実行に失敗しました: %v", err)
+		return "", errors.New(i18n.T("editor.run_error", err))
 	}
 
 	// Read the edited content
 	content, err := os.ReadFile(tmpPath)
 	if err != nil {
-		fmt.Println("編集後のファイルの読み込みに失敗しました。変更内容が失われた可能性があります。")
-		return "", fmt.Errorf("編集後のファイルの読み込みに失敗しました: %v", err)
+		fmt.Fprintln(out, i18n.T("editor.read_back_lost"))
+		return "", errors.New(i18n.T("editor.read_back_error", err))
 	}
 
 	return string(content), nil
@@ -1,9 +1,11 @@
 package interactive
 
 import (
+	"bytes"
 	"os"
 	"testing"
 
+	"github.com/econron/wamon/internal/i18n"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -49,3 +51,14 @@ func TestEditWithExternalEditor(t *testing.T) {
 	tmpFile.Close()
 	os.Remove(tmpFile.Name())
 }
+
+func TestEditWithExternalEditorWritesToOut(t *testing.T) {
+	// Without an editor the hint is written to out, not to stdout
+	t.Setenv("EDITOR", "non-existent-editor")
+	t.Setenv("PATH", t.TempDir())
+
+	var out bytes.Buffer
+	_, err := EditWithExternalEditor(&out, "テスト用テキスト")
+	assert.Error(t, err)
+	assert.Equal(t, i18n.T("editor.not_found_hint")+"\n", out.String())
+}
@@ -0,0 +1,360 @@
+package interactive
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/econron/wamon/internal/i18n"
+	"github.com/econron/wamon/internal/models"
+	"github.com/econron/wamon/internal/templates"
+)
+
+// ErrQuit is returned by Journal.Run when the user quits
+var ErrQuit = errors.New("quit")
+
+// BackCommand returns to the previous step of the journal
+const BackCommand = "back"
+
+// journalStep is a state of the interactive journal
+type journalStep int
+
+const (
+	stepCategory journalStep = iota
+	stepContent
+	stepSatisfaction
+	stepDone
+)
+
+// String names the step in the log
+func (s journalStep) String() string {
+	switch s {
+	case stepCategory:
+		return "category"
+	case stepContent:
+		return "content"
+	case stepSatisfaction:
+		return "satisfaction"
+	default:
+		return "done"
+	}
+}
+
+// Journal walks the user through recording an entry: choosing the category,
+// writing the content in the editor and rating the satisfaction. Invalid answers
+// are asked again, "back" returns to the previous step and "quit" stops at any step.
+// The edited text and the entries finished in the session are kept as a draft
+// until they are saved, so nothing is lost.
+type Journal struct {
+	prompter *Prompter
+	// TemplateName selects the editor template; empty uses the category's template
+	TemplateName string
+	// DraftPath is where the draft is kept; empty disables drafts
+	DraftPath string
+	// Recent returns the entries templates can refer to
+	Recent func(entry *models.Entry) ([]*models.Entry, error)
+	// Now returns the current time; defaults to time.Now
+	Now func() time.Time
+
+	// returned holds the IDs of the entries Run has returned, so each gets a new one
+	returned map[string]bool
+	// offered is set once the user has been asked about resuming a draft
+	offered bool
+	// current is the draft of the entry being written, nil between entries
+	current *Draft
+	// finished holds the entries Run returned that are not saved yet
+	finished []Draft
+	// resumed is the unfinished entry of an accepted draft, for the next Run
+	resumed *Draft
+	// restored holds the finished entries of an accepted draft, for Resume
+	restored []*models.Entry
+}
+
+// NewJournal creates a journal that asks its questions with the prompter
+func (p *Prompter) NewJournal() *Journal {
+	return &Journal{prompter: p, Now: time.Now, returned: make(map[string]bool)}
+}
+
+// Run asks for the entry step by step and returns it once everything is answered.
+// It returns ErrQuit when the user quits; the draft is kept in that case.
+// Run can be called again to record more entries in the same session.
+func (j *Journal) Run() (*models.Entry, error) {
+	// Entries recorded within the same second get the next free ID and time
+	entry := &models.Entry{}
+	entry.ID, entry.CreatedAt = models.NewID(j.Now(), func(id string) bool { return j.returned[id] })
+
+	step := stepCategory
+	content := ""
+	if draft := j.resumeDraft(); draft != nil {
+		entry.Category = draft.Category
+		content = draft.Content
+		step = stepContent
+	}
+
+	for step != stepDone {
+		slog.Debug("journal step", "entry", entry.ID, "step", step)
+		switch step {
+		case stepCategory:
+			category, command, err := j.askCategory()
+			if err != nil {
+				return nil, err
+			}
+			switch command {
+			case "quit":
+				return nil, ErrQuit
+			case BackCommand:
+				fmt.Fprintln(j.prompter.out, i18n.T("journal.first_step"))
+				continue
+			}
+			if category != entry.Category {
+				// The template depends on the category
+				content = ""
+			}
+			entry.Category = category
+			step = stepContent
+
+		case stepContent:
+			if content == "" {
+				built, err := j.template(entry)
+				if err != nil {
+					return nil, err
+				}
+				content = built
+			}
+			edited, err := j.prompter.ComposeContent(entry, content, func(text string) {
+				j.saveDraft(entry, text)
+			})
+			if err == ErrEmptyContent {
+				// Emptying the editor goes back to the category
+				fmt.Fprintln(j.prompter.out, i18n.T("journal.back_to_category"))
+				content = ""
+				step = stepCategory
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			content = edited
+			step = stepSatisfaction
+
+		case stepSatisfaction:
+			satisfaction, command, err := j.askSatisfaction()
+			if err != nil {
+				return nil, err
+			}
+			switch command {
+			case "quit":
+				return nil, ErrQuit
+			case BackCommand:
+				step = stepContent
+				continue
+			}
+			entry.Satisfaction = satisfaction
+			step = stepDone
+		}
+	}
+
+	j.returned[entry.ID] = true
+	j.current = nil
+	j.finished = append(j.finished, Draft{
+		ID:           entry.ID,
+		Category:     entry.Category,
+		Content:      content,
+		Satisfaction: entry.Satisfaction,
+		CreatedAt:    entry.CreatedAt,
+	})
+	if err := j.writeDraft(); err != nil {
+		fmt.Fprintln(j.prompter.out, i18n.T("journal.draft_error", err))
+	}
+	return entry, nil
+}
+
+// Resume offers to continue from a draft left by an earlier session and returns
+// the entries it holds that were already finished. The unfinished entry of the
+// draft is continued by the next Run.
+func (j *Journal) Resume() []*models.Entry {
+	j.offerDraft()
+	restored := j.restored
+	j.restored = nil
+	return restored
+}
+
+// Unfinished reports whether the next Run continues an entry restored from a draft
+func (j *Journal) Unfinished() bool {
+	return j.resumed != nil
+}
+
+// Finish is called once the entries Run and Resume returned are saved. It removes
+// them from the draft; a draft of an entry that was left unfinished is kept.
+func (j *Journal) Finish() error {
+	j.finished = nil
+	return j.writeDraft()
+}
+
+// HasDraft reports whether a draft is waiting to be resumed
+func (j *Journal) HasDraft() bool {
+	if j.DraftPath == "" {
+		return false
+	}
+	draft, err := LoadDraft(j.DraftPath)
+	return err == nil && draft != nil
+}
+
+// askCategory asks until a valid category or a command is answered
+func (j *Journal) askCategory() (models.Category, string, error) {
+	for {
+		j.prompter.printCategoryChoices()
+		input, err := j.prompter.readLine()
+		if err != nil {
+			return "", "", err
+		}
+		if command := journalCommand(input); command != "" {
+			return "", command, nil
+		}
+		category, err := parseCategoryChoice(input)
+		if err == nil {
+			return category, "", nil
+		}
+		fmt.Fprintln(j.prompter.out, err)
+	}
+}
+
+// askSatisfaction asks until a valid satisfaction or a command is answered
+func (j *Journal) askSatisfaction() (int, string, error) {
+	for {
+		j.prompter.printSatisfactionPrompt()
+		input, err := j.prompter.readLine()
+		if err != nil {
+			return 0, "", err
+		}
+		if command := journalCommand(input); command != "" {
+			return 0, command, nil
+		}
+		satisfaction, err := parseSatisfaction(input)
+		if err == nil {
+			return satisfaction, "", nil
+		}
+		fmt.Fprintln(j.prompter.out, err)
+	}
+}
+
+// journalCommand returns "quit" or "back" when the answer is one of the commands
+func journalCommand(input string) string {
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "quit":
+		return "quit"
+	case BackCommand:
+		return BackCommand
+	default:
+		return ""
+	}
+}
+
+// template builds the first editor content for the entry
+func (j *Journal) template(entry *models.Entry) (string, error) {
+	var recent []*models.Entry
+	if j.Recent != nil {
+		var err error
+		recent, err = j.Recent(entry)
+		if err != nil {
+			return "", err
+		}
+	}
+	return templates.Build(templates.Dir(), j.TemplateName, templates.NewData(entry, recent))
+}
+
+// resumeDraft returns the unfinished entry of an accepted draft, offering the
+// draft first if Resume has not.
+func (j *Journal) resumeDraft() *Draft {
+	j.offerDraft()
+	draft := j.resumed
+	j.resumed = nil
+	return draft
+}
+
+// offerDraft offers to continue from a draft left by an earlier session.
+// A draft the user declines is deleted. It is only offered once per session.
+func (j *Journal) offerDraft() {
+	if j.DraftPath == "" || j.offered {
+		return
+	}
+	j.offered = true
+
+	draft, err := LoadDraft(j.DraftPath)
+	if err != nil {
+		fmt.Fprintln(j.prompter.out, i18n.T("journal.draft_error", err))
+		return
+	}
+	if draft == nil || (!draft.Category.Valid() && len(draft.Finished) == 0) {
+		return
+	}
+
+	savedAt := draft.SavedAt.Local().Format("2006-01-02 15:04")
+	question := i18n.T("journal.draft_found", savedAt, i18n.CategoryLabel(draft.Category))
+	if len(draft.Finished) > 0 {
+		count := len(draft.Finished)
+		if draft.Category.Valid() {
+			count++
+		}
+		question = i18n.T("journal.draft_found_entries", savedAt, count)
+	}
+	resume, err := j.prompter.AskYesNo(question, true)
+	if err != nil {
+		return
+	}
+	if !resume {
+		if err := RemoveDraft(j.DraftPath); err != nil {
+			fmt.Fprintln(j.prompter.out, i18n.T("journal.draft_error", err))
+		}
+		return
+	}
+
+	for _, finished := range draft.Finished {
+		entry, err := finished.Entry()
+		if err != nil {
+			fmt.Fprintln(j.prompter.out, i18n.T("journal.draft_error", err))
+			continue
+		}
+		j.returned[entry.ID] = true
+		j.restored = append(j.restored, entry)
+		j.finished = append(j.finished, finished)
+	}
+	if draft.Category.Valid() {
+		current := *draft
+		current.Finished = nil
+		j.resumed = &current
+		j.current = &current
+	}
+}
+
+// saveDraft keeps the edited text so it survives quitting or a failed save
+func (j *Journal) saveDraft(entry *models.Entry, content string) {
+	if j.DraftPath == "" {
+		return
+	}
+	j.current = &Draft{ID: entry.ID, Category: entry.Category, Content: content, CreatedAt: entry.CreatedAt}
+	if err := j.writeDraft(); err != nil {
+		fmt.Fprintln(j.prompter.out, i18n.T("journal.draft_error", err))
+		return
+	}
+	slog.Debug("draft saved", "path", j.DraftPath, "bytes", len(content), "finished", len(j.finished))
+}
+
+// writeDraft writes the entry being written and the finished entries that are not
+// saved yet to the draft file, or removes it when there are none
+func (j *Journal) writeDraft() error {
+	if j.DraftPath == "" {
+		return nil
+	}
+	if j.current == nil && len(j.finished) == 0 {
+		return RemoveDraft(j.DraftPath)
+	}
+	draft := &Draft{}
+	if j.current != nil {
+		*draft = *j.current
+	}
+	draft.Finished = j.finished
+	return draft.Save(j.DraftPath)
+}
@@ -0,0 +1,229 @@
+package interactive
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/econron/wamon/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestJournal creates a journal that replays script at a fixed time
+func newTestJournal(script string, out *bytes.Buffer) *Journal {
+	journal := NewScriptPrompter(strings.NewReader(script), out).NewJournal()
+	journal.Now = func() time.Time { return time.Date(2025, 4, 1, 9, 30, 0, 0, time.Local) }
+	return journal
+}
+
+func TestJournalRun(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	var out bytes.Buffer
+	journal := newTestJournal("1\n調べたこと:\nGo\n.\n4\n", &out)
+
+	entry, err := journal.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, "20250401093000", entry.ID)
+	assert.Equal(t, models.Research, entry.Category)
+	assert.Equal(t, "Go", entry.ResearchTopic)
+	assert.Equal(t, 4, entry.Satisfaction)
+}
+
+func TestJournalRetriesInvalidAnswers(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	var out bytes.Buffer
+	journal := newTestJournal("9\nfoo\n2\n.\n0\nとても\n5\n", &out)
+
+	entry, err := journal.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, models.Programming, entry.Category)
+	assert.Equal(t, 5, entry.Satisfaction)
+	assert.Equal(t, 2, strings.Count(out.String(), "無効な選択です"))
+	assert.Equal(t, 2, strings.Count(out.String(), "満足度は1から5の数字で入力してください"))
+}
+
+func TestJournalBack(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	var out bytes.Buffer
+	// back at the first step stays there; back from the satisfaction reopens the content
+	script := "back\n1\n調べたこと:\nGo\n.\nback\n調べたこと:\nRust\n.\n3\n"
+	journal := newTestJournal(script, &out)
+
+	entry, err := journal.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, "Rust", entry.ResearchTopic)
+	assert.Equal(t, 3, entry.Satisfaction)
+	assert.Contains(t, out.String(), "これが最初のステップです")
+}
+
+func TestJournalEmptyContentReturnsToCategory(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	var out bytes.Buffer
+	journal := newTestJournal("1\n\n.\n2\n書いたプログラム:\nwamon\n.\n4\n", &out)
+
+	entry, err := journal.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, models.Programming, entry.Category)
+	assert.Equal(t, "wamon", entry.ProgramTitle)
+	assert.Contains(t, out.String(), "カテゴリの選択に戻ります")
+}
+
+func TestJournalQuit(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	_, err := newTestJournal("quit\n", &bytes.Buffer{}).Run()
+	assert.Equal(t, ErrQuit, err)
+
+	_, err = newTestJournal("QUIT\n", &bytes.Buffer{}).Run()
+	assert.Equal(t, ErrQuit, err)
+
+	_, err = newTestJournal("1\n.\nquit\n", &bytes.Buffer{}).Run()
+	assert.Equal(t, ErrQuit, err)
+
+	// Running out of answers is an error, not a quit
+	_, err = newTestJournal("1\n", &bytes.Buffer{}).Run()
+	assert.Error(t, err)
+	assert.NotEqual(t, ErrQuit, err)
+}
+
+func TestJournalDraft(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "draft.json")
+
+	// The edited text is kept when the user quits
+	journal := newTestJournal("1\n調べたこと:\nSQLite\n.\nquit\n", &bytes.Buffer{})
+	journal.DraftPath = path
+	_, err := journal.Run()
+	assert.Equal(t, ErrQuit, err)
+	assert.True(t, journal.HasDraft())
+
+	// The next session resumes from the draft at the content step
+	var out bytes.Buffer
+	journal = newTestJournal("y\n.\n4\n", &out)
+	journal.DraftPath = path
+	entry, err := journal.Run()
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "下書き")
+	assert.Equal(t, models.Research, entry.Category)
+	assert.Equal(t, "SQLite", entry.ResearchTopic)
+
+	assert.NoError(t, journal.Finish())
+	assert.False(t, journal.HasDraft())
+}
+
+func TestJournalDiscardDraft(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "draft.json")
+	assert.NoError(t, (&Draft{Category: models.Research, Content: "調べたこと:\nold"}).Save(path))
+
+	journal := newTestJournal("n\n2\n.\n3\n", &bytes.Buffer{})
+	journal.DraftPath = path
+	entry, err := journal.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, models.Programming, entry.Category)
+	assert.Empty(t, entry.ResearchTopic)
+
+	// Declining removes the old draft; the new one holds the entry written since
+	draft, err := LoadDraft(path)
+	assert.NoError(t, err)
+	if assert.NotNil(t, draft) && assert.Len(t, draft.Finished, 1) {
+		assert.Empty(t, draft.Category)
+		assert.Equal(t, models.Programming, draft.Finished[0].Category)
+		assert.NotContains(t, draft.Finished[0].Content, "old")
+	}
+}
+
+func TestJournalRunAgain(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "draft.json")
+	journal := newTestJournal("1\n調べたこと:\nGo\n.\n4\n2\n書いたプログラム:\nwamon\n.\n3\n", &bytes.Buffer{})
+	journal.DraftPath = path
+
+	first, err := journal.Run()
+	assert.NoError(t, err)
+	second, err := journal.Run()
+	assert.NoError(t, err)
+
+	// Entries recorded within the same second still get their own IDs
+	assert.Equal(t, "20250401093000", first.ID)
+	assert.Equal(t, "20250401093001", second.ID)
+	assert.Equal(t, first.CreatedAt.Add(time.Second), second.CreatedAt)
+	assert.Equal(t, "wamon", second.ProgramTitle)
+
+	assert.NoError(t, journal.Finish())
+	assert.False(t, journal.HasDraft())
+}
+
+func TestJournalFinishKeepsUnfinishedDraft(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "draft.json")
+	journal := newTestJournal("1\n.\n4\n2\n書いたプログラム:\nwamon\n.\nquit\n", &bytes.Buffer{})
+	journal.DraftPath = path
+
+	_, err := journal.Run()
+	assert.NoError(t, err)
+	_, err = journal.Run()
+	assert.Equal(t, ErrQuit, err)
+
+	// The text of the second entry was never returned, so it stays as the draft
+	assert.NoError(t, journal.Finish())
+	draft, err := LoadDraft(path)
+	assert.NoError(t, err)
+	if assert.NotNil(t, draft) {
+		assert.Contains(t, draft.Content, "wamon")
+	}
+}
+
+func TestJournalDraftKeepsFinishedEntries(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "draft.json")
+
+	// Two entries are written but never saved, and a third is left in the editor
+	script := "1\n調べたこと:\nGo\n.\n4\n2\n書いたプログラム:\nwamon\n.\n3\n1\n調べたこと:\nSQLite\n.\nquit\n"
+	journal := newTestJournal(script, &bytes.Buffer{})
+	journal.DraftPath = path
+	for i := 0; i < 2; i++ {
+		_, err := journal.Run()
+		assert.NoError(t, err)
+	}
+	_, err := journal.Run()
+	assert.Equal(t, ErrQuit, err)
+
+	draft, err := LoadDraft(path)
+	assert.NoError(t, err)
+	if assert.NotNil(t, draft) {
+		assert.Len(t, draft.Finished, 2)
+		assert.Contains(t, draft.Content, "SQLite")
+	}
+
+	// The next session restores the finished entries and continues the third
+	var out bytes.Buffer
+	journal = newTestJournal("y\n.\n5\n", &out)
+	journal.DraftPath = path
+	restored := journal.Resume()
+	assert.Contains(t, out.String(), "(3件)")
+	if assert.Len(t, restored, 2) {
+		assert.Equal(t, "20250401093000", restored[0].ID)
+		assert.Equal(t, "Go", restored[0].ResearchTopic)
+		assert.Equal(t, 4, restored[0].Satisfaction)
+		assert.Equal(t, "20250401093001", restored[1].ID)
+		assert.Equal(t, "wamon", restored[1].ProgramTitle)
+	}
+	assert.True(t, journal.Unfinished())
+
+	entry, err := journal.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, "SQLite", entry.ResearchTopic)
+	assert.Equal(t, "20250401093002", entry.ID)
+
+	// Everything stays in the draft until it is saved
+	draft, err = LoadDraft(path)
+	assert.NoError(t, err)
+	if assert.NotNil(t, draft) {
+		assert.Len(t, draft.Finished, 3)
+	}
+	assert.NoError(t, journal.Finish())
+	assert.False(t, journal.HasDraft())
+}
@@ -2,92 +2,201 @@ package interactive
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
 
+	"github.com/econron/wamon/internal/i18n"
 	"github.com/econron/wamon/internal/models"
+	"github.com/econron/wamon/internal/templates"
 )
 
+// ScriptCommentPrefix starts a comment line in a script
+const ScriptCommentPrefix = "#"
+
+// ScriptBlockEnd ends the editor content in a script
+const ScriptBlockEnd = "."
+
+// ErrEmptyContent is returned when the editor content was emptied to cancel
+var ErrEmptyContent error = emptyContentError{}
+
+// emptyContentError is localized when it is printed, after the language is known
+type emptyContentError struct{}
+
+// Error returns the localized cancel message
+func (emptyContentError) Error() string {
+	return i18n.T("editor.cancelled")
+}
+
 // Prompter handles interactive CLI prompts
 type Prompter struct {
 	reader *bufio.Reader
+	out    io.Writer
+	// script is set when the answers are replayed from a file instead of typed
+	script bool
 }
 
-// NewPrompter creates a new Prompter
+// NewPrompter creates a Prompter that reads from stdin and writes to stdout
 func NewPrompter() *Prompter {
+	return NewPrompterWithIO(os.Stdin, os.Stdout)
+}
+
+// NewPrompterWithIO creates a Prompter that reads answers from in and writes prompts to out
+func NewPrompterWithIO(in io.Reader, out io.Writer) *Prompter {
 	return &Prompter{
-		reader: bufio.NewReader(os.Stdin),
+		reader: bufio.NewReader(in),
+		out:    out,
 	}
 }
 
-// EditEntry prompts the user to edit the entry content interactively with a TUI editor
-func (p *Prompter) EditEntry(entry *models.Entry) error {
-	fmt.Println("エントリの編集を開始します...")
+// NewScriptPrompter creates a Prompter that replays the answers of a script.
+// Each line answers one prompt and lines starting with "#" are skipped.
+// Where an editor would be opened, the lines up to a line with a single "."
+// are used as the edited content; an empty block keeps the template as it is.
+func NewScriptPrompter(script io.Reader, out io.Writer) *Prompter {
+	p := NewPrompterWithIO(script, out)
+	p.script = true
+	return p
+}
 
-	// Create initial content
-	initialContent := fmt.Sprintf("カテゴリ: %s\n\n", entry.Category)
-	if entry.Category == models.Research || entry.Category == models.ResearchAndProgram {
-		initialContent += fmt.Sprintf("調べたこと:\n%s\n\n", entry.ResearchTopic)
-	}
-	if entry.Category == models.Programming || entry.Category == models.ResearchAndProgram {
-		initialContent += fmt.Sprintf("書いたプログラム:\n%s\n\n", entry.ProgramTitle)
-	}
-	initialContent += fmt.Sprintf("満足度: %d/5\n", entry.Satisfaction)
+// EditEntry prompts the user to edit the entry content interactively with a TUI editor.
+// recent holds the entries recorded before it, which templates can refer to.
+func (p *Prompter) EditEntry(entry *models.Entry, recent []*models.Entry) error {
+	fmt.Fprintln(p.out, i18n.T("prompt.edit_start"))
+	return p.ComposeEntry(entry, "", recent)
+}
+
+// editContent opens content in an editor; tests replace it
+var editContent = EditWithExternalEditor
 
-	// Edit content using external editor
-	editedContent, err := EditWithExternalEditor(initialContent)
+// ComposeEntry renders the template for the entry, opens it in an external editor
+// and reads the edited fields back into the entry. An empty templateName selects
+// the template of the entry's category.
+func (p *Prompter) ComposeEntry(entry *models.Entry, templateName string, recent []*models.Entry) error {
+	content, err := templates.Build(templates.Dir(), templateName, templates.NewData(entry, recent))
 	if err != nil {
-		return fmt.Errorf("編集エラー: %v", err)
+		return err
 	}
+	_, err = p.ComposeContent(entry, content, nil)
+	return err
+}
 
-	// Parse the edited content
-	lines := strings.Split(editedContent, "\n")
-	for i, line := range lines {
-		line = strings.TrimSpace(line)
-		if strings.HasPrefix(line, "調べたこと:") {
-			if i+1 < len(lines) {
-				entry.ResearchTopic = strings.TrimSpace(lines[i+1])
-			}
-		} else if strings.HasPrefix(line, "書いたプログラム:") {
-			if i+1 < len(lines) {
-				entry.ProgramTitle = strings.TrimSpace(lines[i+1])
-			}
-		} else if strings.HasPrefix(line, "満足度:") {
-			parts := strings.Split(line, "/")
-			if len(parts) > 0 {
-				if sat, err := strconv.Atoi(strings.TrimSpace(parts[0])); err == nil {
-					entry.Satisfaction = sat
-				}
+// ComposeContent opens content in the editor and reads the edited fields into the entry.
+// While fields are invalid the editor is opened again with the problems marked, until
+// they are fixed or the content is emptied. onEdit, when set, gets every edited text
+// so it can be kept as a draft. The final text is returned.
+func (p *Prompter) ComposeContent(entry *models.Entry, content string, onEdit func(string)) (string, error) {
+	for {
+		// Edit content using external editor
+		editedContent, err := p.edit(content)
+		if err != nil {
+			return content, errors.New(i18n.T("error.edit_short", err))
+		}
+
+		if strings.TrimSpace(StripErrorComments(editedContent)) == "" {
+			return "", ErrEmptyContent
+		}
+		if onEdit != nil {
+			onEdit(editedContent)
+		}
+
+		errs := ParseEditorContent(editedContent, entry)
+		if len(errs) == 0 {
+			return editedContent, nil
+		}
+
+		fmt.Fprintln(p.out, i18n.T("editor.retry", len(errs)))
+		if p.script {
+			// There is no editor to show the marked problems in
+			for _, fieldErr := range errs {
+				fmt.Fprintln(p.out, i18n.T("editor.error", fieldErr.Message))
 			}
 		}
+		content = AnnotateErrors(editedContent, errs)
+	}
+}
+
+// edit opens content in the editor, or reads the next block of the script
+func (p *Prompter) edit(content string) (string, error) {
+	if !p.script {
+		return editContent(p.out, content)
 	}
 
-	return nil
+	var lines []string
+	for {
+		line, err := p.reader.ReadString('\n')
+		if err != nil && line == "" {
+			return "", errors.New(i18n.T("prompt.script_unterminated", ScriptBlockEnd))
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == ScriptBlockEnd {
+			break
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		return content, nil
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// readLine reads the next answer. A script skips comments and echoes the answer
+// so that the output reads like a typed session.
+func (p *Prompter) readLine() (string, error) {
+	for {
+		input, err := p.reader.ReadString('\n')
+		if err == io.EOF && input != "" {
+			err = nil
+		}
+		if err == io.EOF {
+			return "", errors.New(i18n.T("prompt.end_of_input"))
+		}
+		if err != nil {
+			return "", err
+		}
+
+		input = strings.TrimSpace(input)
+		if p.script {
+			if strings.HasPrefix(input, ScriptCommentPrefix) {
+				continue
+			}
+			fmt.Fprintln(p.out, input)
+		}
+		return input, nil
+	}
 }
 
 // AskCategory prompts the user to select a category
 func (p *Prompter) AskCategory() (models.Category, error) {
-	fmt.Println("カテゴリを選択してください:")
-	fmt.Println("1. 調べ物")
-	fmt.Println("2. プログラマ")
-	fmt.Println("3. 調べてプログラマ")
-	fmt.Print("> ")
+	p.printCategoryChoices()
 
-	input, err := p.reader.ReadString('\n')
+	input, err := p.readLine()
 	if err != nil {
-		fmt.Printf("入力の読み取りエラー: %v\n", err)
+		fmt.Fprintln(p.out, i18n.T("prompt.read_error", err))
 		return "", err
 	}
 
-	input = strings.TrimSpace(input)
-
 	// Check for quit command
-	if strings.ToLower(input) == "quit" {
+	if p.CheckForQuit(input) {
 		return "quit", nil
 	}
+	return parseCategoryChoice(input)
+}
+
+// printCategoryChoices shows the numbered categories
+func (p *Prompter) printCategoryChoices() {
+	fmt.Fprintln(p.out, i18n.T("prompt.choose_category"))
+	fmt.Fprintln(p.out, "1. "+i18n.CategoryLabel(models.Research))
+	fmt.Fprintln(p.out, "2. "+i18n.CategoryLabel(models.Programming))
+	fmt.Fprintln(p.out, "3. "+i18n.CategoryLabel(models.ResearchAndProgram))
+	fmt.Fprint(p.out, "> ")
+}
 
+// parseCategoryChoice turns the number of a category into the category
+func parseCategoryChoice(input string) (models.Category, error) {
 	switch input {
 	case "1":
 		return models.Research, nil
@@ -96,77 +205,96 @@ func (p *Prompter) AskCategory() (models.Category, error) {
 	case "3":
 		return models.ResearchAndProgram, nil
 	default:
-		return "", fmt.Errorf("無効な選択です。1, 2, または 3 を入力してください。")
+		return "", errors.New(i18n.T("prompt.invalid_category"))
 	}
 }
 
 // AskResearchTopic prompts for what was researched
 func (p *Prompter) AskResearchTopic() (string, error) {
-	fmt.Println("調べたことを入力してください:")
-	fmt.Print("> ")
+	fmt.Fprintln(p.out, i18n.T("prompt.ask_research_topic"))
+	fmt.Fprint(p.out, "> ")
 
-	input, err := p.reader.ReadString('\n')
+	input, err := p.readLine()
 	if err != nil {
-		fmt.Printf("入力の読み取りエラー: %v\n", err)
+		fmt.Fprintln(p.out, i18n.T("prompt.read_error", err))
 		return "", err
 	}
 
-	return strings.TrimSpace(input), nil
+	return input, nil
 }
 
 // AskProgramTitle prompts for what program was written
 func (p *Prompter) AskProgramTitle() (string, error) {
-	fmt.Println("書いたプログラムを入力してください:")
-	fmt.Print("> ")
+	fmt.Fprintln(p.out, i18n.T("prompt.ask_program_title"))
+	fmt.Fprint(p.out, "> ")
 
-	input, err := p.reader.ReadString('\n')
+	input, err := p.readLine()
 	if err != nil {
-		fmt.Printf("入力の読み取りエラー: %v\n", err)
+		fmt.Fprintln(p.out, i18n.T("prompt.read_error", err))
 		return "", err
 	}
 
-	return strings.TrimSpace(input), nil
+	return input, nil
 }
 
 // AskSatisfaction prompts for satisfaction level (1-5)
 func (p *Prompter) AskSatisfaction() (int, error) {
-	fmt.Println("満足度を1-5で入力してください (5が最高):")
-	fmt.Print("> ")
+	p.printSatisfactionPrompt()
 
-	input, err := p.reader.ReadString('\n')
+	input, err := p.readLine()
 	if err != nil {
-		fmt.Printf("入力の読み取りエラー: %v\n", err)
+		fmt.Fprintln(p.out, i18n.T("prompt.read_error", err))
 		return 0, err
 	}
+	return parseSatisfaction(input)
+}
 
-	input = strings.TrimSpace(input)
+// printSatisfactionPrompt asks for the satisfaction
+func (p *Prompter) printSatisfactionPrompt() {
+	fmt.Fprintln(p.out, i18n.T("prompt.ask_satisfaction"))
+	fmt.Fprint(p.out, "> ")
+}
+
+// parseSatisfaction reads a satisfaction from 1 to 5
+func parseSatisfaction(input string) (int, error) {
 	satisfaction, err := strconv.Atoi(input)
 	if err != nil || satisfaction < 1 || satisfaction > 5 {
-		return 0, fmt.Errorf("満足度は1から5の数字で入力してください")
+		return 0, errors.New(i18n.T("prompt.invalid_satisfaction"))
 	}
-
 	return satisfaction, nil
 }
 
-// ShowSealMessage displays the seal's encouragement message
-func (p *Prompter) ShowSealMessage(satisfaction int) {
-	messages := []string{
-		"頑張ったね！ワモンアザラシはあなたを応援しているよ！",
-		"素晴らしい！ワモンアザラシはあなたの成長を見守っているよ！",
-		"よく頑張ったね！ワモンアザラシは誇りに思うよ！",
-		"すごいね！ワモンアザラシはあなたの成果に拍手！",
-		"素晴らしい進歩だね！ワモンアザラシは喜んでいるよ！",
+// AskYesNo asks a yes/no question until it is answered; an empty answer takes the default
+func (p *Prompter) AskYesNo(question string, defaultYes bool) (bool, error) {
+	for {
+		fmt.Fprintln(p.out, question)
+		fmt.Fprint(p.out, "> ")
+
+		input, err := p.readLine()
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(input) {
+		case "":
+			return defaultYes, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		fmt.Fprintln(p.out, i18n.T("prompt.invalid_yes_no"))
 	}
+}
 
-	// Select message based on satisfaction (or just pick a random one)
-	messageIndex := satisfaction - 1
-	if messageIndex < 0 || messageIndex >= len(messages) {
-		messageIndex = 0
+// ShowSealMessage displays the seal's message; nothing is shown when it is empty
+func (p *Prompter) ShowSealMessage(message string) {
+	if message == "" {
+		return
 	}
 
-	fmt.Println("")
-	fmt.Println("🦭 " + messages[messageIndex])
-	fmt.Println("")
+	fmt.Fprintln(p.out)
+	fmt.Fprintln(p.out, "🦭 "+message)
+	fmt.Fprintln(p.out)
 }
 
 // CheckForQuit checks if input indicates a desire to quit
@@ -176,11 +304,17 @@ func (p *Prompter) CheckForQuit(input string) bool {
 
 // AskString prompts for a string input
 func (p *Prompter) AskString() (string, error) {
-	reader := bufio.NewReader(os.Stdin)
-	input, err := reader.ReadString('\n')
+	input, err := p.readLine()
 	if err != nil {
-		fmt.Printf("入力の読み取りエラー: %v\n", err)
+		fmt.Fprintln(p.out, i18n.T("prompt.read_error", err))
 		return "", err
 	}
-	return strings.TrimSpace(input), nil
+	return input, nil
+}
+
+// HasLabel reports whether line starts with the editor label for key in any language,
+// so files written before switching languages can still be parsed
+func HasLabel(line, key string) bool {
+	_, ok := labelValue(line, key)
+	return ok
 }
@@ -6,6 +6,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/econron/wamon/internal/models"
 	"github.com/stretchr/testify/assert"
@@ -202,33 +203,16 @@ func TestAskSatisfaction(t *testing.T) {
 
 // TestShowSealMessage tests the seal message display
 func TestShowSealMessage(t *testing.T) {
-	// Save and restore stdout
-	oldStdout := os.Stdout
-	defer func() { os.Stdout = oldStdout }()
+	var buf bytes.Buffer
+	prompter := NewPrompterWithIO(strings.NewReader(""), &buf)
 
-	// Create a pipe to capture stdout
-	r, w, _ := os.Pipe()
-	os.Stdout = w
-
-	// Create prompter
-	prompter := NewPrompter()
-
-	// Call the method being tested with different satisfaction levels
-	for i := 1; i <= 5; i++ {
-		// Reset pipe for each test
-		r, w, _ = os.Pipe()
-		os.Stdout = w
+	prompter.ShowSealMessage("ワモンアザラシは喜んでいるよ！")
+	assert.Contains(t, buf.String(), "🦭 ワモンアザラシは喜んでいるよ！")
 
-		prompter.ShowSealMessage(i)
-
-		w.Close()
-		var buf bytes.Buffer
-		io.Copy(&buf, r)
-		output := buf.String()
-
-		// Verify that output contains "ワモンアザラシ"
-		assert.Contains(t, output, "ワモンアザラシ")
-	}
+	// An empty message prints nothing
+	buf.Reset()
+	prompter.ShowSealMessage("")
+	assert.Empty(t, buf.String())
 }
 
 // TestCheckForQuit tests the quit check
@@ -269,3 +253,114 @@ func TestAskString(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expected, result)
 }
+
+func TestPrompterWithIO(t *testing.T) {
+	var out bytes.Buffer
+	prompter := NewPrompterWithIO(strings.NewReader("2\nfirst\nsecond\n"), &out)
+
+	category, err := prompter.AskCategory()
+	assert.NoError(t, err)
+	assert.Equal(t, models.Programming, category)
+	assert.Contains(t, out.String(), "> ")
+
+	// Answers buffered by an earlier read are not lost
+	first, err := prompter.AskString()
+	assert.NoError(t, err)
+	assert.Equal(t, "first", first)
+	second, err := prompter.AskString()
+	assert.NoError(t, err)
+	assert.Equal(t, "second", second)
+
+	// Reading past the end is an error instead of an empty answer
+	_, err = prompter.AskString()
+	assert.Error(t, err)
+}
+
+func TestPrompterLastLineWithoutNewline(t *testing.T) {
+	prompter := NewPrompterWithIO(strings.NewReader("4"), &bytes.Buffer{})
+
+	satisfaction, err := prompter.AskSatisfaction()
+	assert.NoError(t, err)
+	assert.Equal(t, 4, satisfaction)
+}
+
+func TestScriptPrompter(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	script := `# category
+1
+調べたこと:
+SQLiteのWALモード
+タグ:
+sqlite
+.
+# satisfaction
+4
+`
+	var out bytes.Buffer
+	prompter := NewScriptPrompter(strings.NewReader(script), &out)
+
+	category, err := prompter.AskCategory()
+	assert.NoError(t, err)
+	assert.Equal(t, models.Research, category)
+
+	entry := &models.Entry{Category: category, CreatedAt: time.Now()}
+	assert.NoError(t, prompter.ComposeEntry(entry, "", nil))
+	assert.Equal(t, "SQLiteのWALモード", entry.ResearchTopic)
+	assert.Equal(t, []string{"sqlite"}, entry.Tags)
+
+	satisfaction, err := prompter.AskSatisfaction()
+	assert.NoError(t, err)
+	assert.Equal(t, 4, satisfaction)
+
+	// Answers are echoed so the output reads like a typed session; comments are not
+	assert.Contains(t, out.String(), "> 1\n")
+	assert.Contains(t, out.String(), "> 4\n")
+	assert.NotContains(t, out.String(), "# category")
+}
+
+func TestScriptPrompterEditorBlock(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// An empty block keeps the template
+	prompter := NewScriptPrompter(strings.NewReader(".\n"), &bytes.Buffer{})
+	entry := &models.Entry{Category: models.Research, ResearchTopic: "Go", CreatedAt: time.Now()}
+	assert.NoError(t, prompter.ComposeEntry(entry, "", nil))
+	assert.Equal(t, "Go", entry.ResearchTopic)
+
+	// A block without the end line is an error
+	prompter = NewScriptPrompter(strings.NewReader("調べたこと:\nGo\n"), &bytes.Buffer{})
+	assert.Error(t, prompter.ComposeEntry(entry, "", nil))
+
+	// Invalid content takes the next block, like reopening the editor
+	var out bytes.Buffer
+	prompter = NewScriptPrompter(strings.NewReader("満足度: 9/5\n.\n満足度: 3/5\n.\n"), &out)
+	assert.NoError(t, prompter.ComposeEntry(entry, "", nil))
+	assert.Equal(t, 3, entry.Satisfaction)
+	assert.Contains(t, out.String(), "満足度は1から5の数字で指定してください: 9/5")
+}
+
+func TestAskYesNo(t *testing.T) {
+	var out bytes.Buffer
+	prompter := NewPrompterWithIO(strings.NewReader("y\nNO\n\n\nmaybe\nyes\n"), &out)
+
+	answers := []struct {
+		defaultYes bool
+		expected   bool
+	}{
+		{false, true},
+		{true, false},
+		{true, true},
+		{false, false},
+		// An unknown answer is asked again
+		{false, true},
+	}
+	for _, a := range answers {
+		answer, err := prompter.AskYesNo("続けますか？", a.defaultYes)
+		assert.NoError(t, err)
+		assert.Equal(t, a.expected, answer)
+	}
+	assert.Contains(t, out.String(), "y か n で答えてください")
+
+	_, err := prompter.AskYesNo("続けますか？", true)
+	assert.Error(t, err)
+}
@@ -219,7 +219,7 @@ func SimpleEditText(initialContent string) (string, bool, error) {
 	if err != nil {
 		fmt.Println("エディタの初期化に失敗しました。外部エディタを試します。")
 		// Fallback to external editor
-		content, err := EditWithExternalEditor(initialContent)
+		content, err := EditWithExternalEditor(os.Stdout, initialContent)
 		if err != nil {
 			return initialContent, false, fmt.Errorf("テキスト編集に失敗しました: %v", err)
 		}
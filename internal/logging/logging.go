@@ -0,0 +1,133 @@
+// Package logging sets up the structured log of wamon. Logs are off unless
+// --verbose or --log-file is given, and tokens are redacted from every record.
+package logging
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	"github.com/econron/wamon/internal/i18n"
+)
+
+// Formats of the log records
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Options describe where logs go and which records are kept
+type Options struct {
+	Level  slog.Level
+	Format string    // FormatText or FormatJSON
+	Stderr io.Writer // console output; nil keeps the console quiet
+	File   string    // path of the rotating log file; "" for none
+	// MaxSize and MaxBackups bound the log file; zero uses DefaultMaxSize and DefaultMaxBackups
+	MaxSize    int64
+	MaxBackups int
+}
+
+// New returns a logger for opts and closes its log file when the closer is called.
+// Without any output the logger discards every record.
+func New(opts Options) (*slog.Logger, io.Closer, error) {
+	if opts.Format != "" && opts.Format != FormatText && opts.Format != FormatJSON {
+		return nil, nil, errors.New(i18n.T("log.invalid_format", opts.Format))
+	}
+
+	var writers []io.Writer
+	var closer io.Closer = nopCloser{}
+	if opts.Stderr != nil {
+		writers = append(writers, opts.Stderr)
+	}
+	if opts.File != "" {
+		file := &RotatingFile{Path: opts.File, MaxSize: opts.MaxSize, MaxBackups: opts.MaxBackups}
+		if err := file.open(); err != nil {
+			return nil, nil, err
+		}
+		writers = append(writers, file)
+		closer = file
+	}
+	if len(writers) == 0 {
+		return Discard(), closer, nil
+	}
+
+	handlerOpts := &slog.HandlerOptions{Level: opts.Level, ReplaceAttr: redactAttr}
+	out := io.MultiWriter(writers...)
+	if opts.Format == FormatJSON {
+		return slog.New(slog.NewJSONHandler(out, handlerOpts)), closer, nil
+	}
+	return slog.New(slog.NewTextHandler(out, handlerOpts)), closer, nil
+}
+
+// Discard returns a logger that drops every record
+func Discard() *slog.Logger {
+	return slog.New(discardHandler{})
+}
+
+// ParseLevel reads a level such as "debug", "info", "warn" or "error"
+func ParseLevel(name string) (slog.Level, error) {
+	var level slog.Level
+	err := level.UnmarshalText([]byte(name))
+	return level, err
+}
+
+// Enabled reports whether the default logger keeps records of level
+func Enabled(level slog.Level) bool {
+	return slog.Default().Enabled(context.Background(), level)
+}
+
+// secretKeys are attribute names whose values are always redacted
+var secretKeys = []string{"token", "password", "secret", "authorization"}
+
+// tokenPattern matches Slack tokens inside any text
+var tokenPattern = regexp.MustCompile(`xox[a-z]-[A-Za-z0-9-]+`)
+
+// redactAttr hides secrets in the attributes and the message of a record
+func redactAttr(groups []string, a slog.Attr) slog.Attr {
+	for _, key := range secretKeys {
+		if strings.EqualFold(a.Key, key) {
+			return slog.String(a.Key, Redact(a.Value.String()))
+		}
+	}
+	if a.Value.Kind() == slog.KindString {
+		return slog.String(a.Key, RedactTokens(a.Value.String()))
+	}
+	return a
+}
+
+// Redact hides a secret, keeping the kind of a Slack token and the last characters
+// so it can be recognized
+func Redact(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	prefix := ""
+	if loc := tokenPattern.FindStringIndex(secret); loc != nil && loc[0] == 0 {
+		prefix = secret[:5]
+	}
+	if len(secret)-len(prefix) <= 8 {
+		return prefix + "****"
+	}
+	return prefix + "****" + secret[len(secret)-4:]
+}
+
+// RedactTokens redacts every Slack token found in text
+func RedactTokens(text string) string {
+	return tokenPattern.ReplaceAllStringFunc(text, Redact)
+}
+
+// discardHandler drops every record
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
+// nopCloser is the closer of a logger without a log file
+type nopCloser struct{}
+
+func (nopCloser) Close() error { return nil }
@@ -0,0 +1,121 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Defaults of the rotating log file
+const (
+	DefaultMaxSize    = 5 << 20 // 5 MiB
+	DefaultMaxBackups = 3
+)
+
+// RotatingFile is a log file that is renamed to path.1, path.2, ... once it
+// reaches MaxSize, keeping MaxBackups old files. It is readable by its owner
+// only, since logs contain file paths and config values.
+type RotatingFile struct {
+	Path       string
+	MaxSize    int64
+	MaxBackups int
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// Write appends p, rotating the file first when p would make it too large
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		if err := r.openLocked(); err != nil {
+			return 0, err
+		}
+	}
+	if r.size > 0 && r.size+int64(len(p)) > r.maxSize() {
+		if err := r.rotateLocked(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// Close closes the log file
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// open opens the log file, so a path that can't be written is reported at once
+func (r *RotatingFile) open() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.openLocked()
+}
+
+// openLocked opens the log file for appending
+func (r *RotatingFile) openLocked() error {
+	if err := os.MkdirAll(filepath.Dir(r.Path), 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(r.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	r.file, r.size = file, info.Size()
+	return nil
+}
+
+// rotateLocked shifts the old files by one, drops the oldest and starts a new file
+func (r *RotatingFile) rotateLocked() error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+	r.file = nil
+
+	backups := r.maxBackups()
+	os.Remove(backupName(r.Path, backups))
+	for i := backups - 1; i >= 1; i-- {
+		os.Rename(backupName(r.Path, i), backupName(r.Path, i+1))
+	}
+	if err := os.Rename(r.Path, backupName(r.Path, 1)); err != nil {
+		return err
+	}
+	return r.openLocked()
+}
+
+func (r *RotatingFile) maxSize() int64 {
+	if r.MaxSize <= 0 {
+		return DefaultMaxSize
+	}
+	return r.MaxSize
+}
+
+func (r *RotatingFile) maxBackups() int {
+	if r.MaxBackups <= 0 {
+		return DefaultMaxBackups
+	}
+	return r.MaxBackups
+}
+
+// backupName returns the name of the n-th old log file
+func backupName(path string, n int) string {
+	return fmt.Sprintf("%s.%d", path, n)
+}
@@ -0,0 +1,64 @@
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "wamon.log")
+	file := &RotatingFile{Path: path, MaxSize: 10, MaxBackups: 2}
+	defer file.Close()
+
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		_, err := file.Write([]byte(line))
+		assert.NoError(t, err)
+	}
+
+	// Each line went past the size, so the oldest one is gone
+	read := func(name string) string {
+		data, err := os.ReadFile(name)
+		assert.NoError(t, err)
+		return string(data)
+	}
+	assert.Equal(t, "fourth\n", read(path))
+	assert.Equal(t, "third\n", read(path+".1"))
+	assert.Equal(t, "second\n", read(path+".2"))
+	_, err := os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err))
+
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestRotatingFileAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wamon.log")
+	assert.NoError(t, os.WriteFile(path, []byte("earlier\n"), 0600))
+
+	logger, closer, err := New(Options{File: path})
+	assert.NoError(t, err)
+	logger.Info("later")
+	assert.NoError(t, closer.Close())
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.Len(t, lines, 2)
+	assert.Equal(t, "earlier", lines[0])
+	assert.Contains(t, lines[1], "msg=later")
+}
+
+func TestRotatingFileUnwritable(t *testing.T) {
+	dir := t.TempDir()
+	blocker := filepath.Join(dir, "file")
+	assert.NoError(t, os.WriteFile(blocker, nil, 0600))
+
+	// A directory can't be created under a file
+	_, _, err := New(Options{File: filepath.Join(blocker, "wamon.log")})
+	assert.Error(t, err)
+}
@@ -0,0 +1,59 @@
+package models
+
+import "strings"
+
+// Category represents the type of entry.
+// The value is a language-neutral code; it is what the database and exports store.
+// Use i18n.CategoryLabel to display it.
+type Category string
+
+const (
+	Research           Category = "research"
+	Programming        Category = "programming"
+	ResearchAndProgram Category = "research_and_programming"
+)
+
+// categories lists every category in display order
+var categories = []Category{Research, Programming, ResearchAndProgram}
+
+// LegacyCategories maps the Japanese display strings that earlier versions stored
+// in the database to their codes
+var LegacyCategories = map[string]Category{
+	"調べ物":      Research,
+	"プログラマ":    Programming,
+	"調べてプログラマ": ResearchAndProgram,
+}
+
+// categoryAliases are short forms accepted on the command line
+var categoryAliases = map[string]Category{
+	"both": ResearchAndProgram,
+}
+
+// Categories returns every category in display order
+func Categories() []Category {
+	return append([]Category(nil), categories...)
+}
+
+// Valid reports whether c is a known category code
+func (c Category) Valid() bool {
+	for _, category := range categories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseCategory converts a code, a short alias or a legacy Japanese name to a Category.
+// Matching is case-insensitive.
+func ParseCategory(name string) (Category, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if category := Category(name); category.Valid() {
+		return category, true
+	}
+	if category, ok := categoryAliases[name]; ok {
+		return category, true
+	}
+	category, ok := LegacyCategories[name]
+	return category, ok
+}
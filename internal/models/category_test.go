@@ -0,0 +1,45 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCategory(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected Category
+		ok       bool
+	}{
+		{"research", Research, true},
+		{"Programming", Programming, true},
+		{"research_and_programming", ResearchAndProgram, true},
+		{"both", ResearchAndProgram, true},
+		{"調べ物", Research, true},
+		{"プログラマ", Programming, true},
+		{"調べてプログラマ", ResearchAndProgram, true},
+		{"invalid", "", false},
+		{"", "", false},
+	}
+
+	for _, tc := range testCases {
+		category, ok := ParseCategory(tc.input)
+		assert.Equal(t, tc.ok, ok, tc.input)
+		assert.Equal(t, tc.expected, category, tc.input)
+	}
+}
+
+func TestCategoryValid(t *testing.T) {
+	for _, category := range Categories() {
+		assert.True(t, category.Valid())
+	}
+	assert.False(t, Category("調べ物").Valid())
+	assert.False(t, Category("").Valid())
+}
+
+func TestCategoriesIsACopy(t *testing.T) {
+	list := Categories()
+	list[0] = "changed"
+	assert.Equal(t, Research, Categories()[0])
+}
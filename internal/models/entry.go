@@ -4,36 +4,60 @@ import (
 	"time"
 )
 
-// Category represents the type of entry
-type Category string
-
-const (
-	Research           Category = "調べ物"
-	Programming        Category = "プログラマ"
-	ResearchAndProgram Category = "調べてプログラマ"
-)
-
 // Entry represents a single journal entry
 type Entry struct {
-	ID            string    `json:"id"`
-	Category      Category  `json:"category"`
-	ResearchTopic string    `json:"research_topic,omitempty"`
-	ProgramTitle  string    `json:"program_title,omitempty"`
-	Satisfaction  int       `json:"satisfaction"` // 1-5 scale
-	CreatedAt     time.Time `json:"created_at"`
+	ID            string     `json:"id"`
+	Category      Category   `json:"category"`
+	ResearchTopic string     `json:"research_topic,omitempty"`
+	ProgramTitle  string     `json:"program_title,omitempty"`
+	Satisfaction  int        `json:"satisfaction"` // 1-5 scale
+	Tags          []string   `json:"tags,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     *time.Time `json:"updated_at,omitempty"` // nil until the entry is edited
+}
+
+// Revision is a snapshot of an entry taken just before it was updated
+type Revision struct {
+	Number    int       `json:"number"` // 1-based, oldest first
+	EntryID   string    `json:"entry_id"`
+	Entry     Entry     `json:"entry"`
+	RevisedAt time.Time `json:"revised_at"`
 }
 
+// IDLayout is the time layout of entry IDs
+const IDLayout = "20060102150405"
+
 // NewEntry creates a new entry with a unique ID and current timestamp
 func NewEntry(category Category, satisfaction int) *Entry {
+	id, createdAt := NewID(time.Now(), nil)
 	return &Entry{
-		ID:           generateID(),
+		ID:           id,
 		Category:     category,
 		Satisfaction: satisfaction,
-		CreatedAt:    time.Now(),
+		CreatedAt:    createdAt,
 	}
 }
 
-// generateID creates a simple timestamp-based ID
-func generateID() string {
-	return time.Now().Format("20060102150405")
+// NewID returns the timestamp ID of an entry created at now. While taken reports
+// the ID as used, the time is moved forward by a second. The time the ID stands for
+// is returned as well, so the entry's CreatedAt can agree with its ID.
+func NewID(now time.Time, taken func(id string) bool) (string, time.Time) {
+	id := now.Format(IDLayout)
+	for taken != nil && taken(id) {
+		now = now.Add(time.Second)
+		id = now.Format(IDLayout)
+	}
+	return id, now
+}
+
+// Body returns the free-text content of the entry as a single string
+func (e *Entry) Body() string {
+	switch {
+	case e.ResearchTopic != "" && e.ProgramTitle != "":
+		return e.ResearchTopic + " - " + e.ProgramTitle
+	case e.ResearchTopic != "":
+		return e.ResearchTopic
+	default:
+		return e.ProgramTitle
+	}
 }
@@ -29,3 +29,25 @@ func TestNewEntry(t *testing.T) {
 	assert.Equal(t, 1, entry.Satisfaction)
 	assert.WithinDuration(t, time.Now(), entry.CreatedAt, 2*time.Second)
 }
+
+func TestNewID(t *testing.T) {
+	now := time.Date(2025, 4, 1, 9, 30, 0, 0, time.Local)
+
+	id, createdAt := NewID(now, nil)
+	assert.Equal(t, "20250401093000", id)
+	assert.Equal(t, now, createdAt)
+
+	// Taken IDs move both the ID and the time forward
+	taken := map[string]bool{"20250401093000": true, "20250401093001": true}
+	id, createdAt = NewID(now, func(id string) bool { return taken[id] })
+	assert.Equal(t, "20250401093002", id)
+	assert.Equal(t, now.Add(2*time.Second), createdAt)
+	assert.Equal(t, id, createdAt.Format(IDLayout))
+}
+
+func TestEntryBody(t *testing.T) {
+	assert.Equal(t, "topic", (&Entry{ResearchTopic: "topic"}).Body())
+	assert.Equal(t, "program", (&Entry{ProgramTitle: "program"}).Body())
+	assert.Equal(t, "topic - program", (&Entry{ResearchTopic: "topic", ProgramTitle: "program"}).Body())
+	assert.Equal(t, "", (&Entry{}).Body())
+}
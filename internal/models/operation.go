@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// OperationKind is the kind of change recorded in the operation journal
+type OperationKind string
+
+const (
+	OperationSave   OperationKind = "save"
+	OperationUpdate OperationKind = "update"
+	OperationImport OperationKind = "import"
+	OperationDelete OperationKind = "delete"
+)
+
+// Operation is a change to the database that can be undone
+type Operation struct {
+	ID        int64         `json:"id"`
+	Kind      OperationKind `json:"kind"`
+	EntryIDs  []string      `json:"entry_ids"`
+	CreatedAt time.Time     `json:"created_at"`
+	UndoneAt  *time.Time    `json:"undone_at,omitempty"` // nil until the operation is undone
+	// Reverted describes what undoing the operation did to each entry; only set by UndoOperation
+	Reverted []RevertedEntry `json:"-"`
+}
+
+// RevertedEntry is the change undoing an operation made to an entry
+type RevertedEntry struct {
+	Before *Entry // nil when the undo brought back a deleted entry
+	After  *Entry // nil when the undo deleted the entry
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ParseTags splits a comma- or space-separated list into normalized tags
+func ParseTags(text string) []string {
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return r == ',' || r == '、' || unicode.IsSpace(r)
+	})
+	return NormalizeTags(fields)
+}
+
+// NormalizeTags trims, lowercases and de-duplicates tags, keeping their order.
+// A leading "#" is dropped so "#go" and "go" are the same tag.
+func NormalizeTags(tags []string) []string {
+	var result []string
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+		if tag == "" || strings.ContainsAny(tag, ", \t\n") || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		result = append(result, tag)
+	}
+	return result
+}
+
+// HasTag reports whether the entry has the given tag
+func (e *Entry) HasTag(tag string) bool {
+	normalized := NormalizeTags([]string{tag})
+	if len(normalized) == 0 {
+		return false
+	}
+	for _, t := range e.Tags {
+		if t == normalized[0] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTags(t *testing.T) {
+	assert.Equal(t, []string{"go", "sqlite", "cli"}, ParseTags("Go, #sqlite cli,go"))
+	assert.Equal(t, []string{"読書", "メモ"}, ParseTags("読書、メモ"))
+	assert.Nil(t, ParseTags("  , "))
+}
+
+func TestHasTag(t *testing.T) {
+	entry := &Entry{Tags: []string{"go", "cli"}}
+	assert.True(t, entry.HasTag("go"))
+	assert.True(t, entry.HasTag("#CLI"))
+	assert.False(t, entry.HasTag("rust"))
+	assert.False(t, entry.HasTag(""))
+}
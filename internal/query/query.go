@@ -0,0 +1,195 @@
+package query
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/econron/wamon/internal/i18n"
+	"github.com/econron/wamon/internal/models"
+)
+
+// Fields are the field names a query can filter on, besides free text
+var Fields = []string{"category", "tag", "since", "until", "satisfaction", "id"}
+
+// fieldAliases are the short forms accepted for field names
+var fieldAliases = map[string]string{
+	"cat": "category",
+	"sat": "satisfaction",
+}
+
+// condition reports whether an entry satisfies one term of a query
+type condition func(entry *models.Entry) bool
+
+// Query is a parsed filter such as `category:research tag:go since:2025-04-01 sqlite`.
+// Every term must match; a term without a field matches the ID, text fields and tags.
+type Query struct {
+	text       string
+	conditions []condition
+}
+
+// Parse parses a filter query
+func Parse(text string) (*Query, error) {
+	terms, err := split(text)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &Query{text: strings.TrimSpace(text)}
+	for _, term := range terms {
+		cond, err := parseTerm(term)
+		if err != nil {
+			return nil, err
+		}
+		q.conditions = append(q.conditions, cond)
+	}
+	return q, nil
+}
+
+// String returns the query text
+func (q *Query) String() string {
+	return q.text
+}
+
+// Empty reports whether the query has no terms and so matches every entry
+func (q *Query) Empty() bool {
+	return len(q.conditions) == 0
+}
+
+// Match reports whether the entry satisfies every term of the query
+func (q *Query) Match(entry *models.Entry) bool {
+	for _, cond := range q.conditions {
+		if !cond(entry) {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter returns the entries that match the query
+func (q *Query) Filter(entries []*models.Entry) []*models.Entry {
+	var result []*models.Entry
+	for _, entry := range entries {
+		if q.Match(entry) {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+// split breaks the query into terms at whitespace, keeping double-quoted text together
+func split(text string) ([]string, error) {
+	var terms []string
+	var current strings.Builder
+	inQuotes := false
+	for _, r := range text {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case unicode.IsSpace(r) && !inQuotes:
+			if current.Len() > 0 {
+				terms = append(terms, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if inQuotes {
+		return nil, errors.New(i18n.T("query.unclosed_quote", text))
+	}
+	if current.Len() > 0 {
+		terms = append(terms, current.String())
+	}
+	return terms, nil
+}
+
+// parseTerm turns a single term into a condition
+func parseTerm(term string) (condition, error) {
+	field, value, ok := strings.Cut(term, ":")
+	if !ok || field == "" {
+		return textCondition(term), nil
+	}
+	field = strings.ToLower(field)
+	if alias, ok := fieldAliases[field]; ok {
+		field = alias
+	}
+
+	switch field {
+	case "category":
+		category, ok := models.ParseCategory(value)
+		if !ok {
+			return nil, errors.New(i18n.T("query.invalid_value", field, value))
+		}
+		return func(entry *models.Entry) bool {
+			return entry.Category == category
+		}, nil
+	case "tag":
+		return func(entry *models.Entry) bool {
+			return entry.HasTag(value)
+		}, nil
+	case "since", "until":
+		day, err := time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			return nil, errors.New(i18n.T("query.invalid_value", field, value))
+		}
+		if field == "since" {
+			return func(entry *models.Entry) bool {
+				return !entry.CreatedAt.Before(day)
+			}, nil
+		}
+		// until includes the whole day
+		end := day.AddDate(0, 0, 1)
+		return func(entry *models.Entry) bool {
+			return entry.CreatedAt.Before(end)
+		}, nil
+	case "satisfaction":
+		return satisfactionCondition(value)
+	case "id":
+		prefix := strings.TrimSuffix(value, "*")
+		return func(entry *models.Entry) bool {
+			return strings.HasPrefix(entry.ID, prefix)
+		}, nil
+	default:
+		return nil, errors.New(i18n.T("query.unknown_field", field, strings.Join(Fields, ", ")))
+	}
+}
+
+// textCondition matches text case-insensitively against the ID, the text fields and the tags
+func textCondition(text string) condition {
+	text = strings.ToLower(text)
+	return func(entry *models.Entry) bool {
+		haystack := strings.ToLower(entry.ID + " " + entry.ResearchTopic + " " + entry.ProgramTitle + " " + strings.Join(entry.Tags, " "))
+		return strings.Contains(haystack, text)
+	}
+}
+
+// satisfactionCondition parses values such as "3", ">=4" or "<3"
+func satisfactionCondition(value string) (condition, error) {
+	operator := strings.TrimRight(value, "0123456789")
+	number, err := strconv.Atoi(strings.TrimPrefix(value, operator))
+	if err != nil {
+		return nil, errors.New(i18n.T("query.invalid_value", "satisfaction", value))
+	}
+
+	var compare func(int) bool
+	switch operator {
+	case "", "=":
+		compare = func(s int) bool { return s == number }
+	case "<":
+		compare = func(s int) bool { return s < number }
+	case "<=":
+		compare = func(s int) bool { return s <= number }
+	case ">":
+		compare = func(s int) bool { return s > number }
+	case ">=":
+		compare = func(s int) bool { return s >= number }
+	default:
+		return nil, errors.New(i18n.T("query.invalid_value", "satisfaction", value))
+	}
+	return func(entry *models.Entry) bool {
+		return compare(entry.Satisfaction)
+	}, nil
+}
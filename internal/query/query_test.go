@@ -0,0 +1,70 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/econron/wamon/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func testEntries() []*models.Entry {
+	return []*models.Entry{
+		{ID: "20250401093000", Category: models.Research, ResearchTopic: "SQLite indexes", Satisfaction: 2, Tags: []string{"db"}, CreatedAt: time.Date(2025, 4, 1, 9, 30, 0, 0, time.Local)},
+		{ID: "20250410120000", Category: models.Programming, ProgramTitle: "wamon bulk", Satisfaction: 4, Tags: []string{"go", "cli"}, CreatedAt: time.Date(2025, 4, 10, 12, 0, 0, 0, time.Local)},
+		{ID: "20250502080000", Category: models.ResearchAndProgram, ResearchTopic: "Go generics", ProgramTitle: "sample", Satisfaction: 5, Tags: []string{"go"}, CreatedAt: time.Date(2025, 5, 2, 8, 0, 0, 0, time.Local)},
+	}
+}
+
+func ids(entries []*models.Entry) []string {
+	var result []string
+	for _, e := range entries {
+		result = append(result, e.ID)
+	}
+	return result
+}
+
+func TestParseAndFilter(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{"category:research", []string{"20250401093000"}},
+		{"cat:調べてプログラマ", []string{"20250502080000"}},
+		{"tag:#Go", []string{"20250410120000", "20250502080000"}},
+		{"tag:go since:2025-05-01", []string{"20250502080000"}},
+		{"until:2025-04-10", []string{"20250401093000", "20250410120000"}},
+		{"sat:>=4", []string{"20250410120000", "20250502080000"}},
+		{"satisfaction:<3", []string{"20250401093000"}},
+		{"satisfaction:4", []string{"20250410120000"}},
+		{"id:202504*", []string{"20250401093000", "20250410120000"}},
+		{"sqlite", []string{"20250401093000"}},
+		{`"wamon bulk"`, []string{"20250410120000"}},
+		{"go tag:cli", []string{"20250410120000"}},
+	}
+
+	for _, tt := range tests {
+		q, err := Parse(tt.query)
+		assert.NoError(t, err, tt.query)
+		assert.Equal(t, tt.expected, ids(q.Filter(testEntries())), tt.query)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, text := range []string{"category:cooking", "since:yesterday", "sat:high", "sat:=>3", "color:red", `"open`} {
+		_, err := Parse(text)
+		assert.Error(t, err, text)
+	}
+}
+
+func TestEmpty(t *testing.T) {
+	q, err := Parse("   ")
+	assert.NoError(t, err)
+	assert.True(t, q.Empty())
+	assert.Len(t, q.Filter(testEntries()), 3)
+
+	q, err = Parse(" tag:go ")
+	assert.NoError(t, err)
+	assert.False(t, q.Empty())
+	assert.Equal(t, "tag:go", q.String())
+}
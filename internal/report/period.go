@@ -0,0 +1,150 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/econron/wamon/internal/i18n"
+)
+
+// Kind is the length of a report period
+type Kind string
+
+// Report periods
+const (
+	KindWeek    Kind = "week"
+	KindMonth   Kind = "month"
+	KindQuarter Kind = "quarter"
+	KindCustom  Kind = "custom"
+)
+
+// Kinds lists the report periods, in the order they are shown
+var Kinds = []Kind{KindWeek, KindMonth, KindQuarter, KindCustom}
+
+// DefaultWeekStart is the first day of a week when report.week_start is not set
+const DefaultWeekStart = time.Monday
+
+// dateLayout is how dates are given on the command line
+const dateLayout = "2006-01-02"
+
+// ParseKind parses the name of a report period
+func ParseKind(name string) (Kind, error) {
+	for _, kind := range Kinds {
+		if string(kind) == name {
+			return kind, nil
+		}
+	}
+	return "", errors.New(i18n.T("report.invalid_period", name))
+}
+
+// Period is a range of days a report covers. Start is the first day at
+// midnight and End the midnight after the last day, so End is not included.
+type Period struct {
+	Kind  Kind
+	Start time.Time
+	End   time.Time
+}
+
+// Current returns the calendar week, month or quarter containing now.
+// Weeks begin on weekStart.
+func Current(kind Kind, now time.Time, weekStart time.Weekday) Period {
+	day := startOfDay(now)
+	switch kind {
+	case KindMonth:
+		start := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, day.Location())
+		return Period{Kind: kind, Start: start, End: start.AddDate(0, 1, 0)}
+	case KindQuarter:
+		month := (day.Month()-1)/3*3 + 1
+		start := time.Date(day.Year(), month, 1, 0, 0, 0, 0, day.Location())
+		return Period{Kind: kind, Start: start, End: start.AddDate(0, 3, 0)}
+	default:
+		offset := (int(day.Weekday()) - int(weekStart) + 7) % 7
+		start := day.AddDate(0, 0, -offset)
+		return Period{Kind: KindWeek, Start: start, End: start.AddDate(0, 0, 7)}
+	}
+}
+
+// Custom returns the period from since to until, both days included
+func Custom(since, until time.Time) Period {
+	return Period{Kind: KindCustom, Start: startOfDay(since), End: startOfDay(until).AddDate(0, 0, 1)}
+}
+
+// ParseCustom parses the --since and --until dates of a custom period.
+// An empty until means today.
+func ParseCustom(since, until string, now time.Time) (Period, error) {
+	if since == "" {
+		return Period{}, errors.New(i18n.T("report.since_required"))
+	}
+	start, err := time.ParseInLocation(dateLayout, since, time.Local)
+	if err != nil {
+		return Period{}, errors.New(i18n.T("report.invalid_date", "--since", since))
+	}
+	last := now
+	if until != "" {
+		if last, err = time.ParseInLocation(dateLayout, until, time.Local); err != nil {
+			return Period{}, errors.New(i18n.T("report.invalid_date", "--until", until))
+		}
+	}
+	if last.Before(start) {
+		return Period{}, errors.New(i18n.T("report.until_before_since", last.Format(dateLayout), since))
+	}
+	return Custom(start, last), nil
+}
+
+// Previous returns the period just before p. A custom period is moved back by its own length.
+func (p Period) Previous() Period {
+	switch p.Kind {
+	case KindWeek:
+		return Period{Kind: p.Kind, Start: p.Start.AddDate(0, 0, -7), End: p.Start}
+	case KindMonth:
+		return Period{Kind: p.Kind, Start: p.Start.AddDate(0, -1, 0), End: p.Start}
+	case KindQuarter:
+		return Period{Kind: p.Kind, Start: p.Start.AddDate(0, -3, 0), End: p.Start}
+	default:
+		return Period{Kind: p.Kind, Start: p.Start.AddDate(0, 0, -p.Days()), End: p.Start}
+	}
+}
+
+// LastDay returns the last day included in the period
+func (p Period) LastDay() time.Time {
+	return p.End.AddDate(0, 0, -1)
+}
+
+// Days returns the number of days in the period
+func (p Period) Days() int {
+	days := 0
+	for day := p.Start; day.Before(p.End); day = day.AddDate(0, 0, 1) {
+		days++
+	}
+	return days
+}
+
+// Contains reports whether t falls within the period
+func (p Period) Contains(t time.Time) bool {
+	return !t.Before(p.Start) && t.Before(p.End)
+}
+
+// Label describes the period in the current language, such as "2025/04/01〜2025/04/30"
+// or "2025年4月"
+func (p Period) Label() string {
+	switch p.Kind {
+	case KindMonth:
+		return p.Start.Format(i18n.T("report.month_layout"))
+	case KindQuarter:
+		return i18n.T("report.period.quarter", p.Start.Year(), int(p.Start.Month()-1)/3+1)
+	default:
+		layout := i18n.T("report.date_layout")
+		return i18n.T("report.period.range", p.Start.Format(layout), p.LastDay().Format(layout))
+	}
+}
+
+// FormatDay formats a day with its weekday, such as "2025/04/14 (月)"
+func FormatDay(t time.Time) string {
+	return fmt.Sprintf("%s (%s)", t.Format(i18n.T("report.date_layout")), i18n.T(fmt.Sprintf("weekday.short.%d", t.Weekday())))
+}
+
+// startOfDay returns midnight of the day of t
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,112 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// date returns midnight of a day in the local time zone
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+}
+
+func TestParseKind(t *testing.T) {
+	for _, kind := range Kinds {
+		parsed, err := ParseKind(string(kind))
+		assert.NoError(t, err)
+		assert.Equal(t, kind, parsed)
+	}
+
+	_, err := ParseKind("year")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "year")
+}
+
+func TestCurrentWeek(t *testing.T) {
+	// Wednesday 2025-04-16
+	now := time.Date(2025, 4, 16, 15, 30, 0, 0, time.Local)
+
+	week := Current(KindWeek, now, time.Monday)
+	assert.Equal(t, date(2025, 4, 14), week.Start)
+	assert.Equal(t, date(2025, 4, 21), week.End)
+	assert.Equal(t, 7, week.Days())
+
+	week = Current(KindWeek, now, time.Sunday)
+	assert.Equal(t, date(2025, 4, 13), week.Start)
+	assert.Equal(t, date(2025, 4, 20), week.End)
+
+	// The first day of a week belongs to it
+	week = Current(KindWeek, date(2025, 4, 14), time.Monday)
+	assert.Equal(t, date(2025, 4, 14), week.Start)
+}
+
+func TestCurrentMonthAndQuarter(t *testing.T) {
+	now := time.Date(2025, 5, 20, 9, 0, 0, 0, time.Local)
+
+	month := Current(KindMonth, now, time.Monday)
+	assert.Equal(t, date(2025, 5, 1), month.Start)
+	assert.Equal(t, date(2025, 6, 1), month.End)
+
+	quarter := Current(KindQuarter, now, time.Monday)
+	assert.Equal(t, date(2025, 4, 1), quarter.Start)
+	assert.Equal(t, date(2025, 7, 1), quarter.End)
+
+	quarter = Current(KindQuarter, date(2025, 12, 31), time.Monday)
+	assert.Equal(t, date(2025, 10, 1), quarter.Start)
+	assert.Equal(t, date(2026, 1, 1), quarter.End)
+}
+
+func TestPrevious(t *testing.T) {
+	now := date(2025, 3, 15)
+
+	week := Current(KindWeek, now, time.Monday).Previous()
+	assert.Equal(t, date(2025, 3, 3), week.Start)
+	assert.Equal(t, date(2025, 3, 10), week.End)
+
+	month := Current(KindMonth, now, time.Monday).Previous()
+	assert.Equal(t, date(2025, 2, 1), month.Start)
+	assert.Equal(t, date(2025, 3, 1), month.End)
+
+	quarter := Current(KindQuarter, now, time.Monday).Previous()
+	assert.Equal(t, date(2024, 10, 1), quarter.Start)
+	assert.Equal(t, date(2025, 1, 1), quarter.End)
+
+	custom := Custom(date(2025, 4, 1), date(2025, 4, 10)).Previous()
+	assert.Equal(t, date(2025, 3, 22), custom.Start)
+	assert.Equal(t, date(2025, 4, 1), custom.End)
+}
+
+func TestParseCustom(t *testing.T) {
+	now := time.Date(2025, 5, 2, 18, 0, 0, 0, time.Local)
+
+	period, err := ParseCustom("2025-04-01", "2025-04-30", now)
+	assert.NoError(t, err)
+	assert.Equal(t, KindCustom, period.Kind)
+	assert.Equal(t, date(2025, 4, 1), period.Start)
+	assert.Equal(t, date(2025, 5, 1), period.End)
+	assert.True(t, period.Contains(time.Date(2025, 4, 30, 23, 59, 0, 0, time.Local)))
+	assert.False(t, period.Contains(date(2025, 5, 1)))
+
+	// Without --until the period ends today
+	period, err = ParseCustom("2025-04-28", "", now)
+	assert.NoError(t, err)
+	assert.Equal(t, date(2025, 5, 3), period.End)
+
+	_, err = ParseCustom("", "2025-04-30", now)
+	assert.Error(t, err)
+	_, err = ParseCustom("2025/04/01", "", now)
+	assert.Error(t, err)
+	_, err = ParseCustom("2025-04-01", "April", now)
+	assert.Error(t, err)
+	_, err = ParseCustom("2025-04-30", "2025-04-01", now)
+	assert.Error(t, err)
+}
+
+func TestLabel(t *testing.T) {
+	assert.Equal(t, "2025/04/14〜2025/04/20", Current(KindWeek, date(2025, 4, 16), time.Monday).Label())
+	assert.Equal(t, "2025年4月", Current(KindMonth, date(2025, 4, 16), time.Monday).Label())
+	assert.Equal(t, "2025年 第2四半期", Current(KindQuarter, date(2025, 4, 16), time.Monday).Label())
+	assert.Equal(t, "2025/04/01〜2025/04/30", Custom(date(2025, 4, 1), date(2025, 4, 30)).Label())
+}
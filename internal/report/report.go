@@ -0,0 +1,154 @@
+package report
+
+import (
+	"sort"
+	"time"
+
+	"github.com/econron/wamon/internal/models"
+	"github.com/econron/wamon/internal/stats"
+)
+
+// DefaultBest is the number of best-rated entries a report highlights
+const DefaultBest = 3
+
+// TimeOfDay is a part of the day entries are recorded in
+type TimeOfDay string
+
+// Parts of the day
+const (
+	Morning   TimeOfDay = "morning"   // 5:00-11:59
+	Afternoon TimeOfDay = "afternoon" // 12:00-16:59
+	Evening   TimeOfDay = "evening"   // 17:00-21:59
+	Night     TimeOfDay = "night"     // 22:00-4:59
+)
+
+// TimesOfDay lists the parts of the day in the order they are shown
+var TimesOfDay = []TimeOfDay{Morning, Afternoon, Evening, Night}
+
+// TimeOfDayOf returns the part of the day of t in the local time zone
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	switch hour := t.Local().Hour(); {
+	case hour >= 5 && hour < 12:
+		return Morning
+	case hour >= 12 && hour < 17:
+		return Afternoon
+	case hour >= 17 && hour < 22:
+		return Evening
+	default:
+		return Night
+	}
+}
+
+// Day is a day with entries
+type Day struct {
+	Date    time.Time
+	Entries []*models.Entry // oldest first
+}
+
+// CategoryCount is the number of entries of a category
+type CategoryCount struct {
+	Category models.Category
+	Count    int
+}
+
+// TimeOfDayCount is the number of entries recorded in a part of the day
+type TimeOfDayCount struct {
+	TimeOfDay TimeOfDay
+	Count     int
+}
+
+// Comparison holds the figures of the period before the report's
+type Comparison struct {
+	Period           Period
+	Total            int
+	MeanSatisfaction float64
+}
+
+// Report is the content of the report of a period, independent of where it is sent
+type Report struct {
+	Period           Period
+	Total            int
+	ActiveDays       int
+	MeanSatisfaction float64
+	Categories       []CategoryCount  // every category, in display order
+	Best             []*models.Entry  // best-rated entries, newest first among equal ratings
+	Days             []Day            // days with entries, oldest first
+	BusiestDay       *Day             // the day with the most entries, the earliest on a tie; nil without entries
+	TimesOfDay       []TimeOfDayCount // every part of the day, in display order
+	Previous         *Comparison      // nil when the report is not compared
+	Streak           *stats.Streak    // nil when the streak is unknown
+}
+
+// Build computes the report of the entries recorded in period
+func Build(period Period, entries []*models.Entry) *Report {
+	summary := stats.Compute(entries, stats.Options{Since: period.Start, Until: period.End, TopN: DefaultBest})
+	r := &Report{
+		Period:           period,
+		Total:            summary.Total,
+		ActiveDays:       summary.ActiveDays,
+		MeanSatisfaction: summary.MeanSatisfaction,
+		Best:             summary.TopEntries,
+	}
+
+	for _, category := range models.Categories() {
+		r.Categories = append(r.Categories, CategoryCount{Category: category, Count: summary.CategoryCounts[category]})
+	}
+
+	counts := make(map[TimeOfDay]int)
+	var inPeriod []*models.Entry
+	for _, entry := range entries {
+		if period.Contains(entry.CreatedAt) {
+			inPeriod = append(inPeriod, entry)
+			counts[TimeOfDayOf(entry.CreatedAt)]++
+		}
+	}
+	for _, part := range TimesOfDay {
+		r.TimesOfDay = append(r.TimesOfDay, TimeOfDayCount{TimeOfDay: part, Count: counts[part]})
+	}
+
+	r.Days = groupByDay(inPeriod)
+	for i := range r.Days {
+		if r.BusiestDay == nil || len(r.Days[i].Entries) > len(r.BusiestDay.Entries) {
+			r.BusiestDay = &r.Days[i]
+		}
+	}
+	return r
+}
+
+// Compare adds the figures of the previous period, computed from entries
+func (r *Report) Compare(entries []*models.Entry) {
+	previous := r.Period.Previous()
+	summary := stats.Compute(entries, stats.Options{Since: previous.Start, Until: previous.End})
+	r.Previous = &Comparison{Period: previous, Total: summary.Total, MeanSatisfaction: summary.MeanSatisfaction}
+}
+
+// PeakTimeOfDay returns the part of the day with the most entries, or false without entries
+func (r *Report) PeakTimeOfDay() (TimeOfDay, bool) {
+	peak := TimeOfDayCount{}
+	for _, count := range r.TimesOfDay {
+		if count.Count > peak.Count {
+			peak = count
+		}
+	}
+	return peak.TimeOfDay, peak.Count > 0
+}
+
+// groupByDay groups entries by their local day, oldest first
+func groupByDay(entries []*models.Entry) []Day {
+	sorted := make([]*models.Entry, len(entries))
+	copy(sorted, entries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+	})
+
+	var days []Day
+	for _, entry := range sorted {
+		date := startOfDay(entry.CreatedAt.Local())
+		if len(days) == 0 || !days[len(days)-1].Date.Equal(date) {
+			days = append(days, Day{Date: date})
+		}
+		last := &days[len(days)-1]
+		last.Entries = append(last.Entries, entry)
+	}
+	return days
+}
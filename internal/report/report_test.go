@@ -0,0 +1,114 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/econron/wamon/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// at returns a time on a day of April 2025 in the local time zone
+func at(day, hour int) time.Time {
+	return time.Date(2025, 4, day, hour, 0, 0, 0, time.Local)
+}
+
+func TestTimeOfDayOf(t *testing.T) {
+	assert.Equal(t, Night, TimeOfDayOf(at(14, 4)))
+	assert.Equal(t, Morning, TimeOfDayOf(at(14, 5)))
+	assert.Equal(t, Morning, TimeOfDayOf(at(14, 11)))
+	assert.Equal(t, Afternoon, TimeOfDayOf(at(14, 12)))
+	assert.Equal(t, Evening, TimeOfDayOf(at(14, 17)))
+	assert.Equal(t, Night, TimeOfDayOf(at(14, 22)))
+}
+
+func TestBuild(t *testing.T) {
+	period := Current(KindWeek, at(16, 0), time.Monday)
+	entries := []*models.Entry{
+		// Out of order, as hooks may hand them over
+		{ID: "3", Category: models.Programming, Satisfaction: 5, CreatedAt: at(16, 20)},
+		{ID: "1", Category: models.Research, Satisfaction: 3, CreatedAt: at(14, 9)},
+		{ID: "2", Category: models.Research, Satisfaction: 4, CreatedAt: at(16, 13)},
+		{ID: "4", Category: models.Research, Satisfaction: 2, CreatedAt: at(16, 23)},
+		// Outside the period
+		{ID: "0", Category: models.Research, Satisfaction: 5, CreatedAt: at(13, 12)},
+	}
+
+	r := Build(period, entries)
+	assert.Equal(t, 4, r.Total)
+	assert.Equal(t, 2, r.ActiveDays)
+	assert.InDelta(t, 3.5, r.MeanSatisfaction, 0.001)
+
+	assert.Equal(t, []CategoryCount{
+		{Category: models.Research, Count: 3},
+		{Category: models.Programming, Count: 1},
+		{Category: models.ResearchAndProgram, Count: 0},
+	}, r.Categories)
+
+	if assert.Len(t, r.Best, 3) {
+		assert.Equal(t, "3", r.Best[0].ID)
+		assert.Equal(t, "2", r.Best[1].ID)
+		assert.Equal(t, "1", r.Best[2].ID)
+	}
+
+	// Days are sorted, and so are their entries
+	if assert.Len(t, r.Days, 2) {
+		assert.Equal(t, at(14, 0), r.Days[0].Date)
+		assert.Equal(t, at(16, 0), r.Days[1].Date)
+		var ids []string
+		for _, entry := range r.Days[1].Entries {
+			ids = append(ids, entry.ID)
+		}
+		assert.Equal(t, []string{"2", "3", "4"}, ids)
+	}
+	if assert.NotNil(t, r.BusiestDay) {
+		assert.Equal(t, at(16, 0), r.BusiestDay.Date)
+	}
+
+	assert.Equal(t, []TimeOfDayCount{
+		{TimeOfDay: Morning, Count: 1},
+		{TimeOfDay: Afternoon, Count: 1},
+		{TimeOfDay: Evening, Count: 1},
+		{TimeOfDay: Night, Count: 1},
+	}, r.TimesOfDay)
+	peak, ok := r.PeakTimeOfDay()
+	assert.True(t, ok)
+	assert.Equal(t, Morning, peak)
+}
+
+func TestBuildEmpty(t *testing.T) {
+	r := Build(Current(KindWeek, at(16, 0), time.Monday), nil)
+	assert.Equal(t, 0, r.Total)
+	assert.Empty(t, r.Days)
+	assert.Nil(t, r.BusiestDay)
+	_, ok := r.PeakTimeOfDay()
+	assert.False(t, ok)
+}
+
+func TestBusiestDayTie(t *testing.T) {
+	entries := []*models.Entry{
+		{ID: "1", Category: models.Research, Satisfaction: 3, CreatedAt: at(15, 10)},
+		{ID: "2", Category: models.Research, Satisfaction: 3, CreatedAt: at(14, 10)},
+	}
+	r := Build(Current(KindWeek, at(16, 0), time.Monday), entries)
+	assert.Equal(t, at(14, 0), r.BusiestDay.Date)
+}
+
+func TestCompare(t *testing.T) {
+	period := Current(KindWeek, at(16, 0), time.Monday)
+	r := Build(period, []*models.Entry{
+		{ID: "1", Category: models.Research, Satisfaction: 4, CreatedAt: at(15, 10)},
+	})
+	r.Compare([]*models.Entry{
+		{ID: "a", Category: models.Research, Satisfaction: 2, CreatedAt: at(7, 10)},
+		{ID: "b", Category: models.Research, Satisfaction: 4, CreatedAt: at(13, 10)},
+		// Part of the report, not of the previous week
+		{ID: "1", Category: models.Research, Satisfaction: 4, CreatedAt: at(15, 10)},
+	})
+
+	if assert.NotNil(t, r.Previous) {
+		assert.Equal(t, at(7, 0), r.Previous.Period.Start)
+		assert.Equal(t, 2, r.Previous.Total)
+		assert.InDelta(t, 3.0, r.Previous.MeanSatisfaction, 0.001)
+	}
+}
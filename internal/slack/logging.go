@@ -0,0 +1,46 @@
+package slack
+
+import (
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/econron/wamon/internal/logging"
+	"github.com/slack-go/slack"
+)
+
+// newAPI creates a Slack API client. With debug logging on, the requests and
+// responses slack-go sees are logged as well; tokens are redacted by the logger.
+func newAPI(token string) *slack.Client {
+	if logging.Enabled(slog.LevelDebug) {
+		return slack.New(token, slack.OptionDebug(true), slack.OptionLog(apiLogger{}))
+	}
+	return slack.New(token)
+}
+
+// apiLogger passes the debug output of slack-go to slog
+type apiLogger struct{}
+
+// Output logs one message of slack-go
+func (apiLogger) Output(calldepth int, s string) error {
+	slog.Debug("slack api", "message", strings.TrimSpace(s))
+	return nil
+}
+
+// postBlocks posts a message made of blocks and logs the call
+func postBlocks(api *slack.Client, channel string, blocks []slack.Block) error {
+	start := time.Now()
+	_, timestamp, err := api.PostMessage(
+		channel,
+		slack.MsgOptionBlocks(blocks...),
+		slack.MsgOptionAsUser(true),
+	)
+
+	attrs := []any{"method", "chat.postMessage", "channel", channel, "blocks", len(blocks), "duration", time.Since(start)}
+	if err != nil {
+		slog.Warn("slack api call failed", append(attrs, "error", err)...)
+		return err
+	}
+	slog.Info("slack api call", append(attrs, "ts", timestamp)...)
+	return nil
+}
@@ -0,0 +1,102 @@
+package slack
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/econron/wamon/internal/i18n"
+	"github.com/econron/wamon/internal/report"
+	"github.com/slack-go/slack"
+)
+
+// Formats a report can be rendered in without sending it
+const (
+	FormatBlocksJSON = "blocks-json" // the payload posted to Slack, which Block Kit Builder accepts
+	FormatMarkdown   = "markdown"
+	FormatText       = "text"
+)
+
+// Formats lists the formats RenderReport accepts
+var Formats = []string{FormatBlocksJSON, FormatMarkdown, FormatText}
+
+// boldPattern matches bold text in Slack mrkdwn
+var boldPattern = regexp.MustCompile(`\*([^*\n]+)\*`)
+
+// RenderReport renders the message SendReport posts for a report. The Markdown
+// and text formats are made from the same blocks, so they show the same content.
+func RenderReport(r *report.Report, format string) (string, error) {
+	blocks := buildReportBlocks(r)
+	switch format {
+	case FormatBlocksJSON:
+		data, err := json.MarshalIndent(map[string][]slack.Block{"blocks": blocks}, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data) + "\n", nil
+	case FormatMarkdown:
+		return renderBlocks(blocks, markdownBlock), nil
+	case FormatText:
+		return renderBlocks(blocks, textBlock), nil
+	default:
+		return "", errors.New(i18n.T("report.invalid_format", format, strings.Join(Formats, ", ")))
+	}
+}
+
+// renderBlocks renders each block and separates them with blank lines
+func renderBlocks(blocks []slack.Block, render func(slack.Block) string) string {
+	parts := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		if part := render(block); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "\n\n") + "\n"
+}
+
+// markdownBlock renders a block as Markdown
+func markdownBlock(block slack.Block) string {
+	switch b := block.(type) {
+	case *slack.HeaderBlock:
+		return "# " + b.Text.Text
+	case *slack.SectionBlock:
+		text := boldPattern.ReplaceAllString(b.Text.Text, "**$1**")
+		text = strings.ReplaceAll(text, "• ", "- ")
+		// Keep Slack's line breaks, which Markdown would join into one paragraph
+		return strings.ReplaceAll(text, "\n", "  \n")
+	case *slack.ContextBlock:
+		return "_" + contextText(b) + "_"
+	case *slack.DividerBlock:
+		return "---"
+	default:
+		return ""
+	}
+}
+
+// textBlock renders a block as plain text
+func textBlock(block slack.Block) string {
+	switch b := block.(type) {
+	case *slack.HeaderBlock:
+		return b.Text.Text
+	case *slack.SectionBlock:
+		return boldPattern.ReplaceAllString(b.Text.Text, "$1")
+	case *slack.ContextBlock:
+		return contextText(b)
+	case *slack.DividerBlock:
+		return "------------------------"
+	default:
+		return ""
+	}
+}
+
+// contextText returns the texts of a context block
+func contextText(block *slack.ContextBlock) string {
+	var texts []string
+	for _, element := range block.ContextElements.Elements {
+		if text, ok := element.(*slack.TextBlockObject); ok {
+			texts = append(texts, text.Text)
+		}
+	}
+	return strings.Join(texts, " ")
+}
@@ -0,0 +1,54 @@
+package slack
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderReportBlocksJSON(t *testing.T) {
+	output, err := RenderReport(testReport(), FormatBlocksJSON)
+	assert.NoError(t, err)
+
+	// The payload has the blocks SendReport posts
+	var payload struct {
+		Blocks []struct {
+			Type string `json:"type"`
+			Text struct {
+				Type string `json:"type"`
+				Text string `json:"text"`
+			} `json:"text"`
+		} `json:"blocks"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(output), &payload))
+	assert.Len(t, payload.Blocks, len(buildReportBlocks(testReport())))
+	assert.Equal(t, "header", payload.Blocks[0].Type)
+	assert.Equal(t, "🦭 ワモンアザラシの記録 2025/04/14〜2025/04/20 🦭", payload.Blocks[0].Text.Text)
+	assert.Equal(t, "mrkdwn", payload.Blocks[1].Text.Type)
+}
+
+func TestRenderReportMarkdown(t *testing.T) {
+	output, err := RenderReport(testReport(), FormatMarkdown)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(output, "# 🦭 ワモンアザラシの記録 2025/04/14〜2025/04/20 🦭\n\n"))
+	assert.Contains(t, output, "📝 記録 **3件**")
+	assert.Contains(t, output, "- 調べ物: **2件**")
+	assert.Contains(t, output, "\n---\n")
+}
+
+func TestRenderReportText(t *testing.T) {
+	output, err := RenderReport(testReport(), FormatText)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(output, "🦭 ワモンアザラシの記録 2025/04/14〜2025/04/20 🦭\n\n"))
+	assert.Contains(t, output, "📝 記録 3件（活動 2日）")
+	assert.Contains(t, output, "• 調べ物: 2件")
+	assert.NotContains(t, output, "*")
+}
+
+func TestRenderReportUnknownFormat(t *testing.T) {
+	_, err := RenderReport(testReport(), "html")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "html")
+}
@@ -0,0 +1,145 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/econron/wamon/internal/i18n"
+	"github.com/econron/wamon/internal/models"
+	"github.com/econron/wamon/internal/report"
+	"github.com/slack-go/slack"
+)
+
+// Slack refuses messages with more than 50 blocks and section texts longer than 3000 characters
+const (
+	maxDaySections = 35
+	maxSectionText = 3000
+)
+
+// buildReportBlocks renders a report as Block Kit blocks: the figures of the
+// period first, then the entries of each day, oldest first.
+func buildReportBlocks(r *report.Report) []slack.Block {
+	label := r.Period.Label()
+	blocks := []slack.Block{
+		slack.NewHeaderBlock(slack.NewTextBlockObject("plain_text", i18n.T("slack.header", label), true, false)),
+		textSection(overviewText(r)),
+		textSection(categoryText(r)),
+	}
+	if len(r.Best) > 0 {
+		blocks = append(blocks, textSection(bestText(r)))
+	}
+	if text := trendText(r); text != "" {
+		blocks = append(blocks, textSection(text))
+	}
+	blocks = append(blocks, slack.NewDividerBlock())
+
+	// Keep the latest days when there are too many to fit in a message
+	days := r.Days
+	if len(days) > maxDaySections {
+		omitted := len(days) - maxDaySections
+		days = days[omitted:]
+		blocks = append(blocks, slack.NewContextBlock("",
+			slack.NewTextBlockObject("mrkdwn", i18n.T("slack.days_omitted", omitted), false, false)))
+	}
+	for _, day := range days {
+		blocks = append(blocks, textSection(dayText(day)))
+	}
+	blocks = append(blocks, slack.NewDividerBlock())
+
+	// Add streak
+	if r.Streak != nil {
+		blocks = append(blocks, textSection(i18n.T("slack.streak", r.Streak.Current, r.Streak.Longest)))
+	}
+
+	// Add summary footer
+	blocks = append(blocks, textSection(i18n.T("slack.summary", label, r.Total)))
+	return blocks
+}
+
+// textSection creates a section block with a mrkdwn text, shortened to the length Slack accepts
+func textSection(text string) *slack.SectionBlock {
+	if runes := []rune(text); len(runes) > maxSectionText {
+		text = string(runes[:maxSectionText-1]) + "…"
+	}
+	return slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", text, false, false), nil, nil)
+}
+
+// overviewText describes the figures of the period and how they changed since the previous one
+func overviewText(r *report.Report) string {
+	lines := []string{i18n.T("slack.overview", r.Total, r.ActiveDays, r.MeanSatisfaction)}
+	if previous := r.Previous; previous != nil {
+		lines = append(lines, i18n.T("slack.compare", previous.Period.Label(), previous.Total, r.Total, r.Total-previous.Total))
+		if previous.Total > 0 && r.Total > 0 {
+			lines = append(lines, i18n.T("slack.compare_satisfaction",
+				previous.MeanSatisfaction, r.MeanSatisfaction, r.MeanSatisfaction-previous.MeanSatisfaction))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// categoryText lists the number of entries of each category
+func categoryText(r *report.Report) string {
+	lines := []string{i18n.T("slack.by_category")}
+	for _, count := range r.Categories {
+		lines = append(lines, "• "+i18n.T("slack.category_count", i18n.CategoryLabel(count.Category), count.Count))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// bestText lists the best-rated entries
+func bestText(r *report.Report) string {
+	lines := []string{i18n.T("slack.best")}
+	for _, entry := range r.Best {
+		lines = append(lines, fmt.Sprintf("• %s %s (%s)", getStarRating(entry.Satisfaction), entry.Body(), report.FormatDay(entry.CreatedAt.Local())))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// trendText describes the busiest day and the parts of the day entries were recorded in.
+// The part with the most entries is shown in bold.
+func trendText(r *report.Report) string {
+	if r.BusiestDay == nil {
+		return ""
+	}
+	lines := []string{i18n.T("slack.busiest_day", report.FormatDay(r.BusiestDay.Date), len(r.BusiestDay.Entries))}
+
+	peak, _ := r.PeakTimeOfDay()
+	var parts []string
+	for _, count := range r.TimesOfDay {
+		part := i18n.T("slack.time_of_day_count", i18n.T("report.time_of_day."+string(count.TimeOfDay)), count.Count)
+		if count.TimeOfDay == peak {
+			part = "*" + part + "*"
+		}
+		parts = append(parts, part)
+	}
+	lines = append(lines, i18n.T("slack.times_of_day", strings.Join(parts, " / ")))
+	return strings.Join(lines, "\n")
+}
+
+// dayText shows the entries of a day under its date
+func dayText(day report.Day) string {
+	texts := []string{fmt.Sprintf("*%s*", report.FormatDay(day.Date))}
+	for _, entry := range day.Entries {
+		texts = append(texts, entryText(entry))
+	}
+	return strings.Join(texts, "\n\n")
+}
+
+// entryText shows the fields of an entry, one per line
+func entryText(entry *models.Entry) string {
+	var fieldTexts []string
+
+	fieldTexts = append(fieldTexts, i18n.T("slack.category", i18n.CategoryLabel(entry.Category)))
+
+	if entry.ResearchTopic != "" {
+		fieldTexts = append(fieldTexts, i18n.T("slack.research_topic", entry.ResearchTopic))
+	}
+
+	if entry.ProgramTitle != "" {
+		fieldTexts = append(fieldTexts, i18n.T("slack.program_title", entry.ProgramTitle))
+	}
+
+	fieldTexts = append(fieldTexts, i18n.T("slack.satisfaction", getStarRating(entry.Satisfaction)))
+
+	return strings.Join(fieldTexts, "\n")
+}
@@ -0,0 +1,110 @@
+package slack
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/econron/wamon/internal/models"
+	"github.com/econron/wamon/internal/report"
+	"github.com/econron/wamon/internal/stats"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+// sectionTexts returns the texts of the section blocks
+func sectionTexts(blocks []slack.Block) []string {
+	var texts []string
+	for _, block := range blocks {
+		if section, ok := block.(*slack.SectionBlock); ok {
+			texts = append(texts, section.Text.Text)
+		}
+	}
+	return texts
+}
+
+// testReport builds the report of the week of 2025-04-14 with entries on two days
+func testReport() *report.Report {
+	day := func(d, hour int) time.Time {
+		return time.Date(2025, 4, d, hour, 0, 0, 0, time.Local)
+	}
+	period := report.Current(report.KindWeek, day(16, 0), time.Monday)
+	r := report.Build(period, []*models.Entry{
+		{ID: "2", Category: models.Programming, ProgramTitle: "Program", Satisfaction: 5, CreatedAt: day(16, 20)},
+		{ID: "1", Category: models.Research, ResearchTopic: "Topic", Satisfaction: 3, CreatedAt: day(14, 9)},
+		{ID: "3", Category: models.Research, ResearchTopic: "Other", Satisfaction: 4, CreatedAt: day(16, 21)},
+	})
+	r.Compare([]*models.Entry{
+		{ID: "0", Category: models.Research, Satisfaction: 2, CreatedAt: day(8, 9)},
+	})
+	return r
+}
+
+// TestBuildReportBlocks tests the header, the figures and the order of the days
+func TestBuildReportBlocks(t *testing.T) {
+	blocks := buildReportBlocks(testReport())
+
+	header, ok := blocks[0].(*slack.HeaderBlock)
+	if assert.True(t, ok) {
+		assert.Equal(t, "🦭 ワモンアザラシの記録 2025/04/14〜2025/04/20 🦭", header.Text.Text)
+	}
+
+	text := strings.Join(sectionTexts(blocks), "\n")
+	assert.Contains(t, text, "記録 *3件*（活動 2日）・平均満足度 *4.0*")
+	assert.Contains(t, text, "前の期間 (2025/04/07〜2025/04/13) との比較: 記録 1件 → 3件 (+2)")
+	assert.Contains(t, text, "平均満足度 2.0 → 4.0 (+2.0)")
+	assert.Contains(t, text, "調べ物: *2件*")
+	assert.Contains(t, text, "★★★★★ Program (2025/04/16 (水))")
+	assert.Contains(t, text, "最も記録が多かった日: *2025/04/16 (水)* (2件)")
+	assert.Contains(t, text, "*夕方 2件*")
+	assert.Contains(t, text, "2025/04/20 は合計 *3件*")
+
+	// Days are shown oldest first
+	first := strings.Index(text, "*2025/04/14 (月)*")
+	second := strings.Index(text, "*2025/04/16 (水)*\n")
+	assert.True(t, first >= 0 && second > first, "days should be in chronological order")
+	assert.Less(t, strings.Index(text, "Program"), strings.Index(text, "Other"))
+}
+
+// TestBuildReportBlocksStreak tests that the streak section is only added when given
+func TestBuildReportBlocksStreak(t *testing.T) {
+	r := testReport()
+	withoutStreak := buildReportBlocks(r)
+
+	r.Streak = &stats.Streak{Current: 3, Longest: 7}
+	blocks := buildReportBlocks(r)
+	assert.Len(t, blocks, len(withoutStreak)+1)
+	streakBlock, ok := blocks[len(blocks)-2].(*slack.SectionBlock)
+	if assert.True(t, ok) {
+		assert.Contains(t, streakBlock.Text.Text, "現在 *3日*")
+		assert.Contains(t, streakBlock.Text.Text, "最長 *7日*")
+	}
+}
+
+// TestBuildReportBlocksLimits tests that long reports still fit in a Slack message
+func TestBuildReportBlocksLimits(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.Local)
+	var entries []*models.Entry
+	for i := 0; i < 90; i++ {
+		entries = append(entries, &models.Entry{ID: "e", Category: models.Research, ResearchTopic: strings.Repeat("a", 100), Satisfaction: 3, CreatedAt: start.AddDate(0, 0, i)})
+	}
+	for i := 0; i < 40; i++ {
+		entries = append(entries, &models.Entry{ID: "f", Category: models.Research, ResearchTopic: strings.Repeat("b", 100), Satisfaction: 3, CreatedAt: start})
+	}
+	r := report.Build(report.Current(report.KindQuarter, start, time.Monday), entries)
+	r.Streak = &stats.Streak{Current: 1, Longest: 1}
+
+	blocks := buildReportBlocks(r)
+	assert.LessOrEqual(t, len(blocks), 50)
+	for _, text := range sectionTexts(blocks) {
+		assert.LessOrEqual(t, len([]rune(text)), maxSectionText)
+	}
+
+	var context string
+	for _, block := range blocks {
+		if c, ok := block.(*slack.ContextBlock); ok {
+			context = c.ContextElements.Elements[0].(*slack.TextBlockObject).Text
+		}
+	}
+	assert.Contains(t, context, "55日分は省略しました")
+}
@@ -0,0 +1,131 @@
+package stats
+
+import (
+	"bufio"
+	"embed"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/econron/wamon/internal/i18n"
+)
+
+//go:embed holidays/*.txt
+var builtinHolidays embed.FS
+
+// Calendar decides which days are expected to have an entry.
+// A nil Calendar treats every day as a working day.
+type Calendar struct {
+	NonWorkingDays map[time.Weekday]bool
+	Holidays       map[string]string // "2006-01-02" -> holiday name
+}
+
+// weekdayNames maps the accepted config spellings to weekdays
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday, "日": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday, "月": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday, "火": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday, "水": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday, "木": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday, "金": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday, "土": time.Saturday,
+}
+
+// NewCalendar creates a calendar with the given non-working weekdays
+// (e.g. "sat", "sunday", "日") and no holidays
+func NewCalendar(nonWorkingDays []string) (*Calendar, error) {
+	cal := &Calendar{
+		NonWorkingDays: make(map[time.Weekday]bool),
+		Holidays:       make(map[string]string),
+	}
+	for _, name := range nonWorkingDays {
+		day, err := ParseWeekday(name)
+		if err != nil {
+			return nil, err
+		}
+		cal.NonWorkingDays[day] = true
+	}
+	return cal, nil
+}
+
+// ParseWeekday parses a weekday name such as "mon", "Monday" or "月"
+func ParseWeekday(name string) (time.Weekday, error) {
+	day, ok := weekdayNames[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return 0, errors.New(i18n.T("stats.invalid_weekday", name))
+	}
+	return day, nil
+}
+
+// IsWorkingDay reports whether an entry is expected on the day of t
+func (c *Calendar) IsWorkingDay(t time.Time) bool {
+	if c == nil {
+		return true
+	}
+	local := t.Local()
+	if c.NonWorkingDays[local.Weekday()] {
+		return false
+	}
+	_, holiday := c.Holidays[local.Format("2006-01-02")]
+	return !holiday
+}
+
+// HolidaysUntil returns the last year the holidays of the calendar reach, or 0
+// when none are loaded. Holidays after that year are not known.
+func (c *Calendar) HolidaysUntil() int {
+	if c == nil {
+		return 0
+	}
+	last := 0
+	for date := range c.Holidays {
+		if day, err := time.Parse("2006-01-02", date); err == nil && day.Year() > last {
+			last = day.Year()
+		}
+	}
+	return last
+}
+
+// LoadBuiltinHolidays adds one of the holiday lists shipped with wamon (e.g. "jp")
+func (c *Calendar) LoadBuiltinHolidays(name string) error {
+	file, err := builtinHolidays.Open("holidays/" + name + ".txt")
+	if err != nil {
+		return errors.New(i18n.T("stats.unknown_holidays", name))
+	}
+	defer file.Close()
+	return c.readHolidays(file)
+}
+
+// LoadHolidayFile adds the holidays listed in a local file.
+// Each line is "YYYY-MM-DD name"; blank lines and lines starting with # are ignored.
+func (c *Calendar) LoadHolidayFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return c.readHolidays(file)
+}
+
+// readHolidays parses a holiday list
+func (c *Calendar) readHolidays(r io.Reader) error {
+	if c.Holidays == nil {
+		c.Holidays = make(map[string]string)
+	}
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		date, name, _ := strings.Cut(line, " ")
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return errors.New(i18n.T("stats.invalid_holiday_date", lineNo, date))
+		}
+		c.Holidays[date] = strings.TrimSpace(name)
+	}
+	return scanner.Err()
+}
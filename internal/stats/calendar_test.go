@@ -0,0 +1,102 @@
+package stats
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/econron/wamon/internal/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNilCalendarIsAlwaysWorking(t *testing.T) {
+	var cal *Calendar
+	assert.True(t, cal.IsWorkingDay(time.Date(2025, 4, 5, 0, 0, 0, 0, time.Local)))
+}
+
+func TestNewCalendar(t *testing.T) {
+	cal, err := NewCalendar([]string{"sat", "日"})
+	assert.NoError(t, err)
+	assert.False(t, cal.IsWorkingDay(time.Date(2025, 4, 5, 12, 0, 0, 0, time.Local))) // Saturday
+	assert.False(t, cal.IsWorkingDay(time.Date(2025, 4, 6, 12, 0, 0, 0, time.Local))) // Sunday
+	assert.True(t, cal.IsWorkingDay(time.Date(2025, 4, 7, 12, 0, 0, 0, time.Local)))  // Monday
+
+	_, err = NewCalendar([]string{"someday"})
+	assert.Error(t, err)
+}
+
+func TestParseWeekday(t *testing.T) {
+	for _, name := range []string{"mon", "Monday", " MON ", "月"} {
+		day, err := ParseWeekday(name)
+		assert.NoError(t, err, name)
+		assert.Equal(t, time.Monday, day, name)
+	}
+
+	_, err := ParseWeekday("someday")
+	assert.Error(t, err)
+}
+
+func TestLoadBuiltinHolidays(t *testing.T) {
+	cal, err := NewCalendar(nil)
+	assert.NoError(t, err)
+	assert.NoError(t, cal.LoadBuiltinHolidays("jp"))
+
+	assert.Equal(t, "元日", cal.Holidays["2025-01-01"])
+	assert.False(t, cal.IsWorkingDay(time.Date(2025, 5, 5, 9, 0, 0, 0, time.Local)))
+	assert.True(t, cal.IsWorkingDay(time.Date(2025, 5, 7, 9, 0, 0, 0, time.Local)))
+
+	assert.Error(t, cal.LoadBuiltinHolidays("atlantis"))
+}
+
+func TestCalendarErrorsEnglish(t *testing.T) {
+	assert.NoError(t, i18n.SetLanguage("en"))
+	defer i18n.SetLanguage(string(i18n.Default))
+
+	_, err := ParseWeekday("someday")
+	assert.EqualError(t, err, "Unknown weekday: someday")
+
+	cal, err := NewCalendar(nil)
+	assert.NoError(t, err)
+	assert.EqualError(t, cal.LoadBuiltinHolidays("atlantis"), "No built-in holiday list named atlantis")
+
+	path := filepath.Join(t.TempDir(), "holidays.txt")
+	assert.NoError(t, os.WriteFile(path, []byte("# days off\n2025/12/31 New Year's Eve\n"), 0644))
+	assert.EqualError(t, cal.LoadHolidayFile(path), "Invalid date on line 2 of the holiday list: 2025/12/31")
+}
+
+func TestHolidaysUntil(t *testing.T) {
+	var nilCalendar *Calendar
+	assert.Equal(t, 0, nilCalendar.HolidaysUntil())
+
+	cal, err := NewCalendar(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, cal.HolidaysUntil())
+
+	assert.NoError(t, cal.LoadBuiltinHolidays("jp"))
+	assert.Equal(t, 2027, cal.HolidaysUntil())
+
+	// A holiday file can reach further
+	cal.Holidays["2028-01-03"] = "年始休暇"
+	assert.Equal(t, 2028, cal.HolidaysUntil())
+}
+
+func TestLoadHolidayFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "holidays.txt")
+	content := "# company holidays\n\n2025-12-29 年末休暇\n2025-12-30 年末休暇\n"
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	cal, err := NewCalendar(nil)
+	assert.NoError(t, err)
+	assert.NoError(t, cal.LoadHolidayFile(path))
+	assert.Len(t, cal.Holidays, 2)
+	assert.Equal(t, "年末休暇", cal.Holidays["2025-12-29"])
+
+	// Malformed dates are reported with their line number
+	assert.NoError(t, os.WriteFile(path, []byte("2025/12/31 大晦日\n"), 0644))
+	err = cal.LoadHolidayFile(path)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "1行目")
+
+	assert.Error(t, cal.LoadHolidayFile(filepath.Join(t.TempDir(), "missing.txt")))
+}
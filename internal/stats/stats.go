@@ -0,0 +1,165 @@
+package stats
+
+import (
+	"sort"
+	"time"
+
+	"github.com/econron/wamon/internal/models"
+)
+
+// DefaultTopN is the number of top entries kept when Options.TopN is not set
+const DefaultTopN = 3
+
+// Options controls how a Summary is computed
+type Options struct {
+	Since time.Time // start of the period (inclusive)
+	Until time.Time // end of the period (exclusive), also used as "now" for streaks
+	TopN  int       // number of top entries to keep
+	// Calendar marks the days off that don't break a streak; nil means every day counts
+	Calendar *Calendar
+}
+
+// Summary holds the metrics computed over a period
+type Summary struct {
+	Since              time.Time               `json:"since"`
+	Until              time.Time               `json:"until"`
+	Total              int                     `json:"total"`
+	CategoryCounts     map[models.Category]int `json:"category_counts"`
+	MeanSatisfaction   float64                 `json:"mean_satisfaction"`
+	MedianSatisfaction float64                 `json:"median_satisfaction"`
+	TopEntries         []*models.Entry         `json:"top_entries"`
+	HourOfDay          [24]int                 `json:"hour_of_day"`
+	Weekday            [7]int                  `json:"weekday"` // indexed by time.Weekday, Sunday first
+	ActiveDays         int                     `json:"active_days"`
+	Streak             Streak                  `json:"streak"`
+}
+
+// Streak holds consecutive-day activity counts
+type Streak struct {
+	Current int `json:"current"`
+	Longest int `json:"longest"`
+}
+
+// Compute calculates the summary for the entries that fall within the period
+func Compute(entries []*models.Entry, opts Options) *Summary {
+	if opts.Until.IsZero() {
+		opts.Until = time.Now()
+	}
+	if opts.TopN <= 0 {
+		opts.TopN = DefaultTopN
+	}
+
+	summary := &Summary{
+		Since:          opts.Since,
+		Until:          opts.Until,
+		CategoryCounts: make(map[models.Category]int),
+		TopEntries:     []*models.Entry{},
+	}
+
+	var inPeriod []*models.Entry
+	for _, entry := range entries {
+		if entry.CreatedAt.Before(opts.Since) || !entry.CreatedAt.Before(opts.Until) {
+			continue
+		}
+		inPeriod = append(inPeriod, entry)
+	}
+
+	summary.Total = len(inPeriod)
+	if summary.Total == 0 {
+		return summary
+	}
+
+	satisfactions := make([]int, 0, len(inPeriod))
+	days := make(map[string]struct{})
+	sum := 0
+	for _, entry := range inPeriod {
+		local := entry.CreatedAt.Local()
+		summary.CategoryCounts[entry.Category]++
+		summary.HourOfDay[local.Hour()]++
+		summary.Weekday[local.Weekday()]++
+		days[local.Format("2006-01-02")] = struct{}{}
+		satisfactions = append(satisfactions, entry.Satisfaction)
+		sum += entry.Satisfaction
+	}
+
+	summary.ActiveDays = len(days)
+	summary.MeanSatisfaction = float64(sum) / float64(len(satisfactions))
+	summary.MedianSatisfaction = median(satisfactions)
+	summary.TopEntries = TopEntries(inPeriod, opts.TopN)
+	summary.Streak = ComputeStreak(inPeriod, opts.Until, opts.Calendar)
+
+	return summary
+}
+
+// TopEntries returns the n best-rated entries, newest first among equal ratings
+func TopEntries(entries []*models.Entry, n int) []*models.Entry {
+	sorted := make([]*models.Entry, len(entries))
+	copy(sorted, entries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Satisfaction != sorted[j].Satisfaction {
+			return sorted[i].Satisfaction > sorted[j].Satisfaction
+		}
+		return sorted[i].CreatedAt.After(sorted[j].CreatedAt)
+	})
+	if len(sorted) > n {
+		sorted = sorted[:n]
+	}
+	return sorted
+}
+
+// ComputeStreak counts consecutive working days with at least one entry.
+// Days off in the calendar never break a streak, but entries made on them still count.
+// Today is not required to have an entry yet, so the current streak doesn't drop
+// to zero before the user has had a chance to log today.
+func ComputeStreak(entries []*models.Entry, now time.Time, cal *Calendar) Streak {
+	days := make(map[time.Time]struct{})
+	first := time.Time{}
+	for _, entry := range entries {
+		day := dayOf(entry.CreatedAt)
+		days[day] = struct{}{}
+		if first.IsZero() || day.Before(first) {
+			first = day
+		}
+	}
+	if len(days) == 0 {
+		return Streak{}
+	}
+
+	var streak Streak
+	today := dayOf(now)
+	run := 0
+	for day := first; !day.After(today); day = day.AddDate(0, 0, 1) {
+		if _, ok := days[day]; ok {
+			run++
+			if run > streak.Longest {
+				streak.Longest = run
+			}
+		} else if cal.IsWorkingDay(day) && !day.Equal(today) {
+			run = 0
+		}
+	}
+	streak.Current = run
+
+	return streak
+}
+
+// dayOf truncates t to midnight in the local time zone
+func dayOf(t time.Time) time.Time {
+	local := t.Local()
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// median returns the median of the values
+func median(values []int) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := make([]int, len(values))
+	copy(sorted, values)
+	sort.Ints(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return float64(sorted[mid-1]+sorted[mid]) / 2
+	}
+	return float64(sorted[mid])
+}
@@ -0,0 +1,105 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/econron/wamon/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// at returns a local time on the given April 2025 day
+func at(day, hour int) time.Time {
+	return time.Date(2025, 4, day, hour, 0, 0, 0, time.Local)
+}
+
+func TestCompute(t *testing.T) {
+	entries := []*models.Entry{
+		{ID: "1", Category: models.Research, Satisfaction: 2, CreatedAt: at(1, 9)},
+		{ID: "2", Category: models.Programming, Satisfaction: 5, CreatedAt: at(1, 21)},
+		{ID: "3", Category: models.Programming, Satisfaction: 4, CreatedAt: at(2, 9)},
+		{ID: "4", Category: models.ResearchAndProgram, Satisfaction: 5, CreatedAt: at(3, 14)},
+		{ID: "old", Category: models.Research, Satisfaction: 1, CreatedAt: at(1, 9).AddDate(0, -1, 0)},
+	}
+
+	summary := Compute(entries, Options{Since: at(1, 0), Until: at(4, 0), TopN: 2})
+
+	assert.Equal(t, 4, summary.Total)
+	assert.Equal(t, 1, summary.CategoryCounts[models.Research])
+	assert.Equal(t, 2, summary.CategoryCounts[models.Programming])
+	assert.Equal(t, 1, summary.CategoryCounts[models.ResearchAndProgram])
+	assert.InDelta(t, 4.0, summary.MeanSatisfaction, 0.001)
+	assert.InDelta(t, 4.5, summary.MedianSatisfaction, 0.001)
+	assert.Equal(t, 2, summary.HourOfDay[9])
+	assert.Equal(t, 1, summary.HourOfDay[21])
+	assert.Equal(t, 2, summary.Weekday[time.Tuesday]) // 2025-04-01 is a Tuesday
+	assert.Equal(t, 3, summary.ActiveDays)
+
+	// Top entries: highest rating first, newest first among ties
+	if assert.Len(t, summary.TopEntries, 2) {
+		assert.Equal(t, "4", summary.TopEntries[0].ID)
+		assert.Equal(t, "2", summary.TopEntries[1].ID)
+	}
+
+	assert.Equal(t, Streak{Current: 3, Longest: 3}, summary.Streak)
+}
+
+func TestComputeEmpty(t *testing.T) {
+	summary := Compute(nil, Options{Since: at(1, 0), Until: at(4, 0)})
+	assert.Equal(t, 0, summary.Total)
+	assert.Equal(t, 0.0, summary.MeanSatisfaction)
+	assert.Empty(t, summary.TopEntries)
+	assert.Equal(t, Streak{}, summary.Streak)
+}
+
+func TestComputeStreak(t *testing.T) {
+	entries := []*models.Entry{
+		{CreatedAt: at(1, 9)},
+		{CreatedAt: at(2, 9)},
+		{CreatedAt: at(3, 9)},
+		{CreatedAt: at(3, 22)},
+		// gap on the 4th
+		{CreatedAt: at(5, 9)},
+		{CreatedAt: at(6, 9)},
+	}
+
+	// Logged today
+	assert.Equal(t, Streak{Current: 2, Longest: 3}, ComputeStreak(entries, at(6, 23), nil))
+	// Not yet logged today, but yesterday counts
+	assert.Equal(t, Streak{Current: 2, Longest: 3}, ComputeStreak(entries, at(7, 8), nil))
+	// Missed a whole day
+	assert.Equal(t, Streak{Current: 0, Longest: 3}, ComputeStreak(entries, at(8, 8), nil))
+}
+
+func TestMedian(t *testing.T) {
+	assert.Equal(t, 0.0, median(nil))
+	assert.Equal(t, 3.0, median([]int{5, 1, 3}))
+	assert.Equal(t, 2.5, median([]int{4, 1, 3, 2}))
+}
+
+func TestComputeStreakWithCalendar(t *testing.T) {
+	// 2025-04-04 is a Friday, 2025-04-07 a Monday
+	entries := []*models.Entry{
+		{CreatedAt: at(3, 9)},
+		{CreatedAt: at(4, 9)},
+		{CreatedAt: at(7, 9)},
+	}
+
+	// Without a calendar the weekend breaks the streak
+	assert.Equal(t, Streak{Current: 1, Longest: 2}, ComputeStreak(entries, at(7, 20), nil))
+
+	// With weekends off it continues
+	cal, err := NewCalendar([]string{"sat", "Sunday"})
+	assert.NoError(t, err)
+	assert.Equal(t, Streak{Current: 3, Longest: 3}, ComputeStreak(entries, at(7, 20), cal))
+
+	// A weekend entry still counts
+	withSaturday := append(entries, &models.Entry{CreatedAt: at(5, 10)})
+	assert.Equal(t, Streak{Current: 4, Longest: 4}, ComputeStreak(withSaturday, at(7, 20), cal))
+
+	// A holiday on Tuesday doesn't break it either
+	cal.Holidays["2025-04-08"] = "テスト休日"
+	assert.Equal(t, Streak{Current: 3, Longest: 3}, ComputeStreak(entries, at(9, 8), cal))
+	delete(cal.Holidays, "2025-04-08")
+	assert.Equal(t, Streak{Current: 0, Longest: 3}, ComputeStreak(entries, at(9, 8), cal))
+}
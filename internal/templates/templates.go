@@ -0,0 +1,196 @@
+package templates
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/econron/wamon/internal/i18n"
+	"github.com/econron/wamon/internal/models"
+)
+
+// Extension is the file extension of user templates
+const Extension = ".md"
+
+// defaultTemplate is used when there is no user template for the category.
+// It produces the same skeleton the editor has always shown.
+const defaultTemplate = `{{t "editor.category" (category .Category)}}
+{{t "editor.date" (.Date.Format "2006-01-02 15:04")}}
+
+{{if .Research}}{{t "editor.research_topic"}}
+{{.Entry.ResearchTopic}}
+
+{{end}}{{if .Programming}}{{t "editor.program_title"}}
+{{.Entry.ProgramTitle}}
+
+{{end}}{{if .Entry.Satisfaction}}{{t "editor.satisfaction" .Entry.Satisfaction}}
+
+{{end}}{{t "editor.tags"}}
+{{join .Entry.Tags ", "}}
+`
+
+// todoPattern matches "TODO: ..." and "DONE: ..." items in entry text
+var todoPattern = regexp.MustCompile(`(?i)\b(TODO|DONE):\s*([^;]+)`)
+
+// Data is the value templates are executed with
+type Data struct {
+	Entry     *models.Entry   // the entry being written or edited
+	Category  models.Category // same as Entry.Category
+	Date      time.Time       // when the entry was created
+	Weekday   string          // localized name of the day of the week
+	Yesterday []*models.Entry // entries from the day before Date
+	TODOs     []string        // open TODO items from recent entries
+}
+
+// NewData builds the template data for entry from the entries recorded before it
+func NewData(entry *models.Entry, recent []*models.Entry) Data {
+	date := entry.CreatedAt.Local()
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	yesterday := day.AddDate(0, 0, -1)
+
+	data := Data{
+		Entry:    entry,
+		Category: entry.Category,
+		Date:     date,
+		Weekday:  i18n.T("weekday.long." + strconv.Itoa(int(date.Weekday()))),
+		TODOs:    OpenTODOs(recent),
+	}
+	for _, e := range recent {
+		if e.ID != entry.ID && !e.CreatedAt.Before(yesterday) && e.CreatedAt.Before(day) {
+			data.Yesterday = append(data.Yesterday, e)
+		}
+	}
+	return data
+}
+
+// Research reports whether the template should ask what was researched
+func (d Data) Research() bool {
+	return d.Category == models.Research || d.Category == models.ResearchAndProgram
+}
+
+// Programming reports whether the template should ask what program was written
+func (d Data) Programming() bool {
+	return d.Category == models.Programming || d.Category == models.ResearchAndProgram
+}
+
+// OpenTODOs returns the "TODO:" items of entries, oldest first,
+// leaving out the ones a later entry marked with "DONE:"
+func OpenTODOs(entries []*models.Entry) []string {
+	sorted := make([]*models.Entry, len(entries))
+	copy(sorted, entries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+	})
+
+	var todos []string
+	for _, entry := range sorted {
+		for _, text := range []string{entry.ResearchTopic, entry.ProgramTitle} {
+			for _, match := range todoPattern.FindAllStringSubmatch(text, -1) {
+				item := strings.TrimSpace(match[2])
+				todos = removeItem(todos, item)
+				if strings.EqualFold(match[1], "TODO") {
+					todos = append(todos, item)
+				}
+			}
+		}
+	}
+	return todos
+}
+
+// removeItem removes item from items, ignoring case
+func removeItem(items []string, item string) []string {
+	var result []string
+	for _, existing := range items {
+		if !strings.EqualFold(existing, item) {
+			result = append(result, existing)
+		}
+	}
+	return result
+}
+
+// Dir returns the directory user templates are read from
+func Dir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "templates"
+	}
+	return filepath.Join(home, ".wamon", "templates")
+}
+
+// Load returns the text of the named template in dir.
+// When name is empty, the template for the category is used if it exists,
+// and the built-in default otherwise.
+func Load(dir, name string, category models.Category) (string, error) {
+	if name != "" {
+		content, err := os.ReadFile(filepath.Join(dir, name+Extension))
+		if os.IsNotExist(err) {
+			return "", errors.New(i18n.T("template.not_found", name, dir))
+		}
+		if err != nil {
+			return "", errors.New(i18n.T("template.read_error", name, err))
+		}
+		return string(content), nil
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, string(category)+Extension))
+	if os.IsNotExist(err) {
+		return defaultTemplate, nil
+	}
+	if err != nil {
+		return "", errors.New(i18n.T("template.read_error", category, err))
+	}
+	return string(content), nil
+}
+
+// Render executes the template text with data
+func Render(text string, data Data) (string, error) {
+	tmpl, err := template.New("entry").Funcs(template.FuncMap{
+		"t":        i18n.T,
+		"category": i18n.CategoryLabel,
+		"join":     strings.Join,
+	}).Parse(text)
+	if err != nil {
+		return "", errors.New(i18n.T("template.parse_error", err))
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", errors.New(i18n.T("template.render_error", err))
+	}
+	return buf.String(), nil
+}
+
+// Build loads the named template from dir and renders it with data
+func Build(dir, name string, data Data) (string, error) {
+	text, err := Load(dir, name, data.Category)
+	if err != nil {
+		return "", err
+	}
+	return Render(text, data)
+}
+
+// List returns the names of the user templates in dir
+func List(dir string) ([]string, error) {
+	files, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, file := range files {
+		if !file.IsDir() && strings.HasSuffix(file.Name(), Extension) {
+			names = append(names, strings.TrimSuffix(file.Name(), Extension))
+		}
+	}
+	return names, nil
+}
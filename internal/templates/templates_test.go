@@ -0,0 +1,109 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/econron/wamon/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultTemplateForNewEntry(t *testing.T) {
+	entry := &models.Entry{ID: "1", Category: models.ResearchAndProgram, CreatedAt: time.Date(2025, 4, 1, 9, 30, 0, 0, time.Local)}
+
+	content, err := Build(t.TempDir(), "", NewData(entry, nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "カテゴリ: 調べてプログラマ\n日時: 2025-04-01 09:30\n\n調べたこと:\n\n\n書いたプログラム:\n\n\nタグ:\n\n", content)
+}
+
+func TestDefaultTemplateForExistingEntry(t *testing.T) {
+	entry := &models.Entry{
+		ID:            "1",
+		Category:      models.Research,
+		ResearchTopic: "Goのテンプレート",
+		Satisfaction:  4,
+		Tags:          []string{"go", "cli"},
+		CreatedAt:     time.Now(),
+	}
+
+	content, err := Build(t.TempDir(), "", NewData(entry, nil))
+	assert.NoError(t, err)
+	assert.Contains(t, content, "調べたこと:\nGoのテンプレート\n")
+	assert.Contains(t, content, "満足度: 4/5")
+	assert.Contains(t, content, "タグ:\ngo, cli\n")
+	assert.NotContains(t, content, "書いたプログラム:")
+}
+
+func TestCategoryTemplate(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "programming.md"), []byte("# {{.Date.Format \"2006-01-02\"}} ({{.Weekday}})\n{{t \"editor.program_title\"}}\n"), 0644))
+
+	entry := &models.Entry{ID: "1", Category: models.Programming, CreatedAt: time.Date(2025, 4, 7, 10, 0, 0, 0, time.Local)}
+	content, err := Build(dir, "", NewData(entry, nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "# 2025-04-07 (月曜日)\n書いたプログラム:\n", content)
+
+	// Other categories still use the built-in template
+	entry.Category = models.Research
+	content, err = Build(dir, "", NewData(entry, nil))
+	assert.NoError(t, err)
+	assert.Contains(t, content, "調べたこと:")
+}
+
+func TestNamedTemplate(t *testing.T) {
+	dir := t.TempDir()
+	standup := "昨日:\n{{range .Yesterday}}- {{.Body}}\n{{end}}TODO:\n{{range .TODOs}}- {{.}}\n{{end}}"
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "standup.md"), []byte(standup), 0644))
+
+	now := time.Date(2025, 4, 8, 9, 0, 0, 0, time.Local)
+	recent := []*models.Entry{
+		{ID: "a", Category: models.Research, ResearchTopic: "SQLite; TODO: インデックスを追加", CreatedAt: now.AddDate(0, 0, -2)},
+		{ID: "b", Category: models.Programming, ProgramTitle: "CLI; TODO: テストを書く", CreatedAt: now.AddDate(0, 0, -1)},
+		{ID: "c", Category: models.Programming, ProgramTitle: "DONE: インデックスを追加", CreatedAt: now.Add(-time.Hour)},
+	}
+	entry := &models.Entry{ID: "d", Category: models.Research, CreatedAt: now}
+
+	content, err := Build(dir, "standup", NewData(entry, recent))
+	assert.NoError(t, err)
+	assert.Equal(t, "昨日:\n- CLI; TODO: テストを書く\nTODO:\n- テストを書く\n", content)
+
+	_, err = Build(dir, "missing", NewData(entry, nil))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "missing")
+}
+
+func TestRenderErrors(t *testing.T) {
+	entry := &models.Entry{ID: "1", Category: models.Research, CreatedAt: time.Now()}
+
+	_, err := Render("{{.Unknown", NewData(entry, nil))
+	assert.Error(t, err)
+
+	_, err = Render("{{.Unknown}}", NewData(entry, nil))
+	assert.Error(t, err)
+}
+
+func TestOpenTODOs(t *testing.T) {
+	now := time.Now()
+	entries := []*models.Entry{
+		{ResearchTopic: "done: B", CreatedAt: now},
+		{ResearchTopic: "TODO: A; todo: B", CreatedAt: now.Add(-time.Hour)},
+	}
+	assert.Equal(t, []string{"A"}, OpenTODOs(entries))
+	assert.Nil(t, OpenTODOs(nil))
+}
+
+func TestList(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "standup.md"), []byte(""), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte(""), 0644))
+
+	names, err := List(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"standup"}, names)
+
+	names, err = List(filepath.Join(dir, "missing"))
+	assert.NoError(t, err)
+	assert.Empty(t, names)
+}
@@ -0,0 +1,418 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/econron/wamon/internal/db"
+	"github.com/econron/wamon/internal/i18n"
+	"github.com/econron/wamon/internal/models"
+	"github.com/econron/wamon/internal/stats"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// categories are the filter values cycled with the "c" key; "" means all categories
+var categories = append([]models.Category{""}, models.Categories()...)
+
+// satisfactionOptions are the choices of the satisfaction drop-down
+var satisfactionOptions = []string{"1", "2", "3", "4", "5"}
+
+// Browser is a full-screen entry browser.
+// All reads and writes go through the db.DB interface.
+type Browser struct {
+	db       db.DB
+	calendar *stats.Calendar
+
+	app     *tview.Application
+	pages   *tview.Pages
+	list    *tview.List
+	detail  *tview.TextView
+	sidebar *tview.TextView
+	search  *tview.InputField
+	status  *tview.TextView
+
+	entries  []*models.Entry // every entry, newest first
+	visible  []*models.Entry // entries matching the search and category filter
+	query    string
+	category models.Category
+}
+
+// NewBrowser creates a browser and loads the entries from the database.
+// The calendar is used for the streak in the stats sidebar and may be nil.
+func NewBrowser(database db.DB, calendar *stats.Calendar) (*Browser, error) {
+	b := &Browser{
+		db:       database,
+		calendar: calendar,
+		app:      tview.NewApplication(),
+		pages:    tview.NewPages(),
+		list:     tview.NewList().ShowSecondaryText(false),
+		detail:   tview.NewTextView().SetDynamicColors(true).SetWrap(true),
+		sidebar:  tview.NewTextView().SetDynamicColors(true),
+		search:   tview.NewInputField().SetLabel(i18n.T("tui.search")),
+		status:   tview.NewTextView().SetText(i18n.T("tui.help")).SetTextColor(tcell.ColorYellow),
+	}
+
+	b.list.SetBorder(true).SetTitle(i18n.T("tui.entries"))
+	b.detail.SetBorder(true).SetTitle(i18n.T("tui.detail"))
+	b.sidebar.SetBorder(true).SetTitle(i18n.T("tui.stats"))
+
+	b.list.SetChangedFunc(func(index int, _ string, _ string, _ rune) {
+		b.showDetail(index)
+	})
+	b.list.SetInputCapture(b.handleListKey)
+
+	// Incremental search: filter on every keystroke, return to the list on Enter/Esc
+	b.search.SetChangedFunc(func(text string) {
+		b.query = text
+		b.applyFilter()
+	})
+	b.search.SetDoneFunc(func(key tcell.Key) {
+		b.app.SetFocus(b.list)
+	})
+
+	main := tview.NewFlex().
+		AddItem(b.list, 0, 2, true).
+		AddItem(b.detail, 0, 3, false).
+		AddItem(b.sidebar, 30, 0, false)
+	layout := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(b.search, 1, 0, false).
+		AddItem(main, 0, 1, true).
+		AddItem(b.status, 1, 0, false)
+
+	b.pages.AddPage("main", layout, true, true)
+	b.app.SetRoot(b.pages, true)
+
+	if err := b.reload(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Run starts the browser and blocks until the user quits
+func (b *Browser) Run() error {
+	return b.app.Run()
+}
+
+// reload fetches every entry from the database and refreshes the panes
+func (b *Browser) reload() error {
+	entries, err := b.db.GetAllEntries()
+	if err != nil {
+		return err
+	}
+	b.entries = entries
+	b.applyFilter()
+	return nil
+}
+
+// applyFilter recomputes the visible entries and redraws the list, detail and sidebar
+func (b *Browser) applyFilter() {
+	b.visible = filterEntries(b.entries, b.query, b.category)
+
+	current := b.list.GetCurrentItem()
+	b.list.Clear()
+	for _, entry := range b.visible {
+		b.list.AddItem(tview.Escape(listLabel(entry)), "", 0, nil)
+	}
+	if current >= len(b.visible) {
+		current = len(b.visible) - 1
+	}
+	if current >= 0 {
+		b.list.SetCurrentItem(current)
+	}
+
+	b.showDetail(b.list.GetCurrentItem())
+	b.updateSidebar()
+
+	title := i18n.T("tui.entries")
+	if b.category != "" {
+		title = i18n.T("tui.entries_filtered", i18n.CategoryLabel(b.category))
+	}
+	b.list.SetTitle(fmt.Sprintf("%s(%d/%d) ", title, len(b.visible), len(b.entries)))
+}
+
+// filterEntries returns the entries matching the search query and category.
+// The query is matched case-insensitively against the ID, the text fields and the tags.
+func filterEntries(entries []*models.Entry, query string, category models.Category) []*models.Entry {
+	query = strings.ToLower(strings.TrimSpace(query))
+	var result []*models.Entry
+	for _, entry := range entries {
+		if category != "" && entry.Category != category {
+			continue
+		}
+		if query != "" {
+			haystack := strings.ToLower(entry.ID + " " + entry.ResearchTopic + " " + entry.ProgramTitle + " " + strings.Join(entry.Tags, " "))
+			if !strings.Contains(haystack, query) {
+				continue
+			}
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+// listLabel is the one-line summary of an entry in the list pane
+func listLabel(entry *models.Entry) string {
+	return fmt.Sprintf("%s %s %s", entry.CreatedAt.Format("01/02 15:04"), strings.Repeat("★", entry.Satisfaction), entry.Body())
+}
+
+// selected returns the entry under the cursor, or nil if the list is empty
+func (b *Browser) selected() *models.Entry {
+	index := b.list.GetCurrentItem()
+	if index < 0 || index >= len(b.visible) {
+		return nil
+	}
+	return b.visible[index]
+}
+
+// showDetail renders the entry at index in the detail pane
+func (b *Browser) showDetail(index int) {
+	if index < 0 || index >= len(b.visible) {
+		b.detail.SetText(i18n.T("list.empty"))
+		return
+	}
+	entry := b.visible[index]
+
+	var sb strings.Builder
+	sb.WriteString(i18n.T("tui.detail.id", entry.ID) + "\n")
+	sb.WriteString(i18n.T("tui.detail.created_at", entry.CreatedAt.Format("2006-01-02 15:04")) + "\n")
+	if entry.UpdatedAt != nil {
+		sb.WriteString(i18n.T("tui.detail.updated_at", entry.UpdatedAt.Format("2006-01-02 15:04")) + "\n")
+	}
+	sb.WriteString(i18n.T("tui.detail.category", tview.Escape(i18n.CategoryLabel(entry.Category))) + "\n")
+	if entry.ResearchTopic != "" {
+		fmt.Fprintf(&sb, "\n%s\n%s\n", i18n.T("tui.detail.research_topic"), tview.Escape(entry.ResearchTopic))
+	}
+	if entry.ProgramTitle != "" {
+		fmt.Fprintf(&sb, "\n%s\n%s\n", i18n.T("tui.detail.program_title"), tview.Escape(entry.ProgramTitle))
+	}
+	sb.WriteString("\n" + i18n.T("tui.detail.satisfaction", entry.Satisfaction) + "\n")
+	if len(entry.Tags) > 0 {
+		sb.WriteString(i18n.T("tui.detail.tags", tview.Escape(strings.Join(entry.Tags, ", "))) + "\n")
+	}
+	b.detail.SetText(sb.String())
+	b.detail.ScrollToBeginning()
+}
+
+// updateSidebar renders the stats of the visible entries
+func (b *Browser) updateSidebar() {
+	now := time.Now()
+	summary := stats.Compute(b.visible, stats.Options{Until: now.Add(time.Second), Calendar: b.calendar})
+	streak := stats.ComputeStreak(b.entries, now, b.calendar)
+
+	var sb strings.Builder
+	sb.WriteString(i18n.T("tui.sidebar.total", summary.Total) + "\n")
+	sb.WriteString(i18n.T("tui.sidebar.mean", summary.MeanSatisfaction) + "\n")
+	sb.WriteString(i18n.T("tui.sidebar.streak", streak.Current) + "\n")
+	sb.WriteString(i18n.T("tui.sidebar.longest", streak.Longest) + "\n\n")
+	for _, category := range categories[1:] {
+		fmt.Fprintf(&sb, "%s: %d\n", tview.Escape(i18n.CategoryLabel(category)), summary.CategoryCounts[category])
+	}
+	b.sidebar.SetText(sb.String())
+}
+
+// handleListKey implements the key bindings of the list pane
+func (b *Browser) handleListKey(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Key() {
+	case tcell.KeyEnter:
+		b.openForm(b.selected())
+		return nil
+	case tcell.KeyEsc:
+		b.app.Stop()
+		return nil
+	case tcell.KeyRune:
+		switch event.Rune() {
+		case '/':
+			b.app.SetFocus(b.search)
+		case 'c':
+			b.cycleCategory()
+		case 'a':
+			b.openForm(nil)
+		case 'e':
+			b.openForm(b.selected())
+		case 'd':
+			b.confirmDelete(b.selected())
+		case 'r':
+			if err := b.reload(); err != nil {
+				b.setStatus(i18n.T("tui.reload_error", err))
+			}
+		case 'q':
+			b.app.Stop()
+		case 'j':
+			return tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone)
+		case 'k':
+			return tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone)
+		default:
+			return event
+		}
+		return nil
+	}
+	return event
+}
+
+// cycleCategory switches the category filter to the next category
+func (b *Browser) cycleCategory() {
+	for i, category := range categories {
+		if category == b.category {
+			b.category = categories[(i+1)%len(categories)]
+			break
+		}
+	}
+	b.applyFilter()
+}
+
+// setStatus shows a message in the status bar
+func (b *Browser) setStatus(message string) {
+	b.status.SetText(" " + message + " |" + i18n.T("tui.help"))
+}
+
+// confirmDelete asks before deleting the entry
+func (b *Browser) confirmDelete(entry *models.Entry) {
+	if entry == nil {
+		return
+	}
+	modal := tview.NewModal().
+		SetText(i18n.T("tui.confirm_delete", entry.ID, entry.Body())).
+		AddButtons([]string{i18n.T("tui.delete"), i18n.T("tui.cancel")}).
+		SetDoneFunc(func(buttonIndex int, _ string) {
+			b.pages.RemovePage("confirm")
+			b.app.SetFocus(b.list)
+			if buttonIndex == 0 {
+				b.deleteEntry(entry)
+			}
+		})
+	b.pages.AddPage("confirm", modal, true, true)
+}
+
+// deleteEntry removes the entry from the database and refreshes the list
+func (b *Browser) deleteEntry(entry *models.Entry) {
+	if err := b.db.DeleteEntry(entry.ID); err != nil {
+		b.setStatus(i18n.T("tui.delete_error", err))
+		return
+	}
+	if err := b.reload(); err != nil {
+		b.setStatus(i18n.T("tui.reload_error", err))
+		return
+	}
+	b.setStatus(i18n.T("tui.deleted", entry.ID))
+}
+
+// openForm shows the add form, or the edit form when entry is not nil
+func (b *Browser) openForm(entry *models.Entry) {
+	isNew := entry == nil
+	values := &models.Entry{Category: models.Research, Satisfaction: 3}
+	if !isNew {
+		copied := *entry
+		values = &copied
+	}
+
+	categoryIndex := 0
+	for i, category := range categories[1:] {
+		if category == values.Category {
+			categoryIndex = i
+		}
+	}
+
+	form := tview.NewForm()
+	var categoryOptions []string
+	for _, category := range categories[1:] {
+		categoryOptions = append(categoryOptions, i18n.CategoryLabel(category))
+	}
+	form.AddDropDown(i18n.T("tui.form.category"), categoryOptions, categoryIndex, func(_ string, index int) {
+		values.Category = categories[index+1]
+	})
+	form.AddInputField(i18n.T("tui.form.research_topic"), values.ResearchTopic, 50, nil, func(text string) {
+		values.ResearchTopic = text
+	})
+	form.AddInputField(i18n.T("tui.form.program_title"), values.ProgramTitle, 50, nil, func(text string) {
+		values.ProgramTitle = text
+	})
+	form.AddInputField(i18n.T("tui.form.tags"), strings.Join(values.Tags, ", "), 50, nil, func(text string) {
+		values.Tags = models.ParseTags(text)
+	})
+	form.AddDropDown(i18n.T("tui.form.satisfaction"), satisfactionOptions, values.Satisfaction-1, func(_ string, index int) {
+		values.Satisfaction = index + 1
+	})
+
+	form.AddButton(i18n.T("tui.save"), func() {
+		b.submitForm(values, isNew)
+	})
+	form.AddButton(i18n.T("tui.cancel"), b.closeForm)
+	form.SetCancelFunc(b.closeForm)
+
+	title := i18n.T("tui.add_title")
+	if !isNew {
+		title = i18n.T("tui.edit_title", entry.ID)
+	}
+	form.SetBorder(true).SetTitle(title)
+
+	b.pages.AddPage("form", centered(form, 70, 15), true, true)
+	b.app.SetFocus(form)
+}
+
+// submitForm saves the form values and closes the form. When the save fails the
+// form stays open with its values, so they can be fixed and saved again.
+func (b *Browser) submitForm(values *models.Entry, isNew bool) {
+	if err := b.saveEntry(values, isNew); err != nil {
+		b.setStatus(i18n.T("tui.save_error", err))
+		return
+	}
+	b.closeForm()
+}
+
+// closeForm removes the form and returns the focus to the list
+func (b *Browser) closeForm() {
+	b.pages.RemovePage("form")
+	b.app.SetFocus(b.list)
+}
+
+// saveEntry writes the form values to the database and refreshes the list
+func (b *Browser) saveEntry(entry *models.Entry, isNew bool) error {
+	// Only keep the text fields that belong to the category
+	if entry.Category == models.Research {
+		entry.ProgramTitle = ""
+	}
+	if entry.Category == models.Programming {
+		entry.ResearchTopic = ""
+	}
+	if strings.TrimSpace(entry.Body()) == "" {
+		return errors.New(i18n.T("tui.empty_body"))
+	}
+
+	var err error
+	if isNew {
+		// IDs are made like the CLI makes them, skipping those already in the database
+		entry.ID, entry.CreatedAt = models.NewID(time.Now(), func(id string) bool {
+			_, err := b.db.GetEntryByID(id)
+			return err == nil
+		})
+		err = b.db.SaveEntry(entry)
+	} else {
+		err = b.db.UpdateEntry(entry)
+	}
+	if err != nil {
+		return err
+	}
+
+	// The entry is saved even if the list cannot be refreshed
+	if err := b.reload(); err != nil {
+		b.setStatus(i18n.T("tui.reload_error", err))
+		return nil
+	}
+	b.setStatus(i18n.T("tui.saved", entry.ID))
+	return nil
+}
+
+// centered wraps a primitive so it is shown in the middle of the screen
+func centered(p tview.Primitive, width, height int) tview.Primitive {
+	return tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(p, height, 1, true).
+			AddItem(nil, 0, 1, false), width, 1, true).
+		AddItem(nil, 0, 1, false)
+}
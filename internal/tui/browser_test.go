@@ -0,0 +1,178 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/econron/wamon/internal/db"
+	"github.com/econron/wamon/internal/models"
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupBrowser creates a browser backed by an in-memory database with three entries
+func setupBrowser(t *testing.T) (*Browser, db.DB) {
+	database, err := db.NewDB(":memory:")
+	assert.NoError(t, err)
+	t.Cleanup(func() { database.Close() })
+
+	now := time.Now()
+	for _, e := range []*models.Entry{
+		{ID: "1", Category: models.Research, ResearchTopic: "Goのジェネリクス", Satisfaction: 4, CreatedAt: now.Add(-3 * time.Hour)},
+		{ID: "2", Category: models.Programming, ProgramTitle: "CLIツール", Satisfaction: 5, CreatedAt: now.Add(-2 * time.Hour)},
+		{ID: "3", Category: models.ResearchAndProgram, ResearchTopic: "tview", ProgramTitle: "Goの画面", Satisfaction: 3, CreatedAt: now.Add(-1 * time.Hour)},
+	} {
+		assert.NoError(t, database.SaveEntry(e))
+	}
+
+	browser, err := NewBrowser(database, nil)
+	assert.NoError(t, err)
+	return browser, database
+}
+
+func visibleIDs(b *Browser) []string {
+	ids := make([]string, len(b.visible))
+	for i, e := range b.visible {
+		ids[i] = e.ID
+	}
+	return ids
+}
+
+func TestNewBrowser(t *testing.T) {
+	b, _ := setupBrowser(t)
+
+	assert.Equal(t, []string{"3", "2", "1"}, visibleIDs(b))
+	assert.Equal(t, 3, b.list.GetItemCount())
+	assert.Contains(t, b.detail.GetText(true), "記録ID: 3")
+	assert.Contains(t, b.sidebar.GetText(true), "記録数: 3件")
+	assert.Contains(t, b.sidebar.GetText(true), "連続記録: 1日")
+}
+
+func TestFilterEntries(t *testing.T) {
+	b, _ := setupBrowser(t)
+
+	assert.Len(t, filterEntries(b.entries, "go", ""), 2)
+	assert.Len(t, filterEntries(b.entries, "GO", models.Research), 1)
+	assert.Len(t, filterEntries(b.entries, "", models.Programming), 1)
+	assert.Empty(t, filterEntries(b.entries, "rust", ""))
+}
+
+func TestIncrementalSearch(t *testing.T) {
+	b, _ := setupBrowser(t)
+
+	b.search.SetText("Go")
+	assert.Equal(t, []string{"3", "1"}, visibleIDs(b))
+	assert.Contains(t, b.sidebar.GetText(true), "記録数: 2件")
+
+	b.search.SetText("")
+	assert.Len(t, b.visible, 3)
+}
+
+func TestCycleCategoryKey(t *testing.T) {
+	b, _ := setupBrowser(t)
+	handler := b.list.GetInputCapture()
+
+	handler(tcell.NewEventKey(tcell.KeyRune, 'c', tcell.ModNone))
+	assert.Equal(t, models.Research, b.category)
+	assert.Equal(t, []string{"1"}, visibleIDs(b))
+
+	handler(tcell.NewEventKey(tcell.KeyRune, 'c', tcell.ModNone))
+	handler(tcell.NewEventKey(tcell.KeyRune, 'c', tcell.ModNone))
+	handler(tcell.NewEventKey(tcell.KeyRune, 'c', tcell.ModNone))
+	assert.Equal(t, models.Category(""), b.category)
+	assert.Len(t, b.visible, 3)
+}
+
+func TestDeleteEntry(t *testing.T) {
+	b, database := setupBrowser(t)
+
+	// "d" opens the confirmation dialog without deleting anything yet
+	b.list.GetInputCapture()(tcell.NewEventKey(tcell.KeyRune, 'd', tcell.ModNone))
+	assert.True(t, b.pages.HasPage("confirm"))
+	assert.Len(t, b.visible, 3)
+
+	b.deleteEntry(b.selected())
+	assert.Equal(t, []string{"2", "1"}, visibleIDs(b))
+	count, err := database.GetEntryCount()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Contains(t, b.status.GetText(true), "削除しました")
+}
+
+func TestSaveEntry(t *testing.T) {
+	b, database := setupBrowser(t)
+
+	// Add
+	err := b.saveEntry(&models.Entry{Category: models.Programming, ResearchTopic: "ignored", ProgramTitle: "新しいプログラム", Satisfaction: 2}, true)
+	assert.NoError(t, err)
+	assert.Len(t, b.visible, 4)
+	added := b.visible[0]
+	assert.Equal(t, "新しいプログラム", added.ProgramTitle)
+	assert.Empty(t, added.ResearchTopic, "text fields outside the category are dropped")
+
+	// Edit
+	edited := *b.visible[1]
+	edited.ResearchTopic = "tview入門"
+	assert.NoError(t, b.saveEntry(&edited, false))
+	stored, err := database.GetEntryByID(edited.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "tview入門", stored.ResearchTopic)
+
+	// Empty content is rejected
+	err = b.saveEntry(&models.Entry{Category: models.Research, Satisfaction: 3}, true)
+	assert.Error(t, err)
+}
+
+func TestOpenForm(t *testing.T) {
+	b, _ := setupBrowser(t)
+
+	b.list.GetInputCapture()(tcell.NewEventKey(tcell.KeyRune, 'e', tcell.ModNone))
+	assert.True(t, b.pages.HasPage("form"))
+
+	b.pages.RemovePage("form")
+	b.list.GetInputCapture()(tcell.NewEventKey(tcell.KeyRune, 'a', tcell.ModNone))
+	assert.True(t, b.pages.HasPage("form"))
+}
+
+func TestSubmitForm(t *testing.T) {
+	b, _ := setupBrowser(t)
+
+	// A failed save keeps the form open with its values
+	b.openForm(nil)
+	b.submitForm(&models.Entry{Category: models.Research, Satisfaction: 3}, true)
+	assert.True(t, b.pages.HasPage("form"))
+	assert.Contains(t, b.status.GetText(true), "保存エラー")
+	assert.Len(t, b.visible, 3)
+
+	b.submitForm(&models.Entry{Category: models.Research, ResearchTopic: "tcell", Satisfaction: 3}, true)
+	assert.False(t, b.pages.HasPage("form"))
+	assert.Len(t, b.visible, 4)
+}
+
+func TestSaveEntrySameSecond(t *testing.T) {
+	b, _ := setupBrowser(t)
+
+	// Entries added within the same second get their own IDs
+	first := &models.Entry{Category: models.Research, ResearchTopic: "a", Satisfaction: 3}
+	second := &models.Entry{Category: models.Research, ResearchTopic: "b", Satisfaction: 3}
+	assert.NoError(t, b.saveEntry(first, true))
+	assert.NoError(t, b.saveEntry(second, true))
+	assert.Len(t, first.ID, len(models.IDLayout))
+	assert.NotEqual(t, first.ID, second.ID)
+	assert.Len(t, b.visible, 5)
+}
+
+func TestEmptyBrowser(t *testing.T) {
+	database, err := db.NewDB(":memory:")
+	assert.NoError(t, err)
+	defer database.Close()
+
+	b, err := NewBrowser(database, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, b.selected())
+	assert.Contains(t, b.detail.GetText(true), "記録がありません")
+
+	// Editing and deleting with nothing selected is a no-op
+	b.confirmDelete(nil)
+	assert.False(t, b.pages.HasPage("confirm"))
+}